@@ -1,39 +1,146 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
 	"pp-jurassic-park-api/internal/api/handlers"
+	"pp-jurassic-park-api/internal/config"
 	"pp-jurassic-park-api/internal/db"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
 )
 
 func main() {
-	// Run DB Migration
-	err := db.Migrate()
+	cfg, args, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Configuration error: %v", err)
+	}
+
+	if cfg.Log.Level != "debug" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	dbConn, err := db.Connect(cfg.Database, cfg.Log.Level)
+	if err != nil {
+		log.Fatalf("Database connection error: %v", err)
+	}
+
+	if len(args) > 0 {
+		if err := runCommand(dbConn, args); err != nil {
+			log.Fatalf("%v", err)
+		}
+		return
+	}
+
+	// Refuse to serve requests against an outdated schema
+	err = db.CheckSchema(dbConn)
+	if err != nil {
+		log.Fatalf("Schema error: %v. Run `migrate up` first.", err)
+	}
+
+	sqlDB, err := dbConn.DB()
 	if err != nil {
-		log.Fatalf("Migration error: %v", err)
+		log.Fatalf("Database connection error: %v", err)
+	}
+
+	store := repository.NewGormStore(dbConn)
+	idempotencyKeys := service.NewIdempotencyService(store, time.Duration(cfg.Server.WriteTimeout))
+	cageService := service.NewCageService(store)
+	dinosaurService := service.NewDinosaurService(store)
+	cageHandler := handlers.NewCageHandler(cageService, idempotencyKeys)
+	dinosaurHandler := handlers.NewDinosaurHandler(dinosaurService, idempotencyKeys)
+	inventoryHandler := handlers.NewInventoryHandler(service.NewInventoryService(store))
+	debugHandler := handlers.NewDebugHandler(sqlDB)
+	graphQLHandler, err := handlers.NewGraphQLHandler(cageService, dinosaurService)
+	if err != nil {
+		log.Fatalf("GraphQL schema error: %v", err)
 	}
 
 	router := gin.Default()
+	router.Use(handlers.Actor())
 
-	// Cages API
-	router.GET("/cages", handlers.GetCages)
-	router.GET("/cages/:id", handlers.GetCage)
-	router.POST("/cages", handlers.CreateCage)
-	router.PATCH("/cages/:id", handlers.UpdateCagePowerStatus)
-	router.DELETE("/cages/:id", handlers.DeleteCage)
+	// Cages and dinosaurs API and inventory export and import, along with the debug API when enabled
+	routes := handlers.Routes(cageHandler, dinosaurHandler)
+	routes = append(routes, handlers.InventoryRoutes(inventoryHandler)...)
+	if cfg.Features.DebugEndpoints {
+		routes = append(routes, handlers.DebugRoutes(debugHandler)...)
+	}
+	handlers.RegisterRoutes(router, routes)
 
-	// Dinosaur API
-	router.GET("/dinosaurs", handlers.GetDinosaurs)
-	router.GET("/dinosaurs/:id", handlers.GetDinosaur)
-	router.POST("/dinosaurs", handlers.AddDinosaur)
-	router.PATCH("/dinosaurs/:id", handlers.MoveDinosaur)
-	router.DELETE("/dinosaurs/:id", handlers.RemoveDinosaur)
+	// API documentation
+	router.GET("/openapi.json", handlers.OpenAPI(routes))
+	router.GET("/docs/*path", handlers.Docs("/openapi.json"))
+
+	// GraphQL API over the same cages and dinosaurs
+	router.POST("/graphql", graphQLHandler.Query)
 
 	// Start server
-	err = router.Run()
+	server := &http.Server{
+		Addr:         cfg.Server.Addr,
+		Handler:      router,
+		ReadTimeout:  time.Duration(cfg.Server.ReadTimeout),
+		WriteTimeout: time.Duration(cfg.Server.WriteTimeout),
+		IdleTimeout:  time.Duration(cfg.Server.IdleTimeout),
+	}
+	go func() {
+		log.Printf("Listening on %s", cfg.Server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Error starting server: %v", err)
+		}
+	}()
+
+	// gRPC API over the same cages and dinosaurs, for internal systems
+	cageGRPCServer := handlers.NewCageGRPCServer(cageService, time.Duration(cfg.GRPC.WatchInterval))
+	grpcServer := handlers.NewGRPCServer(cageGRPCServer, handlers.NewDinosaurGRPCServer(dinosaurService))
+	listener, err := net.Listen("tcp", cfg.GRPC.Addr)
 	if err != nil {
-		log.Fatalf("Error starting server: %v", err)
+		log.Fatalf("Error starting gRPC server: %v", err)
+	}
+	go func() {
+		log.Printf("Serving gRPC on %s", cfg.GRPC.Addr)
+		if err := grpcServer.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			log.Fatalf("Error starting gRPC server: %v", err)
+		}
+	}()
+
+	// Wait for termination and let in-flight requests finish
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Server.ShutdownTimeout))
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Fatalf("Error shutting down server: %v", err)
+	}
+	cageGRPCServer.StopWatches()
+	stopGRPC(ctx, grpcServer)
+}
+
+// stopGRPC lets in-flight calls finish until the context is done, then cancels the remaining ones.
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
 	}
 }
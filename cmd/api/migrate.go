@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"pp-jurassic-park-api/internal/db"
+	"strconv"
+	"text/tabwriter"
+
+	"gorm.io/gorm"
+)
+
+const migrateUsage = "usage: migrate up | migrate down N | migrate status"
+
+// runCommand executes a subcommand instead of starting the server.
+func runCommand(dbConn *gorm.DB, args []string) error {
+	switch args[0] {
+	case "migrate":
+		return runMigrate(dbConn, args[1:])
+	default:
+		return fmt.Errorf("unknown command %q, %s", args[0], migrateUsage)
+	}
+}
+
+func runMigrate(dbConn *gorm.DB, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := db.MigrateUp(dbConn)
+		for _, migration := range applied {
+			fmt.Printf("Applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if err == nil && len(applied) == 0 {
+			fmt.Println("Schema is up to date")
+		}
+		return err
+	case "down":
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+		steps, err := strconv.Atoi(args[1])
+		if err != nil || steps <= 0 {
+			return fmt.Errorf("invalid number of migrations to roll back %q", args[1])
+		}
+		rolledBack, err := db.MigrateDown(dbConn, steps)
+		for _, migration := range rolledBack {
+			fmt.Printf("Rolled back %d_%s\n", migration.Version, migration.Name)
+		}
+		return err
+	case "status":
+		statuses, err := db.MigrationStatuses(dbConn)
+		if err != nil {
+			return err
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, status := range statuses {
+			if status.Applied {
+				fmt.Fprintf(writer, "%d\t%s\tapplied\t%s\n", status.Version, status.Name, status.AppliedAt.Format("2006-01-02 15:04:05 MST"))
+			} else {
+				fmt.Fprintf(writer, "%d\t%s\tpending\t\n", status.Version, status.Name)
+			}
+		}
+		return writer.Flush()
+	default:
+		return fmt.Errorf("unknown migrate command %q, %s", args[0], migrateUsage)
+	}
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"strings"
+
+	"pp-jurassic-park-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActorHeader names who makes the request. It is recorded in the history of every changed resource.
+const ActorHeader = "X-Actor"
+
+// Actor attributes the changes made by a request to the actor named in the X-Actor header.
+// Requests without the header are recorded as made by an anonymous actor.
+func Actor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if actor := strings.TrimSpace(c.GetHeader(ActorHeader)); actor != "" {
+			c.Request = c.Request.WithContext(service.WithActor(c.Request.Context(), actor))
+		}
+		c.Next()
+	}
+}
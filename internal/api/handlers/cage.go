@@ -3,88 +3,187 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"time"
 
 	apimodels "pp-jurassic-park-api/internal/api/models"
 	transform "pp-jurassic-park-api/internal/api/transform"
-	db "pp-jurassic-park-api/internal/db"
 	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
-// GetCage returns single cage for the requested id.
+// CageHandler serves the cages API.
+type CageHandler struct {
+	cages *service.CageService
+	keys  *service.IdempotencyService
+}
+
+func NewCageHandler(cages *service.CageService, keys *service.IdempotencyService) *CageHandler {
+	return &CageHandler{cages: cages, keys: keys}
+}
+
+// GetCage returns single cage for the requested id, restricted to the requested fields.
 // Used to retrieve data around single cage and its habitants at the Jurassic Park.
-func GetCage(c *gin.Context) {
+func (h *CageHandler) GetCage(c *gin.Context) {
+	view, ok := viewParams(c, apimodels.Cage{}, "dinosaurs")
+	if !ok {
+		return
+	}
+	if cage, ok := h.getCage(c, view, repository.CageRelations{Dinosaurs: view.selects("dinosaurs")}); ok {
+		respondWithView(c, "cage", apimodels.GetCageResponse{Cage: transform.CageToApi(cage)}, view)
+	}
+}
+
+// getCage loads the requested cage with the relations and sets the ETag of its view, for all versions of the API.
+// Responds with an error and returns false when the cage cannot be loaded, or with 304 when the client's copy
+// is still current.
+func (h *CageHandler) getCage(c *gin.Context, view recordView, relations repository.CageRelations) (dbmodels.Cage, bool) {
 	idParam := c.Param("id")
 	cageID, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid cage ID."})
-		return
+		respondWithValidationError(c, "id", "Invalid cage ID.")
+		return dbmodels.Cage{}, false
 	}
 
-	dbConn, err := db.Connect()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to connect to database."})
-		return
+	withDeleted, ok := includeDeleted(c)
+	if !ok {
+		return dbmodels.Cage{}, false
 	}
 
-	var cage dbmodels.Cage
-	if err := dbConn.Preload("Dinosaurs").First(&cage, cageID).Error; err != nil {
-		c.JSON(http.StatusNotFound, apimodels.ErrorResponse{Error: "Cage not found."})
-		return
+	cage, err := h.cages.GetCage(c.Request.Context(), uint(cageID), withDeleted, relations)
+	if err != nil {
+		respondWithError(c, err, "Failed to retrieve cage.")
+		return dbmodels.Cage{}, false
 	}
 
-	c.JSON(http.StatusOK, apimodels.GetCageResponse{Cage: transform.CageToApi(cage)})
+	etag := ""
+	if !cage.DeletedAt.Valid {
+		// Deleting the cage keeps its version, so only the modification time tells whether the client has seen it.
+		etag = setETag(c, viewETag(cageETag(cage), view))
+	}
+	if respondIfNotModified(c, etag, cageModified(cage)) {
+		return dbmodels.Cage{}, false
+	}
+	return cage, true
 }
 
-// GetDinosaurs returns all dinosaurs matching provided filters.
+// GetCages returns all cages matching provided filters, restricted to the requested fields.
+// Filters are read from the query string and, for backward compatibility, from an optional JSON body.
 // Used to retrieve data around all cages and their habitants at the Jurassic Park.
-func GetCages(c *gin.Context) {
-	var req apimodels.GetCagesRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid input."})
+func (h *CageHandler) GetCages(c *gin.Context) {
+	view, ok := viewParams(c, apimodels.Cage{}, "dinosaurs")
+	if !ok {
 		return
 	}
+	if page, ok := h.listCages(c, repository.CageRelations{Dinosaurs: view.selects("dinosaurs")}); ok {
+		respondWithPage(c, "cages", apimodels.GetCagesResponse{
+			Cages:      transform.CagesToApi(page.records),
+			NextCursor: page.nextCursor,
+			Total:      page.total,
+		}, view)
+	}
+}
+
+// listCages loads the requested page of cages with the relations, for all versions of the API.
+// Responds with an error and returns false when the cages cannot be loaded, or with 304 when the page
+// has not changed since If-Modified-Since.
+//
+// Last-Modified covers the cages read, along with their dinosaurs and the cage past the page deciding its cursor,
+// and the deletion of any cage matching the filters, which takes it off the page. With the total, it covers every
+// cage matching the filters. A cage changed so that it leaves the page otherwise is only told by the ETag of the page.
+func (h *CageHandler) listCages(c *gin.Context, relations repository.CageRelations) (listPage[dbmodels.Cage], bool) {
+	var req apimodels.GetCagesRequest
+	if !bindOptionalJSON(c, &req) {
+		return listPage[dbmodels.Cage]{}, false
+	}
 
-	dbConn, err := db.Connect()
+	withDeleted, ok := includeDeleted(c)
+	if !ok {
+		return listPage[dbmodels.Cage]{}, false
+	}
+
+	withTotal, ok := includeTotal(c)
+	if !ok {
+		return listPage[dbmodels.Cage]{}, false
+	}
+
+	page, ok := listPageParams(c, repository.CageSortColumns)
+	if !ok {
+		return listPage[dbmodels.Cage]{}, false
+	}
+
+	filter, ok := cageFilterParams(c)
+	if !ok {
+		return listPage[dbmodels.Cage]{}, false
+	}
+	for _, powerStatus := range req.FilteredPowerStatuses {
+		filter.PowerStatuses = append(filter.PowerStatuses, string(powerStatus))
+	}
+
+	cages, err := h.cages.ListCages(c.Request.Context(), filter, lookahead(page), withDeleted, relations)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to connect to database."})
-		return
+		respondWithError(c, err, "Failed to retrieve cages.")
+		return listPage[dbmodels.Cage]{}, false
 	}
 
-	var cages []dbmodels.Cage
-	if len(req.FilteredPowerStatuses) > 0 {
-		if err := dbConn.Preload("Dinosaurs").Where("power_status IN ?", req.FilteredPowerStatuses).Find(&cages).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to retrieve cages with filtered statuses."})
-			return
-		}
-	} else {
-		if err := dbConn.Preload("Dinosaurs").Find(&cages).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to retrieve cages."})
-			return
-		}
+	var others time.Time
+	switch {
+	case withTotal:
+		others, err = h.cages.LastModified(c.Request.Context(), filter)
+	case !withDeleted:
+		others, err = h.cages.LastDeleted(c.Request.Context(), filter)
+	}
+	if err != nil {
+		respondWithError(c, err, "Failed to retrieve cages.")
+		return listPage[dbmodels.Cage]{}, false
 	}
+	// The ETag of the page is only checked once the response has been encoded.
+	if respondIfNotModified(c, "", pageModified(cages, cageModified, others)) {
+		return listPage[dbmodels.Cage]{}, false
+	}
+	result := listPage[dbmodels.Cage]{}
+	result.records, result.nextCursor = nextPage(cages, page, repository.CageSortColumns)
 
-	c.JSON(http.StatusOK, apimodels.GetCagesResponse{Cages: transform.CagesToApi(cages)})
+	if withTotal {
+		total, err := h.cages.CountCages(c.Request.Context(), filter, withDeleted)
+		if err != nil {
+			respondWithError(c, err, "Failed to retrieve cages.")
+			return listPage[dbmodels.Cage]{}, false
+		}
+		result.total = &total
+	}
+	return result, true
 }
 
-// UpdateCagePowerStatus creates a new cage.
+// CreateCage creates a new cage, only once per Idempotency-Key.
 // Used to register a new cage at the Jurassic Park.
-func CreateCage(c *gin.Context) {
+func (h *CageHandler) CreateCage(c *gin.Context) {
+	idempotent(c, h.keys, func(c *gin.Context) {
+		if cage, ok := h.createCage(c); ok {
+			c.JSON(http.StatusOK, apimodels.CreateCageResponse{Cage: transform.CageToApi(cage)})
+		}
+	})
+}
+
+// createCage creates the requested cage and sets its ETag, for all versions of the API.
+// Responds with an error and returns false when the cage cannot be created.
+func (h *CageHandler) createCage(c *gin.Context) (dbmodels.Cage, bool) {
 	var req apimodels.CreateCageRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid input."})
-		return
+		respondWithValidationError(c, "", "Invalid input.")
+		return dbmodels.Cage{}, false
 	}
 
 	if req.PowerStatus != apimodels.Active && req.PowerStatus != apimodels.Down {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid power status."})
-		return
+		respondWithValidationError(c, "power_status", "Invalid power status.")
+		return dbmodels.Cage{}, false
 	}
 
 	if req.Capacity <= 0 {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Capacity should be greater than 0."})
-		return
+		respondWithValidationError(c, "capacity", "Capacity should be greater than 0.")
+		return dbmodels.Cage{}, false
 	}
 
 	cage := dbmodels.Cage{
@@ -92,95 +191,147 @@ func CreateCage(c *gin.Context) {
 		PowerStatus: string(req.PowerStatus),
 	}
 
-	dbConn, err := db.Connect()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to connect to database."})
-		return
+	if err := h.cages.CreateCage(c.Request.Context(), &cage); err != nil {
+		respondWithError(c, err, "Failed to create cage.")
+		return dbmodels.Cage{}, false
 	}
 
-	if err := dbConn.Create(&cage).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to create cage."})
-		return
-	}
+	setETag(c, cageETag(cage))
+	return cage, true
+}
 
-	c.JSON(http.StatusOK, apimodels.CreateCageResponse{Cage: transform.CageToApi(cage)})
+// UpdateCage changes the capacity or power status of a given cage, sent as JSON Merge Patch.
+// Used to control power and resize cages at the Jurassic Park.
+func (h *CageHandler) UpdateCage(c *gin.Context) {
+	if cage, ok := h.updateCage(c); ok {
+		c.JSON(http.StatusOK, apimodels.UpdateCageResponse{Cage: transform.CageToApi(cage)})
+	}
 }
 
-// UpdateCagePowerStatus sets power status for a given cage.
-// Used to control power of cages at the Jurassic Park.
-func UpdateCagePowerStatus(c *gin.Context) {
+// updateCage applies the requested patch to the cage and sets its ETag, for all versions of the API.
+// Responds with an error and returns false when the cage cannot be updated.
+func (h *CageHandler) updateCage(c *gin.Context) (dbmodels.Cage, bool) {
 	idParam := c.Param("id")
 	cageID, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid cage ID."})
-		return
+		respondWithValidationError(c, "id", "Invalid cage ID.")
+		return dbmodels.Cage{}, false
 	}
 
-	var req apimodels.UpdateCagePowerStatusRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid input."})
-		return
+	var req apimodels.UpdateCageRequest
+	if !bindMergePatch(c, &req, "capacity", "power_status") {
+		return dbmodels.Cage{}, false
 	}
 
-	if req.PowerStatus != apimodels.Active && req.PowerStatus != apimodels.Down {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid power status."})
-		return
+	update := service.CageUpdate{Capacity: req.Capacity}
+	if req.PowerStatus != nil {
+		if *req.PowerStatus != apimodels.Active && *req.PowerStatus != apimodels.Down {
+			respondWithValidationError(c, "power_status", "Invalid power status.")
+			return dbmodels.Cage{}, false
+		}
+		powerStatus := string(*req.PowerStatus)
+		update.PowerStatus = &powerStatus
 	}
 
-	dbConn, err := db.Connect()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to connect to database."})
-		return
+	if req.Capacity != nil && *req.Capacity <= 0 {
+		respondWithValidationError(c, "capacity", "Capacity should be greater than 0.")
+		return dbmodels.Cage{}, false
 	}
 
-	var cage dbmodels.Cage
-	if err := dbConn.First(&cage, cageID).Error; err != nil {
-		c.JSON(http.StatusNotFound, apimodels.ErrorResponse{Error: "Cage not found."})
-		return
+	expectedVersion, ok := ifMatchVersion(c)
+	if !ok {
+		return dbmodels.Cage{}, false
 	}
 
-	if cage.PowerStatus != string(req.PowerStatus) {
-		cage.PowerStatus = string(req.PowerStatus)
-		if err := dbConn.Save(&cage).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to update cage power status."})
-			return
-		}
+	cage, err := h.cages.UpdateCage(c.Request.Context(), uint(cageID), update, expectedVersion)
+	if err != nil {
+		respondWithError(c, err, "Failed to update cage.")
+		return dbmodels.Cage{}, false
 	}
 
-	c.JSON(http.StatusOK, apimodels.UpdateCagePowerStatusResponse{Cage: transform.CageToApi(cage)})
+	setETag(c, cageETag(cage))
+	return cage, true
 }
 
 // DeleteCage deletes the cage.
 // Used to remove all no longer needed cages at the Jurassic Park.
-func DeleteCage(c *gin.Context) {
+func (h *CageHandler) DeleteCage(c *gin.Context) {
 	idParam := c.Param("id")
 	cageID, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid cage ID."})
+		respondWithValidationError(c, "id", "Invalid cage ID.")
 		return
 	}
 
-	dbConn, err := db.Connect()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to connect to database."})
+	expectedVersion, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
 
-	var cage dbmodels.Cage
-	if err := dbConn.Preload("Dinosaurs").First(&cage, cageID).Error; err != nil {
-		c.JSON(http.StatusNotFound, apimodels.ErrorResponse{Error: "Cage not found."})
+	if err := h.cages.DeleteCage(c.Request.Context(), uint(cageID), expectedVersion); err != nil {
+		respondWithError(c, err, "Failed to delete cage.")
 		return
 	}
 
-	if len(cage.Dinosaurs) > 0 {
-		c.JSON(http.StatusConflict, apimodels.ErrorResponse{Error: "Cannot delete cage with dinosaurs inside."})
+	c.JSON(http.StatusOK, apimodels.DeleteCageResponse{})
+}
+
+// RestoreCage restores the deleted cage.
+// Used to bring back cages removed by mistake at the Jurassic Park.
+func (h *CageHandler) RestoreCage(c *gin.Context) {
+	if cage, ok := h.restoreCage(c); ok {
+		c.JSON(http.StatusOK, apimodels.RestoreCageResponse{Cage: transform.CageToApi(cage)})
+	}
+}
+
+// restoreCage restores the requested cage and sets its ETag, for all versions of the API.
+// Responds with an error and returns false when the cage cannot be restored.
+func (h *CageHandler) restoreCage(c *gin.Context) (dbmodels.Cage, bool) {
+	idParam := c.Param("id")
+	cageID, err := strconv.Atoi(idParam)
+	if err != nil {
+		respondWithValidationError(c, "id", "Invalid cage ID.")
+		return dbmodels.Cage{}, false
+	}
+
+	expectedVersion, ok := ifMatchVersion(c)
+	if !ok {
+		return dbmodels.Cage{}, false
+	}
+
+	cage, err := h.cages.RestoreCage(c.Request.Context(), uint(cageID), expectedVersion)
+	if err != nil {
+		respondWithError(c, err, "Failed to restore cage.")
+		return dbmodels.Cage{}, false
+	}
+
+	setETag(c, cageETag(cage))
+	return cage, true
+}
+
+// GetCageHistory returns the recorded changes of the cage, oldest first.
+// Used to audit who changed a cage at the Jurassic Park and when.
+func (h *CageHandler) GetCageHistory(c *gin.Context) {
+	idParam := c.Param("id")
+	cageID, err := strconv.Atoi(idParam)
+	if err != nil {
+		respondWithValidationError(c, "id", "Invalid cage ID.")
 		return
 	}
 
-	if err := dbConn.Delete(&cage).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to delete cage."})
+	limit, afterID, ok := pageParams(c)
+	if !ok {
 		return
 	}
 
-	c.JSON(http.StatusOK, apimodels.DeleteCageResponse{})
+	page, err := h.cages.GetCageHistory(c.Request.Context(), uint(cageID), afterID, limit)
+	if err != nil {
+		respondWithError(c, err, "Failed to retrieve cage history.")
+		return
+	}
+
+	c.JSON(http.StatusOK, apimodels.GetHistoryResponse{
+		History:    transform.HistoryToApi(page.Entries),
+		NextCursor: encodeCursor(page.NextAfterID),
+	})
 }
@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"database/sql"
+	"net/http"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	transform "pp-jurassic-park-api/internal/api/transform"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugHandler serves operational insights into the running API.
+type DebugHandler struct {
+	sqlDB *sql.DB
+}
+
+func NewDebugHandler(sqlDB *sql.DB) *DebugHandler {
+	return &DebugHandler{sqlDB: sqlDB}
+}
+
+// GetDBStats returns statistics of the shared database connection pool.
+// Used to tune pool limits and spot connection starvation.
+func (h *DebugHandler) GetDBStats(c *gin.Context) {
+	c.JSON(http.StatusOK, apimodels.GetDBStatsResponse{Stats: transform.DBStatsToApi(h.sqlDB.Stats())})
+}
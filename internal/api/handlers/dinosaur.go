@@ -1,218 +1,579 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	apimodels "pp-jurassic-park-api/internal/api/models"
 	transform "pp-jurassic-park-api/internal/api/transform"
-	db "pp-jurassic-park-api/internal/db"
 	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
 
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
-// GetDinosaur returns single dinosaur for the requested id.
+// DinosaurHandler serves the dinosaurs API.
+type DinosaurHandler struct {
+	dinosaurs *service.DinosaurService
+	keys      *service.IdempotencyService
+}
+
+func NewDinosaurHandler(dinosaurs *service.DinosaurService, keys *service.IdempotencyService) *DinosaurHandler {
+	return &DinosaurHandler{dinosaurs: dinosaurs, keys: keys}
+}
+
+// GetDinosaur returns single dinosaur for the requested id, restricted to the requested fields.
 // Used to retrieve data around single dinosaur at the Jurassic Park.
-func GetDinosaur(c *gin.Context) {
+func (h *DinosaurHandler) GetDinosaur(c *gin.Context) {
+	view, ok := viewParams(c, apimodels.Dinosaur{}, "cage")
+	if !ok {
+		return
+	}
+	if dinosaur, ok := h.getDinosaur(c, view, enclosingCage(view, repository.CageRelations{Dinosaurs: true})); ok {
+		respondWithView(c, "dinosaur", apimodels.GetDinosaurResponse{Dinosaur: transform.DinosaurToApi(dinosaur)}, view)
+	}
+}
+
+// enclosingCage returns the relations loading the cage of the dinosaurs, with its own relations,
+// if the view includes it.
+func enclosingCage(view recordView, cageRelations repository.CageRelations) repository.DinosaurRelations {
+	if !view.includes("cage") {
+		return repository.DinosaurRelations{}
+	}
+	return repository.DinosaurRelations{Cage: &cageRelations}
+}
+
+// getDinosaur loads the requested dinosaur with the relations and sets the ETag of its view, for all versions
+// of the API. Responds with an error and returns false when the dinosaur cannot be loaded, or with 304 when
+// the client's copy is still current.
+func (h *DinosaurHandler) getDinosaur(c *gin.Context, view recordView, relations repository.DinosaurRelations) (dbmodels.Dinosaur, bool) {
 	idParam := c.Param("id")
 	dinosaurID, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid dinosaur ID."})
-		return
+		respondWithValidationError(c, "id", "Invalid dinosaur ID.")
+		return dbmodels.Dinosaur{}, false
 	}
 
-	dbConn, err := db.Connect()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to connect to database."})
-		return
+	withDeleted, ok := includeDeleted(c)
+	if !ok {
+		return dbmodels.Dinosaur{}, false
 	}
 
-	var dinosaur dbmodels.Dinosaur
-	if err := dbConn.First(&dinosaur, dinosaurID).Error; err != nil {
-		c.JSON(http.StatusNotFound, apimodels.ErrorResponse{Error: "Dinosaur not found."})
-		return
+	dinosaur, err := h.dinosaurs.GetDinosaur(c.Request.Context(), uint(dinosaurID), withDeleted, relations)
+	if err != nil {
+		respondWithError(c, err, "Failed to retrieve dinosaur.")
+		return dbmodels.Dinosaur{}, false
 	}
 
-	c.JSON(http.StatusOK, apimodels.GetDinosaurResponse{Dinosaur: transform.DinosaurToApi(dinosaur)})
+	etag := ""
+	switch {
+	case dinosaur.DeletedAt.Valid:
+		// Deleting the dinosaur keeps its version, so only the modification time tells whether the client has seen it.
+	case dinosaur.Cage != nil:
+		// The version of the dinosaur does not cover its cage.
+	default:
+		etag = setETag(c, viewETag(versionETag(dinosaur.Version), view))
+	}
+	if respondIfNotModified(c, etag, dinosaurModified(dinosaur)) {
+		return dbmodels.Dinosaur{}, false
+	}
+	return dinosaur, true
 }
 
-// GetDinosaurs returns all dinosaurs matching provided filters.
+// GetDinosaurs returns all dinosaurs matching provided filters, restricted to the requested fields.
+// Filters are read from the query string and, for backward compatibility, from an optional JSON body.
 // Used to retrieve data around all current dinosaur at the Jurassic Park.
-func GetDinosaurs(c *gin.Context) {
-	var req apimodels.GetDinosaursRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid input."})
+func (h *DinosaurHandler) GetDinosaurs(c *gin.Context) {
+	view, ok := viewParams(c, apimodels.Dinosaur{}, "cage")
+	if !ok {
 		return
 	}
+	if page, ok := h.listDinosaurs(c, enclosingCage(view, repository.CageRelations{Dinosaurs: true})); ok {
+		respondWithPage(c, "dinosaurs", apimodels.GetDinosaursResponse{
+			Dinosaurs:  transform.DinosaursToApi(page.records),
+			NextCursor: page.nextCursor,
+			Total:      page.total,
+		}, view)
+	}
+}
+
+// listDinosaurs loads the requested page of dinosaurs with the relations, for all versions of the API.
+// Responds with an error and returns false when the dinosaurs cannot be loaded, or with 304 when the page
+// has not changed since If-Modified-Since. Last-Modified is scoped to the page the same way as for cages.
+func (h *DinosaurHandler) listDinosaurs(c *gin.Context, relations repository.DinosaurRelations) (listPage[dbmodels.Dinosaur], bool) {
+	var req apimodels.GetDinosaursRequest
+	if !bindOptionalJSON(c, &req) {
+		return listPage[dbmodels.Dinosaur]{}, false
+	}
+
+	withDeleted, ok := includeDeleted(c)
+	if !ok {
+		return listPage[dbmodels.Dinosaur]{}, false
+	}
+
+	withTotal, ok := includeTotal(c)
+	if !ok {
+		return listPage[dbmodels.Dinosaur]{}, false
+	}
+
+	page, ok := listPageParams(c, repository.DinosaurSortColumns)
+	if !ok {
+		return listPage[dbmodels.Dinosaur]{}, false
+	}
 
-	dbConn, err := db.Connect()
+	filter, ok := dinosaurFilterParams(c)
+	if !ok {
+		return listPage[dbmodels.Dinosaur]{}, false
+	}
+	for _, species := range req.FilteredSpecies {
+		filter.Species = append(filter.Species, string(species))
+	}
+
+	dinosaurs, err := h.dinosaurs.ListDinosaurs(c.Request.Context(), filter, lookahead(page), withDeleted, relations)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to connect to database."})
-		return
+		respondWithError(c, err, "Failed to retrieve dinosaurs.")
+		return listPage[dbmodels.Dinosaur]{}, false
 	}
 
-	var dinosaurs []dbmodels.Dinosaur
-	if len(req.FilteredSpecies) > 0 {
-		if err := dbConn.Where("species IN ?", req.FilteredSpecies).Find(&dinosaurs).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to retrieve dinosaurs with filtered species."})
-			return
-		}
-	} else {
-		if err := dbConn.Find(&dinosaurs).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to retrieve dinosaurs."})
-			return
+	var others time.Time
+	switch {
+	case withTotal:
+		others, err = h.dinosaurs.LastModified(c.Request.Context(), filter)
+	case !withDeleted:
+		others, err = h.dinosaurs.LastDeleted(c.Request.Context(), filter)
+	}
+	if err != nil {
+		respondWithError(c, err, "Failed to retrieve dinosaurs.")
+		return listPage[dbmodels.Dinosaur]{}, false
+	}
+	// The ETag of the page is only checked once the response has been encoded.
+	if respondIfNotModified(c, "", pageModified(dinosaurs, dinosaurModified, others)) {
+		return listPage[dbmodels.Dinosaur]{}, false
+	}
+	result := listPage[dbmodels.Dinosaur]{}
+	result.records, result.nextCursor = nextPage(dinosaurs, page, repository.DinosaurSortColumns)
+
+	if withTotal {
+		total, err := h.dinosaurs.CountDinosaurs(c.Request.Context(), filter, withDeleted)
+		if err != nil {
+			respondWithError(c, err, "Failed to retrieve dinosaurs.")
+			return listPage[dbmodels.Dinosaur]{}, false
 		}
+		result.total = &total
 	}
-
-	c.JSON(http.StatusOK, apimodels.GetDinosaursResponse{Dinosaurs: transform.DinosaursToApi(dinosaurs)})
+	return result, true
 }
 
 // AddDinosaur adds a new dinosaurs to the cage.
 // Used when dinosaur is imported to the Jurassic Park.
-func AddDinosaur(c *gin.Context) {
+func (h *DinosaurHandler) AddDinosaur(c *gin.Context) {
+	idempotent(c, h.keys, func(c *gin.Context) {
+		result, ok := h.addDinosaur(c)
+		switch {
+		case !ok:
+		case result.dryRun:
+			respondWithDryRun(c, result)
+		default:
+			c.JSON(http.StatusOK, apimodels.AddDinosaurResponse{Dinosaur: transform.DinosaurToApi(result.dinosaur)})
+		}
+	})
+}
+
+// addDinosaur adds the requested dinosaur and sets its ETag, or only checks it in a dry run, for all versions of the API.
+// Responds with an error and returns false when the dinosaur cannot be added.
+func (h *DinosaurHandler) addDinosaur(c *gin.Context) (placement, bool) {
 	var req apimodels.AddDinosaurRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid input."})
-		return
+		respondWithValidationError(c, "", "Invalid input.")
+		return placement{}, false
 	}
 
-	dbConn, err := db.Connect()
+	dinosaur, err := newDinosaur(req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to connect to database."})
+		respondWithError(c, err, "Failed to add dinosaur.")
+		return placement{}, false
+	}
+
+	dryRun, ok := queryBool(c, "dry_run")
+	if !ok {
+		return placement{}, false
+	}
+	if dryRun != nil && *dryRun {
+		violations, err := h.dinosaurs.PlacementViolations(c.Request.Context(), dinosaur.CageID, dinosaur)
+		if err != nil {
+			respondWithError(c, err, "Failed to check dinosaur.")
+			return placement{}, false
+		}
+		return placement{dinosaur: dinosaur, dryRun: true, violations: violations}, true
+	}
+
+	if err := h.dinosaurs.AddDinosaur(c.Request.Context(), &dinosaur); err != nil {
+		respondWithError(c, err, "Failed to add dinosaur.")
+		return placement{}, false
+	}
+
+	setETag(c, versionETag(dinosaur.Version))
+	return placement{dinosaur: dinosaur}, true
+}
+
+const maxBatchSize = 100
+
+// AddDinosaurs adds a batch of new dinosaurs to their cages at once, reporting the outcome for each of them.
+// An all-or-nothing batch with any dinosaur rejected is answered with 422 and adds none of them.
+// Used when a whole shipment of dinosaurs arrives at the Jurassic Park.
+func (h *DinosaurHandler) AddDinosaurs(c *gin.Context) {
+	items, rejected, ok := h.addDinosaurs(c)
+	if !ok {
 		return
 	}
 
+	results := batchResults(items, rejected)
+	for i, item := range items {
+		if results[i].Status == apimodels.BatchItemCreated {
+			dinosaur := transform.DinosaurToApi(item.Dinosaur)
+			results[i].Dinosaur = &dinosaur
+		}
+	}
+	c.JSON(batchStatus(rejected), apimodels.AddDinosaursResponse{Results: results})
+}
+
+// addDinosaurs adds the requested batch of dinosaurs, for all versions of the API, and reports whether
+// an all-or-nothing batch was rejected. Responds with an error and returns false when the batch is invalid or cannot be processed.
+func (h *DinosaurHandler) addDinosaurs(c *gin.Context) ([]service.BatchItem, bool, bool) {
+	var req apimodels.AddDinosaursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, "", "Invalid input.")
+		return nil, false, false
+	}
+
+	if req.Mode == "" {
+		req.Mode = apimodels.AllOrNothing
+	}
+	if req.Mode != apimodels.AllOrNothing && req.Mode != apimodels.BestEffort {
+		respondWithValidationError(c, "mode", "Invalid mode.")
+		return nil, false, false
+	}
+
+	if len(req.Dinosaurs) == 0 || len(req.Dinosaurs) > maxBatchSize {
+		respondWithValidationError(c, "dinosaurs", "Invalid batch. A batch should hold between 1 and 100 dinosaurs.")
+		return nil, false, false
+	}
+
+	items := make([]service.BatchItem, len(req.Dinosaurs))
+	for i, dinosaurReq := range req.Dinosaurs {
+		items[i].Dinosaur, items[i].Err = newDinosaur(dinosaurReq)
+	}
+
+	err := h.dinosaurs.AddDinosaurs(c.Request.Context(), items, req.Mode == apimodels.AllOrNothing)
+	if err != nil && !errors.Is(err, service.ErrBatchRejected) {
+		respondWithError(c, err, "Failed to add dinosaurs.")
+		return nil, false, false
+	}
+	return items, err != nil, true
+}
+
+// batchResults reports the outcome for each dinosaur of the batch, leaving out the created dinosaurs.
+func batchResults(items []service.BatchItem, rejected bool) []apimodels.AddDinosaursResult {
+	results := make([]apimodels.AddDinosaursResult, len(items))
+	for i, item := range items {
+		results[i].Index = i
+		switch {
+		case item.Err != nil:
+			results[i].Status = apimodels.BatchItemRejected
+			problem, _ := errorProblem(item.Err)
+			results[i].Code, results[i].Error = problem.Code, problem.Detail
+		case rejected:
+			results[i].Status = apimodels.BatchItemRolledBack
+		default:
+			results[i].Status = apimodels.BatchItemCreated
+		}
+	}
+	return results
+}
+
+// batchStatus answers a rejected all-or-nothing batch with 422.
+func batchStatus(rejected bool) int {
+	if rejected {
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusOK
+}
+
+// newDinosaur validates the request of a new dinosaur, and derives its type from the species.
+func newDinosaur(req apimodels.AddDinosaurRequest) (dbmodels.Dinosaur, error) {
 	if strings.TrimSpace(req.Name) == "" {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid name. Name cannot be blank."})
-		return
+		return dbmodels.Dinosaur{}, invalidInputError{field: "name", message: "Invalid name. Name cannot be blank."}
 	}
 
 	knownSpecies, species, dinosaurType := apimodels.LookupSpeciesType(req.Species)
 	if !knownSpecies {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Unknown species."})
-		return
+		return dbmodels.Dinosaur{}, invalidInputError{field: "species", message: "Unknown species."}
 	}
 
-	dinosaur := dbmodels.Dinosaur{
+	return dbmodels.Dinosaur{
 		Name:    req.Name,
 		Species: string(species),
 		Type:    string(dinosaurType),
 		CageID:  req.CageID,
-	}
-
-	if !canBeMovedToCage(c, dbConn, dinosaur, req.CageID) {
-		return
-	}
+	}, nil
+}
 
-	if err := dbConn.Create(&dinosaur).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to add dinosaur."})
-		return
+// UpdateDinosaur changes the name or species of existing dinosaurs, or moves them to a different cage,
+// sent as JSON Merge Patch.
+// Used to move dinosaurs around the Jurassic Park and to correct their records.
+func (h *DinosaurHandler) UpdateDinosaur(c *gin.Context) {
+	result, ok := h.updateDinosaur(c)
+	switch {
+	case !ok:
+	case result.dryRun:
+		respondWithDryRun(c, result)
+	default:
+		c.JSON(http.StatusOK, apimodels.UpdateDinosaurResponse{Dinosaur: transform.DinosaurToApi(result.dinosaur)})
 	}
-
-	c.JSON(http.StatusOK, apimodels.AddDinosaurResponse{Dinosaur: transform.DinosaurToApi(dinosaur)})
 }
 
-// MoveDinosaur moves existing dinosaurs to a different cage.
-// Used to move dinosaurs around the Jurassic Park.
-func MoveDinosaur(c *gin.Context) {
+// updateDinosaur applies the requested patch to the dinosaur and sets its ETag, or only checks it in a dry run,
+// for all versions of the API. Responds with an error and returns false when the dinosaur cannot be updated.
+func (h *DinosaurHandler) updateDinosaur(c *gin.Context) (placement, bool) {
 	idParam := c.Param("id")
 	dinosaurID, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid dinosaur ID."})
-		return
+		respondWithValidationError(c, "id", "Invalid dinosaur ID.")
+		return placement{}, false
 	}
 
-	var req apimodels.MoveDinosaurRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid input."})
-		return
+	var req apimodels.UpdateDinosaurRequest
+	if !bindMergePatch(c, &req, "name", "species", "cage_id") {
+		return placement{}, false
+	}
+
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		respondWithValidationError(c, "name", "Invalid name. Name cannot be blank.")
+		return placement{}, false
+	}
+
+	if req.Species != nil {
+		if knownSpecies, _, _ := apimodels.LookupSpeciesType(*req.Species); !knownSpecies {
+			respondWithValidationError(c, "species", "Unknown species.")
+			return placement{}, false
+		}
 	}
 
-	dbConn, err := db.Connect()
+	expectedVersion, ok := ifMatchVersion(c)
+	if !ok {
+		return placement{}, false
+	}
+
+	dryRun, ok := queryBool(c, "dry_run")
+	if !ok {
+		return placement{}, false
+	}
+
+	update := service.DinosaurUpdate{Name: req.Name, Species: req.Species, CageID: req.CageID}
+	if dryRun != nil && *dryRun {
+		dinosaur, violations, err := h.dinosaurs.UpdateViolations(c.Request.Context(), uint(dinosaurID), update, expectedVersion)
+		if err != nil {
+			respondWithError(c, err, "Failed to check dinosaur.")
+			return placement{}, false
+		}
+		return placement{dinosaur: dinosaur, dryRun: true, violations: violations}, true
+	}
+
+	dinosaur, err := h.dinosaurs.UpdateDinosaur(c.Request.Context(), uint(dinosaurID), update, expectedVersion)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to connect to database."})
+		respondWithError(c, err, "Failed to update dinosaur.")
+		return placement{}, false
+	}
+
+	setETag(c, versionETag(dinosaur.Version))
+	return placement{dinosaur: dinosaur}, true
+}
+
+// GetCompatibility checks whether a dinosaur of the species, or the existing dinosaur, can be placed in the cage,
+// listing every rule the placement would break. Nothing is changed.
+// Used to plan the placement of dinosaurs at the Jurassic Park.
+func (h *DinosaurHandler) GetCompatibility(c *gin.Context) {
+	idParam := c.Param("id")
+	cageID, err := strconv.Atoi(idParam)
+	if err != nil {
+		respondWithValidationError(c, "id", "Invalid cage ID.")
 		return
 	}
 
-	var dinosaur dbmodels.Dinosaur
-	if err := dbConn.First(&dinosaur, dinosaurID).Error; err != nil {
-		c.JSON(http.StatusNotFound, apimodels.ErrorResponse{Error: "Dinosaur not found."})
+	speciesParam, dinosaurIDParam := c.Query("species"), c.Query("dinosaur_id")
+	if (speciesParam == "") == (dinosaurIDParam == "") {
+		respondWithValidationError(c, "", "Either species or dinosaur_id parameter is required.")
 		return
 	}
 
-	if dinosaur.CageID != req.CageID {
-		if !canBeMovedToCage(c, dbConn, dinosaur, req.CageID) {
+	var dinosaur dbmodels.Dinosaur
+	if speciesParam != "" {
+		knownSpecies, species, dinosaurType := apimodels.LookupSpeciesType(speciesParam)
+		if !knownSpecies {
+			invalidParameter(c, "species")
 			return
 		}
-		dinosaur.CageID = req.CageID
-		if err := dbConn.Save(&dinosaur).Error; err != nil {
-			c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to move dinosaur."})
+		dinosaur = dbmodels.Dinosaur{Species: string(species), Type: string(dinosaurType)}
+	} else {
+		dinosaurID, err := strconv.ParseUint(dinosaurIDParam, 10, 0)
+		if err != nil {
+			invalidParameter(c, "dinosaur_id")
 			return
 		}
+		if dinosaur, err = h.dinosaurs.GetDinosaur(c.Request.Context(), uint(dinosaurID), false, repository.DinosaurRelations{}); err != nil {
+			respondWithError(c, err, "Failed to check compatibility.")
+			return
+		}
+	}
+
+	violations, err := h.dinosaurs.PlacementViolations(c.Request.Context(), uint(cageID), dinosaur)
+	if err != nil {
+		respondWithError(c, err, "Failed to check compatibility.")
+		return
 	}
 
-	c.JSON(http.StatusOK, apimodels.MoveDinosaurResponse{Dinosaur: transform.DinosaurToApi(dinosaur)})
+	c.JSON(http.StatusOK, apimodels.GetCompatibilityResponse{
+		Compatible: len(violations) == 0,
+		Violations: violationsToApi(violations),
+	})
+}
+
+// placement is the outcome of adding or updating a dinosaur. In a dry run nothing is stored,
+// and the dinosaur is reported as it would be stored along with the rules it would break.
+type placement struct {
+	dinosaur   dbmodels.Dinosaur
+	dryRun     bool
+	violations []error
+}
+
+// respondWithDryRun reports the outcome of a dry run.
+func respondWithDryRun(c *gin.Context, result placement) {
+	c.JSON(http.StatusOK, apimodels.DryRunDinosaurResponse{
+		Dinosaur:   transform.DinosaurToApi(result.dinosaur),
+		Compatible: len(result.violations) == 0,
+		Violations: violationsToApi(result.violations),
+	})
+}
+
+// MoveDinosaurs moves several dinosaurs at once, applying either all of the moves or none.
+// Only the final placement is checked against the park rules, so dinosaurs can swap cages even when those are full.
+// Used to rotate dinosaurs between the cages of the Jurassic Park.
+func (h *DinosaurHandler) MoveDinosaurs(c *gin.Context) {
+	if dinosaurs, ok := h.moveDinosaurs(c); ok {
+		c.JSON(http.StatusOK, apimodels.MoveDinosaursResponse{Dinosaurs: transform.DinosaursToApi(dinosaurs)})
+	}
+}
+
+// moveDinosaurs applies the requested moves and returns the moved dinosaurs, for all versions of the API.
+// Responds with an error and returns false when the moves cannot be applied.
+func (h *DinosaurHandler) moveDinosaurs(c *gin.Context) ([]dbmodels.Dinosaur, bool) {
+	var req apimodels.MoveDinosaursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, "", "Invalid input.")
+		return nil, false
+	}
+
+	if len(req.Moves) == 0 || len(req.Moves) > maxBatchSize {
+		respondWithValidationError(c, "moves", "Invalid moves. Between 1 and 100 dinosaurs can be moved at once.")
+		return nil, false
+	}
+
+	moves := make([]service.Move, len(req.Moves))
+	for i, move := range req.Moves {
+		moves[i] = service.Move{DinosaurID: move.DinosaurID, TargetCageID: move.TargetCageID}
+	}
+
+	dinosaurs, err := h.dinosaurs.MoveDinosaurs(c.Request.Context(), moves)
+	if err != nil {
+		respondWithError(c, err, "Failed to move dinosaurs.")
+		return nil, false
+	}
+	return dinosaurs, true
 }
 
 // RemoveDinosaur removes dinosaur from their existing cage.
 // Used when dinosaur is exported from the Jurassic Park.
-func RemoveDinosaur(c *gin.Context) {
+func (h *DinosaurHandler) RemoveDinosaur(c *gin.Context) {
 	idParam := c.Param("id")
 	dinosaurID, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, apimodels.ErrorResponse{Error: "Invalid dinosaur ID."})
-		return
-	}
-
-	dbConn, err := db.Connect()
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to connect to database."})
+		respondWithValidationError(c, "id", "Invalid dinosaur ID.")
 		return
 	}
 
-	var dinosaur dbmodels.Dinosaur
-	if err := dbConn.First(&dinosaur, dinosaurID).Error; err != nil {
-		c.JSON(http.StatusNotFound, apimodels.ErrorResponse{Error: "Dinosaur not found."})
+	expectedVersion, ok := ifMatchVersion(c)
+	if !ok {
 		return
 	}
 
-	if err := dbConn.Delete(&dinosaur).Error; err != nil {
-		c.JSON(http.StatusInternalServerError, apimodels.ErrorResponse{Error: "Failed to remove dinosaur."})
+	if err := h.dinosaurs.RemoveDinosaur(c.Request.Context(), uint(dinosaurID), expectedVersion); err != nil {
+		respondWithError(c, err, "Failed to remove dinosaur.")
 		return
 	}
 
 	c.JSON(http.StatusOK, apimodels.RemoveDinosaurResponse{})
 }
 
-func canBeMovedToCage(c *gin.Context, dbConn *gorm.DB, dinosaur dbmodels.Dinosaur, cageID uint) bool {
-	var cage dbmodels.Cage
-	if err := dbConn.Preload("Dinosaurs").First(&cage, cageID).Error; err != nil {
-		c.JSON(http.StatusNotFound, apimodels.ErrorResponse{Error: "Cage not found."})
-		return false
+// RestoreDinosaur restores the deleted dinosaur into the cage it was removed from.
+// Used to bring back dinosaurs removed by mistake at the Jurassic Park.
+func (h *DinosaurHandler) RestoreDinosaur(c *gin.Context) {
+	if dinosaur, ok := h.restoreDinosaur(c); ok {
+		c.JSON(http.StatusOK, apimodels.RestoreDinosaurResponse{Dinosaur: transform.DinosaurToApi(dinosaur)})
+	}
+}
+
+// restoreDinosaur restores the requested dinosaur and sets its ETag, for all versions of the API.
+// Responds with an error and returns false when the dinosaur cannot be restored.
+func (h *DinosaurHandler) restoreDinosaur(c *gin.Context) (dbmodels.Dinosaur, bool) {
+	idParam := c.Param("id")
+	dinosaurID, err := strconv.Atoi(idParam)
+	if err != nil {
+		respondWithValidationError(c, "id", "Invalid dinosaur ID.")
+		return dbmodels.Dinosaur{}, false
 	}
 
-	if cage.Capacity == len(cage.Dinosaurs) {
-		c.JSON(http.StatusConflict, apimodels.ErrorResponse{Error: "Dinosaur cannot be placed in cage that is already full."})
-		return false
+	expectedVersion, ok := ifMatchVersion(c)
+	if !ok {
+		return dbmodels.Dinosaur{}, false
 	}
 
-	if cage.PowerStatus != string(apimodels.Active) {
-		c.JSON(http.StatusConflict, apimodels.ErrorResponse{Error: "Dinosaur cannot be placed in cage that has no power."})
-		return false
+	dinosaur, err := h.dinosaurs.RestoreDinosaur(c.Request.Context(), uint(dinosaurID), expectedVersion)
+	if err != nil {
+		respondWithError(c, err, "Failed to restore dinosaur.")
+		return dbmodels.Dinosaur{}, false
 	}
 
-	for _, dinosaurInCage := range cage.Dinosaurs {
-		if dinosaur.Type == string(apimodels.Herbivore) && dinosaurInCage.Type == string(apimodels.Carnivore) {
-			c.JSON(http.StatusConflict, apimodels.ErrorResponse{Error: "Herbivore Dinosaur cannot be placed in cage with Carnivores."})
-			return false
-		}
-		if dinosaur.Type == string(apimodels.Carnivore) && dinosaur.Species != dinosaurInCage.Species {
-			c.JSON(http.StatusConflict, apimodels.ErrorResponse{Error: "Carnivore Dinosaur cannot be placed in cage with any other species."})
-			return false
-		}
+	setETag(c, versionETag(dinosaur.Version))
+	return dinosaur, true
+}
+
+// GetDinosaurHistory returns the recorded changes of the dinosaur, oldest first.
+// Used to trace which cages a dinosaur has been kept in at the Jurassic Park.
+func (h *DinosaurHandler) GetDinosaurHistory(c *gin.Context) {
+	idParam := c.Param("id")
+	dinosaurID, err := strconv.Atoi(idParam)
+	if err != nil {
+		respondWithValidationError(c, "id", "Invalid dinosaur ID.")
+		return
+	}
+
+	limit, afterID, ok := pageParams(c)
+	if !ok {
+		return
 	}
-	return true
+
+	page, err := h.dinosaurs.GetDinosaurHistory(c.Request.Context(), uint(dinosaurID), afterID, limit)
+	if err != nil {
+		respondWithError(c, err, "Failed to retrieve dinosaur history.")
+		return
+	}
+
+	c.JSON(http.StatusOK, apimodels.GetHistoryResponse{
+		History:    transform.HistoryToApi(page.Entries),
+		NextCursor: encodeCursor(page.NextAfterID),
+	})
 }
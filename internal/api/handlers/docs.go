@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/api/openapi"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+var documentGenerator = openapi.Generator{
+	Info: openapi.Info{
+		Title:       "Jurassic Park API",
+		Description: "Manage the cages of the Jurassic Park and the dinosaurs living in them.",
+		Version:     "1.0.0",
+	},
+	Tags: []openapi.Tag{
+		{Name: "cages", Description: "Cages and their power supply."},
+		{Name: "dinosaurs", Description: "Dinosaurs and their placement in cages."},
+		{Name: "inventory", Description: "Export and import of all cages and dinosaurs as CSV or NDJSON."},
+		{Name: "debug", Description: "Operational insights, enabled by the debug_endpoints feature toggle."},
+	},
+	Problem:            apimodels.Problem{},
+	ProblemContentType: apimodels.ProblemContentType,
+	Enums: []openapi.Enum{
+		openapi.EnumOf(apimodels.Active, apimodels.Down),
+		openapi.EnumOf(apimodels.Herbivore, apimodels.Carnivore),
+		openapi.EnumOf(apimodels.AllSpecies...),
+		openapi.EnumOf(apimodels.AllOrNothing, apimodels.BestEffort),
+		openapi.EnumOf(apimodels.BatchItemCreated, apimodels.BatchItemRejected, apimodels.BatchItemRolledBack),
+		openapi.EnumOf(apimodels.CSV, apimodels.NDJSON),
+		openapi.EnumOf(apimodels.InventoryCage, apimodels.InventoryDinosaur),
+		openapi.EnumOf(apimodels.Created, apimodels.PowerChanged, apimodels.Moved, apimodels.Updated, apimodels.Deleted,
+			apimodels.Restored),
+		openapi.EnumOf(apimodels.CageFull, apimodels.CageUnpowered, apimodels.DietConflict, apimodels.SpeciesConflict,
+			apimodels.CageNotEmpty, apimodels.CapacityBelowOccupancy, apimodels.NotFound, apimodels.ValidationFailed,
+			apimodels.PreconditionFailed, apimodels.UnsupportedMediaType, apimodels.IdempotencyKeyReused,
+			apimodels.IdempotencyKeyInUse, apimodels.InternalError),
+	},
+}
+
+// Document returns the OpenAPI document describing the routes.
+func Document(routes []Route) openapi.Document {
+	endpoints := make([]openapi.Endpoint, len(routes))
+	for i, route := range routes {
+		endpoints[i] = route.Endpoint
+	}
+	return documentGenerator.Generate(endpoints)
+}
+
+// OpenAPI serves the OpenAPI document describing the routes.
+func OpenAPI(routes []Route) gin.HandlerFunc {
+	document := Document(routes)
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, document)
+	}
+}
+
+// docsInitializer replaces the initializer of the bundled Swagger UI, which points at an example API.
+const docsInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: %s,
+    dom_id: '#swagger-ui',
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// Docs serves Swagger UI browsing the OpenAPI document at documentURL.
+// It is registered on a wildcard route, e.g. /docs/*path, and serves the UI at the route's root.
+func Docs(documentURL string) gin.HandlerFunc {
+	initializer := []byte(fmt.Sprintf(docsInitializer, strconv.Quote(documentURL)))
+	files := http.FileServer(swaggerFiles.HTTP)
+	return func(c *gin.Context) {
+		path := c.Param("path")
+		if path == "/swagger-initializer.js" {
+			c.Data(http.StatusOK, "application/javascript; charset=utf-8", initializer)
+			return
+		}
+		http.StripPrefix(strings.TrimSuffix(c.Request.URL.Path, path), files).ServeHTTP(c.Writer, c.Request)
+	}
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondWithProblem answers the request with the problem, served as application/problem+json.
+func respondWithProblem(c *gin.Context, problem apimodels.Problem) {
+	c.Header("Content-Type", apimodels.ProblemContentType)
+	c.JSON(problem.Status, problem)
+}
+
+// respondWithValidationError reports a malformed request. field names the request field,
+// query parameter or header at fault, and is left empty when the request as a whole is malformed.
+func respondWithValidationError(c *gin.Context, field string, detail string) {
+	problem := apimodels.ValidationFailedProblem.New(detail)
+	problem.Field = field
+	respondWithProblem(c, problem)
+}
+
+// respondWithError translates service errors into API error responses.
+// Unknown errors are reported as internal errors with the provided message.
+func respondWithError(c *gin.Context, err error, internalErrorMessage string) {
+	problem, known := errorProblem(err)
+	if !known {
+		problem = apimodels.InternalErrorProblem.New(internalErrorMessage)
+	}
+	respondWithProblem(c, problem)
+}
+
+// errorProblem returns the problem reporting a service error. known is false for unexpected errors.
+func errorProblem(err error) (problem apimodels.Problem, known bool) {
+	var invalid invalidInputError
+	switch {
+	case errors.As(err, &invalid):
+		problem = apimodels.ValidationFailedProblem.New(invalid.message)
+		problem.Field = invalid.field
+	case errors.Is(err, service.ErrCageNotFound):
+		problem = apimodels.NotFoundProblem.New("Cage not found.")
+	case errors.Is(err, service.ErrDinosaurNotFound):
+		problem = apimodels.NotFoundProblem.New("Dinosaur not found.")
+	case errors.Is(err, service.ErrCageNotEmpty):
+		problem = apimodels.CageNotEmptyProblem.New("Cannot delete cage with dinosaurs inside.")
+	case errors.Is(err, service.ErrCageFull):
+		problem = apimodels.CageFullProblem.New("Dinosaur cannot be placed in cage that is already full.")
+	case errors.Is(err, service.ErrCageNoPower):
+		problem = apimodels.CageUnpoweredProblem.New("Dinosaur cannot be placed in cage that has no power.")
+	case errors.Is(err, service.ErrHerbivoreWithCarnivores):
+		problem = apimodels.DietConflictProblem.New("Herbivore Dinosaur cannot be placed in cage with Carnivores.")
+	case errors.Is(err, service.ErrCarnivoreWithOtherSpecies):
+		problem = apimodels.SpeciesConflictProblem.New("Carnivore Dinosaur cannot be placed in cage with any other species.")
+	case errors.Is(err, service.ErrCapacityBelowOccupancy):
+		problem = apimodels.CapacityBelowOccupancyProblem.New("Capacity cannot be lower than the number of dinosaurs in the cage.")
+	case errors.Is(err, service.ErrUnknownSpecies):
+		problem = apimodels.ValidationFailedProblem.New("Unknown species.")
+		problem.Field = "species"
+	case errors.Is(err, service.ErrImportCageRejected):
+		problem = apimodels.ValidationFailedProblem.New("Cage of the dinosaur is rejected by its own row.")
+		problem.Field = "cage_ref"
+	case errors.Is(err, service.ErrImportCageNotFound):
+		problem = apimodels.ValidationFailedProblem.New("Unknown cage_ref. No cage of the import has it.")
+		problem.Field = "cage_ref"
+	case errors.Is(err, service.ErrDinosaurMovedTwice):
+		problem = apimodels.ValidationFailedProblem.New("Invalid moves. Each dinosaur can be moved only once.")
+		problem.Field = "moves"
+	case errors.Is(err, service.ErrVersionMismatch), errors.Is(err, repository.ErrVersionConflict):
+		problem = apimodels.PreconditionFailedProblem.New("Resource has been modified. Reload it and try again.")
+		problem.Field = "If-Match"
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		problem = apimodels.IdempotencyKeyReusedProblem.New("Idempotency key has already been used for a different request.")
+		problem.Field = IdempotencyKeyHeader
+	case errors.Is(err, service.ErrIdempotencyKeyInUse):
+		problem = apimodels.IdempotencyKeyInUseProblem.New("A request with the same idempotency key is still being handled. Retry later.")
+		problem.Field = IdempotencyKeyHeader
+	default:
+		return apimodels.Problem{}, false
+	}
+
+	var cageErr *service.CageError
+	if errors.As(err, &cageErr) {
+		problem.CageID = cageErr.CageID
+	}
+	return problem, true
+}
+
+// violationsToApi reports the broken placement rules by the codes and messages of their errors.
+func violationsToApi(violations []error) []apimodels.Violation {
+	result := []apimodels.Violation{}
+	for _, violation := range violations {
+		problem, _ := errorProblem(violation)
+		result = append(result, apimodels.Violation{Rule: problem.Code, Message: problem.Detail})
+	}
+	return result
+}
+
+// invalidInputError carries a validation failure detected by a handler,
+// for places where it is reported alongside service errors.
+type invalidInputError struct {
+	field   string
+	message string
+}
+
+func (e invalidInputError) Error() string {
+	return e.message
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag exposes the ETag of the returned resource, to be sent back in If-Match, and returns it.
+func setETag(c *gin.Context, etag string) string {
+	c.Header("ETag", etag)
+	return etag
+}
+
+// versionETag returns the ETag of a resource changing along with its version.
+func versionETag(version uint) string {
+	return strconv.Quote(strconv.FormatUint(uint64(version), 10))
+}
+
+// cageETag returns the ETag of a cage, which is returned along with its dinosaurs: its version, followed by
+// the version of its occupancy. If-Match only compares the version, so that placements do not fail changes of the cage.
+func cageETag(cage dbmodels.Cage) string {
+	return strconv.Quote(fmt.Sprintf("%d.%d", cage.Version, cage.OccupancyVersion))
+}
+
+// viewETag returns the ETag of a record read in the view, given the ETag of its full representation. A view
+// restricting the fields or including relations gets a weak ETag of its own, telling the representations apart;
+// it is not accepted by If-Match.
+func viewETag(etag string, view recordView) string {
+	if view.fields == nil && view.include == nil {
+		return etag
+	}
+	sum := sha256.Sum256([]byte(strings.Join(view.fields, ",") + ";" + strings.Join(view.include, ",")))
+	return "W/" + strconv.Quote(strings.Trim(etag, `"`)+"-"+hex.EncodeToString(sum[:4]))
+}
+
+// contentETag returns a strong ETag identifying the response body, for responses not covered by a single version.
+func contentETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return strconv.Quote(hex.EncodeToString(sum[:16]))
+}
+
+// respondIfNotModified sets Last-Modified and responds with 304 if the client's copy of the response is still current,
+// which If-None-Match tells if present and If-Modified-Since otherwise. etag is the ETag of the current response,
+// or empty if it is not known yet or does not identify the response; If-None-Match does not match then.
+// Returns true when it has responded.
+func respondIfNotModified(c *gin.Context, etag string, modified time.Time) bool {
+	if !modified.IsZero() {
+		c.Header("Last-Modified", lastModified(modified, time.Now()).Format(http.TimeFormat))
+	}
+
+	notModified := false
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" {
+		notModified = etag != "" && matchesETag(ifNoneMatch, etag)
+	} else if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil && !modified.IsZero() {
+		// Compared at full precision, so a change later in the second of the client's copy is not taken for it.
+		notModified = !modified.After(since)
+	}
+	if notModified {
+		c.Status(http.StatusNotModified)
+	}
+	return notModified
+}
+
+// lastModified returns the Last-Modified time of a response served now, which only has a precision of seconds.
+// The modification time is rounded up, so that If-Modified-Since sent back with it covers the modification.
+// While that second is not over yet, the response gets the current second instead, which a change later
+// in the same second is still after.
+func lastModified(modified time.Time, now time.Time) time.Time {
+	rounded := modified.Truncate(time.Second)
+	if rounded.Before(modified) {
+		rounded = rounded.Add(time.Second)
+	}
+	if rounded.After(now) {
+		rounded = now.Truncate(time.Second)
+	}
+	return rounded.UTC()
+}
+
+// pageModified returns when a page of a list has last been modified: the latest modification of its records,
+// as told by recordModified, or of the records elsewhere in the list, as told by others.
+func pageModified[T any](records []T, recordModified func(T) time.Time, others time.Time) time.Time {
+	modified := others
+	for _, record := range records {
+		if recordModified(record).After(modified) {
+			modified = recordModified(record)
+		}
+	}
+	return modified
+}
+
+// cageModified returns when the cage, or any of its dinosaurs read along with it, has last been modified.
+func cageModified(cage dbmodels.Cage) time.Time {
+	return pageModified(cage.Dinosaurs, dbmodels.Dinosaur.LastModified, cage.LastModified())
+}
+
+// dinosaurModified returns when the dinosaur, or its cage read along with it, has last been modified.
+func dinosaurModified(dinosaur dbmodels.Dinosaur) time.Time {
+	modified := dinosaur.LastModified()
+	if dinosaur.Cage != nil && cageModified(*dinosaur.Cage).After(modified) {
+		modified = cageModified(*dinosaur.Cage)
+	}
+	return modified
+}
+
+// matchesETag tells whether an If-None-Match header lists the ETag. Weak ETags match their strong counterparts.
+func matchesETag(header string, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion reads the version the client expects from the If-Match header.
+// A missing header or "*" matches any version. The occupancy version of a cage's ETag is ignored.
+// Responds with 400 and returns false when the header is malformed.
+func ifMatchVersion(c *gin.Context) (uint, bool) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return service.AnyVersion, true
+	}
+
+	unquoted, err := strconv.Unquote(ifMatch)
+	if err != nil {
+		respondWithValidationError(c, "If-Match", "Invalid If-Match header.")
+		return 0, false
+	}
+	unquoted, occupancy, found := strings.Cut(unquoted, ".")
+	if _, err := strconv.ParseUint(occupancy, 10, 0); found && err != nil {
+		respondWithValidationError(c, "If-Match", "Invalid If-Match header.")
+		return 0, false
+	}
+	version, err := strconv.ParseUint(unquoted, 10, 0)
+	if err != nil || version == uint64(service.AnyVersion) {
+		respondWithValidationError(c, "If-Match", "Invalid If-Match header.")
+		return 0, false
+	}
+	return uint(version), true
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// recordView is the shape of the records returned by a read, as requested with the fields and include
+// query parameters, e.g. ?fields=id,capacity,power_status&include=dinosaurs.
+type recordView struct {
+	// fields are the fields of each record to return, or nil for all of them.
+	fields []string
+	// include lists the related records to embed into each record.
+	include []string
+}
+
+// viewParams reads the fields and include query parameters of a read returning records of the model.
+// Fields are the JSON fields of the model, and relations those of them which can be included.
+// Included relations are returned even if fields leaves them out.
+// Responds with 400 and returns false when either of them names something else.
+func viewParams(c *gin.Context, model any, relations ...string) (recordView, bool) {
+	known := jsonFields(reflect.TypeOf(model))
+	view := recordView{}
+	for _, field := range queryList(c, "fields") {
+		if !slices.Contains(known, field) {
+			invalidParameter(c, "fields")
+			return recordView{}, false
+		}
+		view.fields = append(view.fields, field)
+	}
+
+	for _, relation := range queryList(c, "include") {
+		if !slices.Contains(relations, relation) {
+			invalidParameter(c, "include")
+			return recordView{}, false
+		}
+		view.include = append(view.include, relation)
+		if view.fields != nil && !slices.Contains(view.fields, relation) {
+			view.fields = append(view.fields, relation)
+		}
+	}
+	return view, true
+}
+
+// selects tells whether the field is returned.
+func (v recordView) selects(field string) bool {
+	return v.fields == nil || slices.Contains(v.fields, field)
+}
+
+// includes tells whether the relation has been requested explicitly.
+func (v recordView) includes(relation string) bool {
+	return slices.Contains(v.include, relation)
+}
+
+// respondWithView responds with 200 and the response, its records restricted to the fields of the view.
+// The records are the object or array held by the given member of the response; records embedded into them
+// are returned in full.
+func respondWithView(c *gin.Context, member string, response any, view recordView) {
+	data, err := encodeView(member, response, view)
+	if err != nil {
+		respondWithError(c, err, "Failed to encode response.")
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// respondWithPage responds like respondWithView with a page of records, tagged with an ETag of its content.
+// Responds with 304 instead if If-None-Match holds the ETag already.
+func respondWithPage(c *gin.Context, member string, response any, view recordView) {
+	data, err := encodeView(member, response, view)
+	if err != nil {
+		respondWithError(c, err, "Failed to encode response.")
+		return
+	}
+	etag := contentETag(data)
+	c.Header("ETag", etag)
+	if matchesETag(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+// encodeView encodes the response, its records restricted to the fields of the view.
+func encodeView(member string, response any, view recordView) ([]byte, error) {
+	data, err := json.Marshal(response)
+	if err != nil || view.fields == nil {
+		return data, err
+	}
+	return filterObject(data, func(name string, value json.RawMessage) (json.RawMessage, bool, error) {
+		if name != member {
+			return value, true, nil
+		}
+		restricted, err := selectFields(value, view.fields)
+		return restricted, true, err
+	})
+}
+
+// selectFields restricts a JSON record, or each record of a JSON array, to the fields.
+func selectFields(records json.RawMessage, fields []string) (json.RawMessage, error) {
+	keep := func(name string, value json.RawMessage) (json.RawMessage, bool, error) {
+		return value, slices.Contains(fields, name), nil
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(records), []byte("[")) {
+		return filterObject(records, keep)
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(records, &list); err != nil {
+		return nil, err
+	}
+	for i, record := range list {
+		restricted, err := filterObject(record, keep)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = restricted
+	}
+	return json.Marshal(list)
+}
+
+// filterObject encodes the JSON object again with the members keep returns true for, replaced by the value
+// it returns. The members keep their order.
+func filterObject(data []byte, keep func(name string, value json.RawMessage) (json.RawMessage, bool, error)) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		value, kept, err := keep(name, value)
+		if err != nil {
+			return nil, err
+		}
+		if !kept {
+			continue
+		}
+		if buffer.Len() > 1 {
+			buffer.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// jsonFields returns the names of the JSON fields of a struct type, in the order they are declared.
+func jsonFields(t reflect.Type) []string {
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
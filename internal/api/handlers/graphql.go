@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+)
+
+// GraphQLHandler serves the cages and dinosaurs as a single GraphQL schema, backed by the same services as the REST API.
+type GraphQLHandler struct {
+	cages     *service.CageService
+	dinosaurs *service.DinosaurService
+	schema    graphql.Schema
+}
+
+func NewGraphQLHandler(cages *service.CageService, dinosaurs *service.DinosaurService) (*GraphQLHandler, error) {
+	schema, err := newGraphQLSchema(cages, dinosaurs)
+	if err != nil {
+		return nil, err
+	}
+	return &GraphQLHandler{cages: cages, dinosaurs: dinosaurs, schema: schema}, nil
+}
+
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables"`
+	OperationName string         `json:"operationName"`
+}
+
+// Query executes a GraphQL query or mutation sent as JSON. Errors of the query are reported along with
+// the data it could resolve, each with the error code the REST API would have answered with.
+// Used by dashboards reading cages together with their dinosaurs in a single request.
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req graphQLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, "", "Invalid input.")
+		return
+	}
+	if req.Query == "" {
+		respondWithValidationError(c, "query", "Query is required.")
+		return
+	}
+
+	ctx := context.WithValue(c.Request.Context(), loadersKey{}, newGraphQLLoaders(h.cages, h.dinosaurs))
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+	for i := range result.Errors {
+		result.Errors[i] = formatGraphQLError(result.Errors[i])
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// graphQLProblem carries the problem reported for an error raised while resolving a field.
+type graphQLProblem struct {
+	problem apimodels.Problem
+}
+
+func (e graphQLProblem) Error() string {
+	return e.problem.Detail
+}
+
+// graphQLError translates service errors into problems, like respondWithError.
+// Unknown errors are reported as internal errors with the provided message.
+func graphQLError(err error, internalErrorMessage string) error {
+	problem, known := errorProblem(err)
+	if !known {
+		problem = apimodels.InternalErrorProblem.New(internalErrorMessage)
+	}
+	return graphQLProblem{problem: problem}
+}
+
+// formatGraphQLError adds the code of the problem, the field and the cage at fault to the extensions of the error.
+// Errors not raised by a resolver are malformed queries, reported as validation failures.
+func formatGraphQLError(err gqlerrors.FormattedError) gqlerrors.FormattedError {
+	problem := apimodels.ValidationFailedProblem.New(err.Message)
+	if resolverErr, ok := unwrapGraphQLError(err).(graphQLProblem); ok {
+		problem = resolverErr.problem
+	}
+
+	err.Message = problem.Detail
+	err.Extensions = map[string]interface{}{"code": problem.Code}
+	if problem.Field != "" {
+		err.Extensions["field"] = problem.Field
+	}
+	if problem.CageID != 0 {
+		err.Extensions["cageId"] = problem.CageID
+	}
+	return err
+}
+
+// unwrapGraphQLError returns the error a resolver failed with, which GraphQL wraps once or twice
+// depending on whether the field was resolved directly or through a loader.
+func unwrapGraphQLError(err error) error {
+	for {
+		switch wrapper := err.(type) {
+		case gqlerrors.FormattedError:
+			if wrapper.OriginalError() == nil {
+				return err
+			}
+			err = wrapper.OriginalError()
+		case *gqlerrors.Error:
+			if wrapper.OriginalError == nil {
+				return err
+			}
+			err = wrapper.OriginalError
+		default:
+			return err
+		}
+	}
+}
@@ -0,0 +1,418 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
+
+	"github.com/graphql-go/graphql"
+)
+
+// graphQLSchema resolves GraphQL queries with the same services as the REST API.
+// Nested cages and dinosaurs are read through the loaders of the request, one read per level of the query,
+// unless they have been read along with their parent.
+//
+// Nested fields are only resolved once every mutation of the request has run. Mutations therefore return
+// their record along with its cage and the dinosaurs in it, as they stand right after the change.
+type graphQLSchema struct {
+	cages     *service.CageService
+	dinosaurs *service.DinosaurService
+}
+
+type loadersKey struct{}
+
+// loaders returns the loaders of the request being resolved.
+func loaders(ctx context.Context) *graphQLLoaders {
+	return ctx.Value(loadersKey{}).(*graphQLLoaders)
+}
+
+func newGraphQLSchema(cages *service.CageService, dinosaurs *service.DinosaurService) (graphql.Schema, error) {
+	s := graphQLSchema{cages: cages, dinosaurs: dinosaurs}
+
+	powerStatusEnum := enumOf("PowerStatus", "Whether the electric fence of a cage is powered.", apimodels.Active, apimodels.Down)
+	dinosaurTypeEnum := enumOf("DinosaurType", "What a dinosaur eats.", apimodels.Herbivore, apimodels.Carnivore)
+	speciesNameEnum := enumOf("SpeciesName", "The species living in the Jurassic Park.", apimodels.AllSpecies...)
+
+	var cageType, dinosaurType, speciesType *graphql.Object
+	cageType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Cage",
+		Description: "A cage holding dinosaurs.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":           &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolveCage(func(cage dbmodels.Cage) any { return cage.ID })},
+				"capacity":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: resolveCage(func(cage dbmodels.Cage) any { return cage.Capacity })},
+				"powerStatus":  &graphql.Field{Type: graphql.NewNonNull(powerStatusEnum), Resolve: resolveCage(func(cage dbmodels.Cage) any { return cage.PowerStatus })},
+				"currentCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: resolveCage(func(cage dbmodels.Cage) any { return cage.Occupancy })},
+				"cursor": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.String),
+					Description: "Passed as after to cages, lists the cages following this one.",
+					Resolve:     resolveCage(func(cage dbmodels.Cage) any { return recordCursor(cage, cageIDOrder, repository.CageSortColumns) }),
+				},
+				"dinosaurs": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(dinosaurType))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						cage := sourceCage(p)
+						if cage.Dinosaurs != nil {
+							return cage.Dinosaurs, nil
+						}
+						return loaders(p.Context).dinosaursByCage.load(p.Context, cage.ID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	dinosaurType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Dinosaur",
+		Description: "A dinosaur living in one of the cages.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":     &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolveDinosaur(func(dinosaur dbmodels.Dinosaur) any { return dinosaur.ID })},
+				"name":   &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: resolveDinosaur(func(dinosaur dbmodels.Dinosaur) any { return dinosaur.Name })},
+				"type":   &graphql.Field{Type: graphql.NewNonNull(dinosaurTypeEnum), Resolve: resolveDinosaur(func(dinosaur dbmodels.Dinosaur) any { return dinosaur.Type })},
+				"cageId": &graphql.Field{Type: graphql.NewNonNull(graphql.ID), Resolve: resolveDinosaur(func(dinosaur dbmodels.Dinosaur) any { return dinosaur.CageID })},
+				"species": &graphql.Field{
+					Type:    graphql.NewNonNull(speciesType),
+					Resolve: resolveDinosaur(func(dinosaur dbmodels.Dinosaur) any { return apimodels.Species(dinosaur.Species) }),
+				},
+				"cage": &graphql.Field{
+					Type: cageType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						dinosaur := sourceDinosaur(p)
+						if dinosaur.Cage != nil {
+							return dinosaur.Cage, nil
+						}
+						return loaders(p.Context).cages.load(p.Context, dinosaur.CageID), nil
+					},
+				},
+			}
+		}),
+	})
+
+	speciesType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Species",
+		Description: "A species of dinosaurs, which determines what they eat.",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"name": &graphql.Field{
+					Type:    graphql.NewNonNull(speciesNameEnum),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) { return string(p.Source.(apimodels.Species)), nil },
+				},
+				"type": &graphql.Field{
+					Type: graphql.NewNonNull(dinosaurTypeEnum),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						_, _, dinosaurType := apimodels.LookupSpeciesType(string(p.Source.(apimodels.Species)))
+						return string(dinosaurType), nil
+					},
+				},
+				"dinosaurs": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(dinosaurType))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loaders(p.Context).dinosaursBySpecies.load(p.Context, string(p.Source.(apimodels.Species))), nil
+					},
+				},
+			}
+		}),
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"cages": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(cageType))),
+				Description: "Cages matching all of the filters, ordered by ID, in pages of first cages following the cursor of the cage after.",
+				Args: graphql.FieldConfigArgument{
+					"powerStatus":     &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(powerStatusEnum))},
+					"hasFreeCapacity": &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"capacityGte":     &graphql.ArgumentConfig{Type: graphql.Int},
+					"capacityLte":     &graphql.ArgumentConfig{Type: graphql.Int},
+					"first":           &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: defaultPageLimit},
+					"after":           &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: s.listCages,
+			},
+			"cage": &graphql.Field{
+				Type:        cageType,
+				Description: "A single cage, null if it does not exist.",
+				Args:        graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve:     s.getCage,
+			},
+			"dinosaurs": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(dinosaurType))),
+				Description: "Dinosaurs matching all of the filters, ordered by ID.",
+				Args: graphql.FieldConfigArgument{
+					"species":    &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(speciesNameEnum))},
+					"type":       &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(dinosaurTypeEnum))},
+					"cageId":     &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.ID))},
+					"namePrefix": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Matched case-sensitively against the start of the name."},
+				},
+				Resolve: s.listDinosaurs,
+			},
+			"dinosaur": &graphql.Field{
+				Type:        dinosaurType,
+				Description: "A single dinosaur, null if it does not exist.",
+				Args:        graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve:     s.getDinosaur,
+			},
+			"species": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(speciesType))),
+				Description: "Every species living in the Jurassic Park.",
+				Resolve: func(graphql.ResolveParams) (interface{}, error) {
+					return apimodels.AllSpecies, nil
+				},
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"addDinosaur": &graphql.Field{
+				Type:        graphql.NewNonNull(dinosaurType),
+				Description: "Adds a new dinosaur to the cage, following the placement rules.",
+				Args: graphql.FieldConfigArgument{
+					"name":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"species": &graphql.ArgumentConfig{Type: graphql.NewNonNull(speciesNameEnum)},
+					"cageId":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: s.addDinosaur,
+			},
+			"moveDinosaur": &graphql.Field{
+				Type:        graphql.NewNonNull(dinosaurType),
+				Description: "Moves the dinosaur to another cage, following the placement rules.",
+				Args: graphql.FieldConfigArgument{
+					"id":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"cageId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: s.moveDinosaur,
+			},
+			"setCagePower": &graphql.Field{
+				Type:        graphql.NewNonNull(cageType),
+				Description: "Powers the cage up or down.",
+				Args: graphql.FieldConfigArgument{
+					"id":          &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"powerStatus": &graphql.ArgumentConfig{Type: graphql.NewNonNull(powerStatusEnum)},
+				},
+				Resolve: s.setCagePower,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+func (s graphQLSchema) listCages(p graphql.ResolveParams) (interface{}, error) {
+	filter := repository.CageFilter{PowerStatuses: stringListArg(p, "powerStatus")}
+	if hasFreeCapacity, ok := p.Args["hasFreeCapacity"].(bool); ok {
+		filter.HasFreeCapacity = &hasFreeCapacity
+	}
+	for name, bound := range map[string]**int{"capacityGte": &filter.CapacityGTE, "capacityLte": &filter.CapacityLTE} {
+		if capacity, ok := p.Args[name].(int); ok {
+			if capacity < 0 {
+				return nil, graphQLError(invalidInputError{field: name, message: "Invalid capacity. Capacity cannot be negative."}, "")
+			}
+			*bound = &capacity
+		}
+	}
+
+	// Pages are the same as those of GET /cages, with the same limits.
+	first, _ := p.Args["first"].(int)
+	if first <= 0 || first > maxPageLimit {
+		return nil, graphQLError(invalidInputError{field: "first", message: "Invalid first. First should be between 1 and 100."}, "")
+	}
+	after, _ := p.Args["after"].(string)
+	page, err := sortedPage(first, nil, after, repository.CageSortColumns)
+	if err != nil {
+		return nil, graphQLError(invalidInputError{field: "after", message: "Invalid cursor."}, "")
+	}
+
+	cages, err := s.cages.ListCages(p.Context, filter, page, false, repository.CageRelations{})
+	if err != nil {
+		return nil, graphQLError(err, "Failed to retrieve cages.")
+	}
+	for i := range cages {
+		loaders(p.Context).cages.prime(cages[i].ID, &cages[i])
+	}
+	return cages, nil
+}
+
+// cageIDOrder is the order of the cages listed by GraphQL.
+var cageIDOrder = []repository.SortKey{{Column: "id"}}
+
+func (s graphQLSchema) getCage(p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	cage, err := s.cages.GetCage(p.Context, id, false, repository.CageRelations{})
+	if errors.Is(err, service.ErrCageNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, graphQLError(err, "Failed to retrieve cage.")
+	}
+	return cage, nil
+}
+
+func (s graphQLSchema) listDinosaurs(p graphql.ResolveParams) (interface{}, error) {
+	filter := repository.DinosaurFilter{
+		Species:      stringListArg(p, "species"),
+		Types:        stringListArg(p, "type"),
+		NamePrefixes: stringListArg(p, "namePrefix"),
+	}
+	for _, value := range stringListArg(p, "cageId") {
+		cageID, err := parseID("cageId", value)
+		if err != nil {
+			return nil, err
+		}
+		filter.CageIDs = append(filter.CageIDs, cageID)
+	}
+
+	dinosaurs, err := s.dinosaurs.ListDinosaurs(p.Context, filter, repository.Page{}, false, repository.DinosaurRelations{})
+	if err != nil {
+		return nil, graphQLError(err, "Failed to retrieve dinosaurs.")
+	}
+	return dinosaurs, nil
+}
+
+func (s graphQLSchema) getDinosaur(p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	dinosaur, err := s.dinosaurs.GetDinosaur(p.Context, id, false, repository.DinosaurRelations{})
+	if errors.Is(err, service.ErrDinosaurNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, graphQLError(err, "Failed to retrieve dinosaur.")
+	}
+	return dinosaur, nil
+}
+
+// addDinosaur adds the dinosaur the same way as POST /dinosaurs, so the placement rules are checked by the service.
+func (s graphQLSchema) addDinosaur(p graphql.ResolveParams) (interface{}, error) {
+	cageID, err := idArg(p, "cageId")
+	if err != nil {
+		return nil, err
+	}
+	dinosaur, err := newDinosaur(apimodels.AddDinosaurRequest{
+		Name:    p.Args["name"].(string),
+		Species: p.Args["species"].(string),
+		CageID:  cageID,
+	})
+	if err != nil {
+		return nil, graphQLError(err, "")
+	}
+
+	if err := s.dinosaurs.AddDinosaur(p.Context, &dinosaur); err != nil {
+		return nil, graphQLError(err, "Failed to add dinosaur.")
+	}
+	return s.dinosaurWithCage(p.Context, dinosaur.ID)
+}
+
+// moveDinosaur moves the dinosaur the same way as PATCH /dinosaurs/{id}, so the placement rules are checked by the service.
+func (s graphQLSchema) moveDinosaur(p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	cageID, err := idArg(p, "cageId")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.dinosaurs.UpdateDinosaur(p.Context, id, service.DinosaurUpdate{CageID: &cageID}, service.AnyVersion); err != nil {
+		return nil, graphQLError(err, "Failed to move dinosaur.")
+	}
+	return s.dinosaurWithCage(p.Context, id)
+}
+
+// dinosaurWithCage reads the changed dinosaur along with its cage and cage-mates.
+func (s graphQLSchema) dinosaurWithCage(ctx context.Context, id uint) (interface{}, error) {
+	dinosaur, err := s.dinosaurs.GetDinosaur(ctx, id, false, repository.DinosaurRelations{Cage: &repository.CageRelations{Dinosaurs: true}})
+	if err != nil {
+		return nil, graphQLError(err, "Failed to retrieve dinosaur.")
+	}
+	return dinosaur, nil
+}
+
+// setCagePower returns the cage along with its dinosaurs, which are read while the cage is locked for the change.
+func (s graphQLSchema) setCagePower(p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p, "id")
+	if err != nil {
+		return nil, err
+	}
+	powerStatus := p.Args["powerStatus"].(string)
+
+	cage, err := s.cages.UpdateCage(p.Context, id, service.CageUpdate{PowerStatus: &powerStatus}, service.AnyVersion)
+	if err != nil {
+		return nil, graphQLError(err, "Failed to update cage.")
+	}
+	return cage, nil
+}
+
+// enumOf returns a GraphQL enum whose values are named like the API values.
+func enumOf[T ~string](name string, description string, values ...T) *graphql.Enum {
+	enumValues := graphql.EnumValueConfigMap{}
+	for _, value := range values {
+		enumValues[string(value)] = &graphql.EnumValueConfig{Value: string(value)}
+	}
+	return graphql.NewEnum(graphql.EnumConfig{Name: name, Description: description, Values: enumValues})
+}
+
+// sourceCage returns the cage being resolved, which is either read directly or through a loader.
+func sourceCage(p graphql.ResolveParams) dbmodels.Cage {
+	if cage, ok := p.Source.(*dbmodels.Cage); ok {
+		return *cage
+	}
+	return p.Source.(dbmodels.Cage)
+}
+
+func sourceDinosaur(p graphql.ResolveParams) dbmodels.Dinosaur {
+	if dinosaur, ok := p.Source.(*dbmodels.Dinosaur); ok {
+		return *dinosaur
+	}
+	return p.Source.(dbmodels.Dinosaur)
+}
+
+func resolveCage(field func(dbmodels.Cage) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return field(sourceCage(p)), nil
+	}
+}
+
+func resolveDinosaur(field func(dbmodels.Dinosaur) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		return field(sourceDinosaur(p)), nil
+	}
+}
+
+// stringListArg returns the values of a list argument, nil if it is not set.
+func stringListArg(p graphql.ResolveParams, name string) []string {
+	list, _ := p.Args[name].([]interface{})
+	var values []string
+	for _, value := range list {
+		values = append(values, value.(string))
+	}
+	return values
+}
+
+// idArg reads an ID argument, which identifies cages and dinosaurs by their number.
+func idArg(p graphql.ResolveParams, name string) (uint, error) {
+	return parseID(name, p.Args[name].(string))
+}
+
+func parseID(name string, value string) (uint, error) {
+	id, err := strconv.ParseUint(strings.TrimSpace(value), 10, 0)
+	if err != nil || id == 0 {
+		return 0, graphQLError(invalidInputError{field: name, message: "Invalid ID."}, "")
+	}
+	return uint(id), nil
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/api/parkpb"
+	"pp-jurassic-park-api/internal/service"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// NewGRPCServer serves the cages and dinosaurs as the CageService and DinosaurService of the gRPC API,
+// backed by the same services as the REST API.
+func NewGRPCServer(cages *CageGRPCServer, dinosaurs *DinosaurGRPCServer) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryActor),
+		grpc.ChainStreamInterceptor(streamActor),
+	)
+	parkpb.RegisterCageServiceServer(server, cages)
+	parkpb.RegisterDinosaurServiceServer(server, dinosaurs)
+	return server
+}
+
+// actorMetadataKey is the metadata naming who makes the call, like the X-Actor header of the REST API.
+var actorMetadataKey = strings.ToLower(ActorHeader)
+
+// unaryActor attributes the changes made by a call to the actor named in the x-actor metadata.
+// Calls without it are recorded as made by an anonymous actor.
+func unaryActor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(withCallActor(ctx), req)
+}
+
+func streamActor(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, actorStream{ServerStream: stream, ctx: withCallActor(stream.Context())})
+}
+
+func withCallActor(ctx context.Context) context.Context {
+	for _, actor := range metadata.ValueFromIncomingContext(ctx, actorMetadataKey) {
+		if actor = strings.TrimSpace(actor); actor != "" {
+			return service.WithActor(ctx, actor)
+		}
+	}
+	return ctx
+}
+
+// actorStream is a server stream whose context carries the actor of the call.
+type actorStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s actorStream) Context() context.Context {
+	return s.ctx
+}
+
+// problemCodes maps the statuses of the REST API to the gRPC status codes reported for the same errors.
+var problemCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.FailedPrecondition,
+	http.StatusPreconditionFailed:  codes.Aborted,
+	http.StatusUnprocessableEntity: codes.FailedPrecondition,
+}
+
+// grpcError translates service errors into gRPC statuses, like respondWithError. The status details carry
+// an ErrorInfo whose reason is the code of the problem, along with the field and the cage at fault.
+// Unknown errors are reported as internal errors with the provided message.
+func grpcError(err error, internalErrorMessage string) error {
+	problem, known := errorProblem(err)
+	if !known {
+		problem = apimodels.InternalErrorProblem.New(internalErrorMessage)
+	}
+
+	code, mapped := problemCodes[problem.Status]
+	if !mapped {
+		code = codes.Internal
+	}
+	info := &errdetails.ErrorInfo{Reason: string(problem.Code), Domain: "jurassic-park", Metadata: map[string]string{}}
+	if problem.Field != "" {
+		info.Metadata["field"] = problem.Field
+	}
+	if problem.CageID != 0 {
+		info.Metadata["cage_id"] = strconv.FormatUint(uint64(problem.CageID), 10)
+	}
+
+	result := status.New(code, problem.Detail)
+	if withInfo, err := result.WithDetails(info); err == nil {
+		result = withInfo
+	}
+	return result.Err()
+}
+
+// grpcPageLimit returns the number of records of a page requested with the limit, the default for 0.
+func grpcPageLimit(limit int32) (int, error) {
+	if limit == 0 {
+		return defaultPageLimit, nil
+	}
+	if limit < 0 || limit > maxPageLimit {
+		return 0, errInvalidLimit
+	}
+	return int(limit), nil
+}
+
+func violationsToProto(violations []error) []*parkpb.Violation {
+	result := []*parkpb.Violation{}
+	for _, violation := range violationsToApi(violations) {
+		result = append(result, &parkpb.Violation{Rule: string(violation.Rule), Message: violation.Message})
+	}
+	return result
+}
+
+func uintIDs(ids []uint64) []uint {
+	result := make([]uint, len(ids))
+	for i, id := range ids {
+		result[i] = uint(id)
+	}
+	return result
+}
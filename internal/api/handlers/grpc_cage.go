@@ -0,0 +1,261 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"pp-jurassic-park-api/internal/api/parkpb"
+	transform "pp-jurassic-park-api/internal/api/transform"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
+)
+
+// CageGRPCServer serves the CageService of the gRPC API.
+type CageGRPCServer struct {
+	parkpb.UnimplementedCageServiceServer
+	cages *service.CageService
+	// watchInterval is how often watched cages are checked for changes.
+	watchInterval time.Duration
+	// watchesStopped is closed once StopWatches has been called.
+	watchesStopped chan struct{}
+	stopOnce       sync.Once
+}
+
+func NewCageGRPCServer(cages *service.CageService, watchInterval time.Duration) *CageGRPCServer {
+	return &CageGRPCServer{cages: cages, watchInterval: watchInterval, watchesStopped: make(chan struct{})}
+}
+
+// StopWatches ends all running and future WatchCages calls, which would otherwise keep a graceful stop
+// of the server waiting.
+func (s *CageGRPCServer) StopWatches() {
+	s.stopOnce.Do(func() { close(s.watchesStopped) })
+}
+
+// ListCages returns the page of cages matching all given filters.
+// Used by internal systems to retrieve data around all cages at the Jurassic Park.
+func (s *CageGRPCServer) ListCages(ctx context.Context, req *parkpb.ListCagesRequest) (*parkpb.ListCagesResponse, error) {
+	filter := repository.CageFilter{HasFreeCapacity: req.HasFreeCapacity}
+	for _, powerStatus := range req.PowerStatus {
+		value, known := transform.PowerStatusFromProto(powerStatus)
+		if !known {
+			return nil, grpcError(invalidInputError{field: "power_status", message: "Invalid power_status parameter."}, "")
+		}
+		filter.PowerStatuses = append(filter.PowerStatuses, string(value))
+	}
+	if req.CapacityGte != nil && *req.CapacityGte < 0 {
+		return nil, grpcError(invalidInputError{field: "capacity_gte", message: "Invalid capacity_gte parameter."}, "")
+	}
+	if req.CapacityLte != nil && *req.CapacityLte < 0 {
+		return nil, grpcError(invalidInputError{field: "capacity_lte", message: "Invalid capacity_lte parameter."}, "")
+	}
+	filter.CapacityGTE, filter.CapacityLTE = optionalInt(req.CapacityGte), optionalInt(req.CapacityLte)
+
+	limit, err := grpcPageLimit(req.Limit)
+	if err != nil {
+		return nil, grpcError(err, "")
+	}
+	page, err := sortedPage(limit, req.Sort, req.Cursor, repository.CageSortColumns)
+	if err != nil {
+		return nil, grpcError(err, "")
+	}
+
+	relations := repository.CageRelations{Dinosaurs: req.IncludeDinosaurs}
+	cages, err := s.cages.ListCages(ctx, filter, lookahead(page), req.IncludeDeleted, relations)
+	if err != nil {
+		return nil, grpcError(err, "Failed to retrieve cages.")
+	}
+	resp := &parkpb.ListCagesResponse{}
+	cages, resp.NextCursor = nextPage(cages, page, repository.CageSortColumns)
+	resp.Cages = transform.CagesToProto(cages)
+
+	if req.IncludeTotal {
+		total, err := s.cages.CountCages(ctx, filter, req.IncludeDeleted)
+		if err != nil {
+			return nil, grpcError(err, "Failed to retrieve cages.")
+		}
+		count := int32(total)
+		resp.Total = &count
+	}
+	return resp, nil
+}
+
+func optionalInt(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+	result := int(*value)
+	return &result
+}
+
+// GetCage returns a single cage.
+// Used by internal systems to retrieve data around a single cage and its habitants at the Jurassic Park.
+func (s *CageGRPCServer) GetCage(ctx context.Context, req *parkpb.GetCageRequest) (*parkpb.GetCageResponse, error) {
+	cage, err := s.cages.GetCage(ctx, uint(req.Id), req.IncludeDeleted, repository.CageRelations{Dinosaurs: req.IncludeDinosaurs})
+	if err != nil {
+		return nil, grpcError(err, "Failed to retrieve cage.")
+	}
+	return &parkpb.GetCageResponse{Cage: transform.CageToProto(cage)}, nil
+}
+
+// CreateCage registers a new cage at the Jurassic Park.
+func (s *CageGRPCServer) CreateCage(ctx context.Context, req *parkpb.CreateCageRequest) (*parkpb.CreateCageResponse, error) {
+	powerStatus, known := transform.PowerStatusFromProto(req.PowerStatus)
+	if !known {
+		return nil, grpcError(invalidInputError{field: "power_status", message: "Invalid power status."}, "")
+	}
+	if req.Capacity <= 0 {
+		return nil, grpcError(invalidInputError{field: "capacity", message: "Capacity should be greater than 0."}, "")
+	}
+
+	cage := dbmodels.Cage{Capacity: int(req.Capacity), PowerStatus: string(powerStatus)}
+	if err := s.cages.CreateCage(ctx, &cage); err != nil {
+		return nil, grpcError(err, "Failed to create cage.")
+	}
+	return &parkpb.CreateCageResponse{Cage: transform.CageToProto(cage)}, nil
+}
+
+// UpdateCage changes the capacity or power status of a given cage.
+// Used by the perimeter controller to control power and to resize cages at the Jurassic Park.
+func (s *CageGRPCServer) UpdateCage(ctx context.Context, req *parkpb.UpdateCageRequest) (*parkpb.UpdateCageResponse, error) {
+	update := service.CageUpdate{Capacity: optionalInt(req.Capacity)}
+	if req.PowerStatus != nil {
+		powerStatus, known := transform.PowerStatusFromProto(*req.PowerStatus)
+		if !known {
+			return nil, grpcError(invalidInputError{field: "power_status", message: "Invalid power status."}, "")
+		}
+		value := string(powerStatus)
+		update.PowerStatus = &value
+	}
+	if req.Capacity != nil && *req.Capacity <= 0 {
+		return nil, grpcError(invalidInputError{field: "capacity", message: "Capacity should be greater than 0."}, "")
+	}
+
+	cage, err := s.cages.UpdateCage(ctx, uint(req.Id), update, uint(req.ExpectedVersion))
+	if err != nil {
+		return nil, grpcError(err, "Failed to update cage.")
+	}
+	return &parkpb.UpdateCageResponse{Cage: transform.CageToProto(cage)}, nil
+}
+
+// DeleteCage deletes an empty cage.
+func (s *CageGRPCServer) DeleteCage(ctx context.Context, req *parkpb.DeleteCageRequest) (*parkpb.DeleteCageResponse, error) {
+	if err := s.cages.DeleteCage(ctx, uint(req.Id), uint(req.ExpectedVersion)); err != nil {
+		return nil, grpcError(err, "Failed to delete cage.")
+	}
+	return &parkpb.DeleteCageResponse{}, nil
+}
+
+// RestoreCage restores the deleted cage.
+func (s *CageGRPCServer) RestoreCage(ctx context.Context, req *parkpb.RestoreCageRequest) (*parkpb.RestoreCageResponse, error) {
+	cage, err := s.cages.RestoreCage(ctx, uint(req.Id), uint(req.ExpectedVersion))
+	if err != nil {
+		return nil, grpcError(err, "Failed to restore cage.")
+	}
+	return &parkpb.RestoreCageResponse{Cage: transform.CageToProto(cage)}, nil
+}
+
+// GetCageHistory returns the recorded changes of the cage, oldest first.
+func (s *CageGRPCServer) GetCageHistory(ctx context.Context, req *parkpb.GetCageHistoryRequest) (*parkpb.GetHistoryResponse, error) {
+	limit, err := grpcPageLimit(req.Limit)
+	if err != nil {
+		return nil, grpcError(err, "")
+	}
+	afterID, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return nil, grpcError(err, "")
+	}
+
+	page, err := s.cages.GetCageHistory(ctx, uint(req.Id), afterID, limit)
+	if err != nil {
+		return nil, grpcError(err, "Failed to retrieve cage history.")
+	}
+	return &parkpb.GetHistoryResponse{
+		History:    transform.HistoryToProto(page.Entries),
+		NextCursor: encodeCursor(page.NextAfterID),
+	}, nil
+}
+
+// WatchCages sends the current state of the watched cages, then checks them for changes every watch interval
+// and sends every cage that has been created, updated, deleted or restored since, until the call is cancelled.
+// A cage changes along with its dinosaurs. Changes made between two checks are reported as a single event.
+// Each check only reads the cages changed since shortly before the previous one.
+// The call ends without an error once the server stops watching.
+// Used by the perimeter controller to follow the power and occupancy of the cages at the Jurassic Park.
+func (s *CageGRPCServer) WatchCages(req *parkpb.WatchCagesRequest, stream parkpb.CageService_WatchCagesServer) error {
+	ctx := stream.Context()
+	filter := repository.CageFilter{IDs: uintIDs(req.Ids)}
+	relations := repository.CageRelations{Dinosaurs: req.IncludeDinosaurs}
+
+	// Deleted cages are read as well, so that their restoration is noticed.
+	checked := time.Now()
+	cages, err := s.cages.ListCages(ctx, filter, repository.Page{}, true, relations)
+	if err != nil {
+		return grpcError(err, "Failed to retrieve cages.")
+	}
+	seen := map[uint]dbmodels.Cage{}
+	for _, cage := range cages {
+		seen[cage.ID] = cage
+		if cage.DeletedAt.Valid {
+			continue
+		}
+		if err := stream.Send(&parkpb.CageEvent{Type: parkpb.CageEvent_TYPE_CURRENT, Cage: transform.CageToProto(cage)}); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(s.watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.watchesStopped:
+			return nil
+		case <-ticker.C:
+		}
+
+		// Cages seen already are only reported again once their versions have changed.
+		since := checked.Add(-watchLookback)
+		checked = time.Now()
+		changed := filter
+		changed.ChangedSince = &since
+		cages, err := s.cages.ListCages(ctx, changed, repository.Page{}, true, relations)
+		if err != nil {
+			return grpcError(err, "Failed to retrieve cages.")
+		}
+		for _, cage := range cages {
+			previous, known := seen[cage.ID]
+			seen[cage.ID] = cage
+			if eventType := cageChange(previous, known, cage); eventType != parkpb.CageEvent_TYPE_UNSPECIFIED {
+				if err := stream.Send(&parkpb.CageEvent{Type: eventType, Cage: transform.CageToProto(cage)}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// watchLookback is how long before the previous check WatchCages reads changes from, so that changes made by
+// transactions still running at the previous check are not missed.
+const watchLookback = 30 * time.Second
+
+// cageChange returns how the cage has changed since it was previously seen, TYPE_UNSPECIFIED if it has not.
+// Deleting a cage keeps its version, so only restorations and other changes are told apart by it.
+func cageChange(previous dbmodels.Cage, known bool, cage dbmodels.Cage) parkpb.CageEvent_Type {
+	switch {
+	case !known && cage.DeletedAt.Valid:
+		return parkpb.CageEvent_TYPE_UNSPECIFIED
+	case !known:
+		return parkpb.CageEvent_TYPE_CREATED
+	case !previous.DeletedAt.Valid && cage.DeletedAt.Valid:
+		return parkpb.CageEvent_TYPE_DELETED
+	case previous.DeletedAt.Valid && !cage.DeletedAt.Valid:
+		return parkpb.CageEvent_TYPE_RESTORED
+	case (previous.Version != cage.Version || previous.OccupancyVersion != cage.OccupancyVersion) && !cage.DeletedAt.Valid:
+		return parkpb.CageEvent_TYPE_UPDATED
+	default:
+		return parkpb.CageEvent_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/api/parkpb"
+	transform "pp-jurassic-park-api/internal/api/transform"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
+)
+
+// DinosaurGRPCServer serves the DinosaurService of the gRPC API.
+type DinosaurGRPCServer struct {
+	parkpb.UnimplementedDinosaurServiceServer
+	dinosaurs *service.DinosaurService
+}
+
+func NewDinosaurGRPCServer(dinosaurs *service.DinosaurService) *DinosaurGRPCServer {
+	return &DinosaurGRPCServer{dinosaurs: dinosaurs}
+}
+
+// ListDinosaurs returns the page of dinosaurs matching all given filters.
+// Used by internal systems to retrieve data around all dinosaurs at the Jurassic Park.
+func (s *DinosaurGRPCServer) ListDinosaurs(ctx context.Context, req *parkpb.ListDinosaursRequest) (*parkpb.ListDinosaursResponse, error) {
+	filter := repository.DinosaurFilter{CageIDs: uintIDs(req.CageId)}
+	for _, species := range req.Species {
+		value, known := transform.SpeciesFromProto(species)
+		if !known {
+			return nil, grpcError(invalidInputError{field: "species", message: "Invalid species parameter."}, "")
+		}
+		filter.Species = append(filter.Species, string(value))
+	}
+	for _, dinosaurType := range req.Type {
+		value, known := transform.DinosaurTypeFromProto(dinosaurType)
+		if !known {
+			return nil, grpcError(invalidInputError{field: "type", message: "Invalid type parameter."}, "")
+		}
+		filter.Types = append(filter.Types, string(value))
+	}
+	for _, prefix := range req.Name {
+		if prefix != "" {
+			filter.NamePrefixes = append(filter.NamePrefixes, prefix)
+		}
+	}
+
+	limit, err := grpcPageLimit(req.Limit)
+	if err != nil {
+		return nil, grpcError(err, "")
+	}
+	page, err := sortedPage(limit, req.Sort, req.Cursor, repository.DinosaurSortColumns)
+	if err != nil {
+		return nil, grpcError(err, "")
+	}
+
+	dinosaurs, err := s.dinosaurs.ListDinosaurs(ctx, filter, lookahead(page), req.IncludeDeleted, withCage(req.IncludeCage))
+	if err != nil {
+		return nil, grpcError(err, "Failed to retrieve dinosaurs.")
+	}
+	resp := &parkpb.ListDinosaursResponse{}
+	dinosaurs, resp.NextCursor = nextPage(dinosaurs, page, repository.DinosaurSortColumns)
+	resp.Dinosaurs = transform.DinosaursToProto(dinosaurs)
+
+	if req.IncludeTotal {
+		total, err := s.dinosaurs.CountDinosaurs(ctx, filter, req.IncludeDeleted)
+		if err != nil {
+			return nil, grpcError(err, "Failed to retrieve dinosaurs.")
+		}
+		count := int32(total)
+		resp.Total = &count
+	}
+	return resp, nil
+}
+
+// withCage returns the relations loading the cage of the dinosaurs along with its dinosaurs, if requested.
+func withCage(include bool) repository.DinosaurRelations {
+	if !include {
+		return repository.DinosaurRelations{}
+	}
+	return repository.DinosaurRelations{Cage: &repository.CageRelations{Dinosaurs: true}}
+}
+
+// GetDinosaur returns a single dinosaur.
+func (s *DinosaurGRPCServer) GetDinosaur(ctx context.Context, req *parkpb.GetDinosaurRequest) (*parkpb.GetDinosaurResponse, error) {
+	dinosaur, err := s.dinosaurs.GetDinosaur(ctx, uint(req.Id), req.IncludeDeleted, withCage(req.IncludeCage))
+	if err != nil {
+		return nil, grpcError(err, "Failed to retrieve dinosaur.")
+	}
+	return &parkpb.GetDinosaurResponse{Dinosaur: transform.DinosaurToProto(dinosaur)}, nil
+}
+
+// newDinosaurFromProto validates the new dinosaur like newDinosaur. Unknown species are reported as such.
+func newDinosaurFromProto(req *parkpb.NewDinosaur) (dbmodels.Dinosaur, error) {
+	if req == nil {
+		return dbmodels.Dinosaur{}, invalidInputError{field: "dinosaur", message: "Dinosaur is required."}
+	}
+	species, _ := transform.SpeciesFromProto(req.Species)
+	return newDinosaur(apimodels.AddDinosaurRequest{Name: req.Name, Species: string(species), CageID: uint(req.CageId)})
+}
+
+// AddDinosaur adds a new dinosaur to its cage, or only checks its placement in a dry run.
+// Used when a dinosaur is imported to the Jurassic Park.
+func (s *DinosaurGRPCServer) AddDinosaur(ctx context.Context, req *parkpb.AddDinosaurRequest) (*parkpb.AddDinosaurResponse, error) {
+	dinosaur, err := newDinosaurFromProto(req.Dinosaur)
+	if err != nil {
+		return nil, grpcError(err, "Failed to add dinosaur.")
+	}
+
+	if req.DryRun {
+		violations, err := s.dinosaurs.PlacementViolations(ctx, dinosaur.CageID, dinosaur)
+		if err != nil {
+			return nil, grpcError(err, "Failed to check dinosaur.")
+		}
+		return &parkpb.AddDinosaurResponse{Dinosaur: transform.DinosaurToProto(dinosaur), Check: placementCheck(violations)}, nil
+	}
+
+	if err := s.dinosaurs.AddDinosaur(ctx, &dinosaur); err != nil {
+		return nil, grpcError(err, "Failed to add dinosaur.")
+	}
+	return &parkpb.AddDinosaurResponse{Dinosaur: transform.DinosaurToProto(dinosaur)}, nil
+}
+
+func placementCheck(violations []error) *parkpb.PlacementCheck {
+	return &parkpb.PlacementCheck{Compatible: len(violations) == 0, Violations: violationsToProto(violations)}
+}
+
+// AddDinosaurs adds a batch of new dinosaurs to their cages at once, reporting the outcome for each of them.
+// Unlike the REST API, a rejected all-or-nothing batch is not an error, it is reported by the rejected flag.
+// Used when a whole shipment of dinosaurs arrives at the Jurassic Park.
+func (s *DinosaurGRPCServer) AddDinosaurs(ctx context.Context, req *parkpb.AddDinosaursRequest) (*parkpb.AddDinosaursResponse, error) {
+	if req.Mode != parkpb.BatchMode_BATCH_MODE_UNSPECIFIED && req.Mode != parkpb.BatchMode_BATCH_MODE_ALL_OR_NOTHING &&
+		req.Mode != parkpb.BatchMode_BATCH_MODE_BEST_EFFORT {
+		return nil, grpcError(invalidInputError{field: "mode", message: "Invalid mode."}, "")
+	}
+	if len(req.Dinosaurs) == 0 || len(req.Dinosaurs) > maxBatchSize {
+		return nil, grpcError(invalidInputError{field: "dinosaurs", message: "Invalid batch. A batch should hold between 1 and 100 dinosaurs."}, "")
+	}
+
+	items := make([]service.BatchItem, len(req.Dinosaurs))
+	for i, dinosaurReq := range req.Dinosaurs {
+		items[i].Dinosaur, items[i].Err = newDinosaurFromProto(dinosaurReq)
+	}
+
+	err := s.dinosaurs.AddDinosaurs(ctx, items, req.Mode != parkpb.BatchMode_BATCH_MODE_BEST_EFFORT)
+	if err != nil && !errors.Is(err, service.ErrBatchRejected) {
+		return nil, grpcError(err, "Failed to add dinosaurs.")
+	}
+
+	rejected := err != nil
+	resp := &parkpb.AddDinosaursResponse{Rejected: rejected}
+	for i, result := range batchResults(items, rejected) {
+		pbResult := &parkpb.AddDinosaursResult{
+			Index:  int32(result.Index),
+			Status: batchItemStatusToProto[result.Status],
+			Code:   string(result.Code),
+			Error:  result.Error,
+		}
+		if result.Status == apimodels.BatchItemCreated {
+			pbResult.Dinosaur = transform.DinosaurToProto(items[i].Dinosaur)
+		}
+		resp.Results = append(resp.Results, pbResult)
+	}
+	return resp, nil
+}
+
+var batchItemStatusToProto = map[apimodels.BatchItemStatus]parkpb.AddDinosaursResult_Status{
+	apimodels.BatchItemCreated:    parkpb.AddDinosaursResult_STATUS_CREATED,
+	apimodels.BatchItemRejected:   parkpb.AddDinosaursResult_STATUS_REJECTED,
+	apimodels.BatchItemRolledBack: parkpb.AddDinosaursResult_STATUS_ROLLED_BACK,
+}
+
+// UpdateDinosaur changes the name or species of an existing dinosaur, or moves it to a different cage,
+// or only checks the change in a dry run.
+// Used to move dinosaurs around the Jurassic Park and to correct their records.
+func (s *DinosaurGRPCServer) UpdateDinosaur(ctx context.Context, req *parkpb.UpdateDinosaurRequest) (*parkpb.UpdateDinosaurResponse, error) {
+	if req.Name != nil && strings.TrimSpace(*req.Name) == "" {
+		return nil, grpcError(invalidInputError{field: "name", message: "Invalid name. Name cannot be blank."}, "")
+	}
+
+	update := service.DinosaurUpdate{Name: req.Name}
+	if req.Species != nil {
+		species, known := transform.SpeciesFromProto(*req.Species)
+		if !known {
+			return nil, grpcError(invalidInputError{field: "species", message: "Unknown species."}, "")
+		}
+		value := string(species)
+		update.Species = &value
+	}
+	if req.CageId != nil {
+		cageID := uint(*req.CageId)
+		update.CageID = &cageID
+	}
+
+	if req.DryRun {
+		dinosaur, violations, err := s.dinosaurs.UpdateViolations(ctx, uint(req.Id), update, uint(req.ExpectedVersion))
+		if err != nil {
+			return nil, grpcError(err, "Failed to check dinosaur.")
+		}
+		return &parkpb.UpdateDinosaurResponse{Dinosaur: transform.DinosaurToProto(dinosaur), Check: placementCheck(violations)}, nil
+	}
+
+	dinosaur, err := s.dinosaurs.UpdateDinosaur(ctx, uint(req.Id), update, uint(req.ExpectedVersion))
+	if err != nil {
+		return nil, grpcError(err, "Failed to update dinosaur.")
+	}
+	return &parkpb.UpdateDinosaurResponse{Dinosaur: transform.DinosaurToProto(dinosaur)}, nil
+}
+
+// RemoveDinosaur removes the dinosaur from its cage.
+// Used when a dinosaur is exported from the Jurassic Park.
+func (s *DinosaurGRPCServer) RemoveDinosaur(ctx context.Context, req *parkpb.RemoveDinosaurRequest) (*parkpb.RemoveDinosaurResponse, error) {
+	if err := s.dinosaurs.RemoveDinosaur(ctx, uint(req.Id), uint(req.ExpectedVersion)); err != nil {
+		return nil, grpcError(err, "Failed to remove dinosaur.")
+	}
+	return &parkpb.RemoveDinosaurResponse{}, nil
+}
+
+// RestoreDinosaur restores the deleted dinosaur into the cage it was removed from.
+func (s *DinosaurGRPCServer) RestoreDinosaur(ctx context.Context, req *parkpb.RestoreDinosaurRequest) (*parkpb.RestoreDinosaurResponse, error) {
+	dinosaur, err := s.dinosaurs.RestoreDinosaur(ctx, uint(req.Id), uint(req.ExpectedVersion))
+	if err != nil {
+		return nil, grpcError(err, "Failed to restore dinosaur.")
+	}
+	return &parkpb.RestoreDinosaurResponse{Dinosaur: transform.DinosaurToProto(dinosaur)}, nil
+}
+
+// GetDinosaurHistory returns the recorded changes of the dinosaur, oldest first.
+func (s *DinosaurGRPCServer) GetDinosaurHistory(ctx context.Context, req *parkpb.GetDinosaurHistoryRequest) (*parkpb.GetHistoryResponse, error) {
+	limit, err := grpcPageLimit(req.Limit)
+	if err != nil {
+		return nil, grpcError(err, "")
+	}
+	afterID, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return nil, grpcError(err, "")
+	}
+
+	page, err := s.dinosaurs.GetDinosaurHistory(ctx, uint(req.Id), afterID, limit)
+	if err != nil {
+		return nil, grpcError(err, "Failed to retrieve dinosaur history.")
+	}
+	return &parkpb.GetHistoryResponse{
+		History:    transform.HistoryToProto(page.Entries),
+		NextCursor: encodeCursor(page.NextAfterID),
+	}, nil
+}
+
+// GetCompatibility checks whether a dinosaur of the species, or the existing dinosaur, can be placed in the cage,
+// listing every rule the placement would break. Nothing is changed.
+// Used to plan the placement of dinosaurs at the Jurassic Park.
+func (s *DinosaurGRPCServer) GetCompatibility(ctx context.Context, req *parkpb.GetCompatibilityRequest) (*parkpb.GetCompatibilityResponse, error) {
+	var dinosaur dbmodels.Dinosaur
+	switch checked := req.Dinosaur.(type) {
+	case *parkpb.GetCompatibilityRequest_Species:
+		species, known := transform.SpeciesFromProto(checked.Species)
+		if !known {
+			return nil, grpcError(invalidInputError{field: "species", message: "Invalid species parameter."}, "")
+		}
+		_, _, dinosaurType := apimodels.LookupSpeciesType(string(species))
+		dinosaur = dbmodels.Dinosaur{Species: string(species), Type: string(dinosaurType)}
+	case *parkpb.GetCompatibilityRequest_DinosaurId:
+		var err error
+		if dinosaur, err = s.dinosaurs.GetDinosaur(ctx, uint(checked.DinosaurId), false, repository.DinosaurRelations{}); err != nil {
+			return nil, grpcError(err, "Failed to check compatibility.")
+		}
+	default:
+		return nil, grpcError(invalidInputError{message: "Either species or dinosaur_id is required."}, "")
+	}
+
+	violations, err := s.dinosaurs.PlacementViolations(ctx, uint(req.CageId), dinosaur)
+	if err != nil {
+		return nil, grpcError(err, "Failed to check compatibility.")
+	}
+	return &parkpb.GetCompatibilityResponse{Compatible: len(violations) == 0, Violations: violationsToProto(violations)}, nil
+}
+
+// MoveDinosaurs moves several dinosaurs at once, applying either all of the moves or none.
+// Used to rotate dinosaurs between the cages of the Jurassic Park.
+func (s *DinosaurGRPCServer) MoveDinosaurs(ctx context.Context, req *parkpb.MoveDinosaursRequest) (*parkpb.MoveDinosaursResponse, error) {
+	if len(req.Moves) == 0 || len(req.Moves) > maxBatchSize {
+		return nil, grpcError(invalidInputError{field: "moves", message: "Invalid moves. Between 1 and 100 dinosaurs can be moved at once."}, "")
+	}
+
+	moves := make([]service.Move, len(req.Moves))
+	for i, move := range req.Moves {
+		moves[i] = service.Move{DinosaurID: uint(move.DinosaurId), TargetCageID: uint(move.TargetCageId)}
+	}
+
+	dinosaurs, err := s.dinosaurs.MoveDinosaurs(ctx, moves)
+	if err != nil {
+		return nil, grpcError(err, "Failed to move dinosaurs.")
+	}
+	return &parkpb.MoveDinosaursResponse{Dinosaurs: transform.DinosaursToProto(dinosaurs)}, nil
+}
@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"pp-jurassic-park-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader carries a key chosen by the client for a creation request. Retries of the request
+// with the same key return the response to the first one instead of creating the resource again.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set on responses replayed for a retried request.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+const maxIdempotencyKeyLength = 255
+
+// idempotent handles a creation request at most once per Idempotency-Key and route. The response to the first
+// request with a key is stored along with a fingerprint of the request, and returned again for retries
+// with the same key. Requests without the header are handled as usual.
+// Server errors are not stored, so that the request can be retried with the same key.
+//
+// Keys are shared by all versions of the API, so a retry sent to another version does not create the resource again.
+// As the stored response is in the format of the version of the first request, the version is part of the fingerprint,
+// and a retry sent to another version is rejected as a different request.
+func idempotent(c *gin.Context, keys *service.IdempotencyService, handle gin.HandlerFunc) {
+	key := c.GetHeader(IdempotencyKeyHeader)
+	if key == "" {
+		handle(c)
+		return
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		respondWithValidationError(c, IdempotencyKeyHeader, "Invalid Idempotency-Key header. Keys are at most 255 characters long.")
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		respondWithValidationError(c, "", "Invalid input.")
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	// The key is stored or released even if the client has gone away meanwhile.
+	ctx := context.WithoutCancel(c.Request.Context())
+	version, path := unversioned(c.FullPath())
+	scope := c.Request.Method + " " + path
+	fingerprint := requestFingerprint(version, c.Request, body)
+	stored, err := keys.Begin(ctx, scope, key, fingerprint)
+	if err != nil {
+		respondWithError(c, err, "Failed to check idempotency key.")
+		return
+	}
+	if stored != nil {
+		if stored.ETag != "" {
+			c.Header("ETag", stored.ETag)
+		}
+		c.Header(IdempotentReplayedHeader, "true")
+		c.Data(stored.StatusCode, stored.ContentType, stored.Body)
+		return
+	}
+
+	// The handler has to be done well before the lease of the key runs out, or a retry could take the key over
+	// while it is still running.
+	handlerCtx, cancel := context.WithTimeout(c.Request.Context(), keys.Timeout())
+	defer cancel()
+	request := c.Request
+	c.Request = c.Request.WithContext(handlerCtx)
+	recorder := &responseRecorder{ResponseWriter: c.Writer}
+	c.Writer = recorder
+	// Released unless the response is stored, also when the handler panics, so that the request can be retried
+	// right away rather than once the lease of the key has run out.
+	handled := false
+	defer func() {
+		c.Writer = recorder.ResponseWriter
+		c.Request = request
+		if handled {
+			return
+		}
+		if err := keys.Release(ctx, scope, key); err != nil {
+			_ = c.Error(fmt.Errorf("releasing idempotency key: %w", err))
+		}
+	}()
+	handle(c)
+
+	if recorder.Status() >= http.StatusInternalServerError {
+		return
+	}
+	handled = true
+	// The response has been sent already. Should storing it fail, the key stays reserved until its lease
+	// runs out, which keeps retries sent meanwhile from creating the resource again.
+	err = keys.Complete(ctx, scope, key, fingerprint, service.StoredResponse{
+		StatusCode:  recorder.Status(),
+		ContentType: recorder.Header().Get("Content-Type"),
+		ETag:        recorder.Header().Get("ETag"),
+		Body:        recorder.body.Bytes(),
+	})
+	if err != nil {
+		_ = c.Error(fmt.Errorf("storing response of idempotency key: %w", err))
+	}
+}
+
+// requestFingerprint identifies the version of the API, the query and the JSON body of a request.
+// Bodies differing only in whitespace share the fingerprint.
+func requestFingerprint(version string, request *http.Request, body []byte) string {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, body); err != nil {
+		compact.Reset()
+		compact.Write(body)
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(version))
+	hash.Write([]byte{0})
+	hash.Write([]byte(request.URL.RawQuery))
+	hash.Write([]byte{0})
+	hash.Write(compact.Bytes())
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// responseRecorder passes the response through to the client, keeping a copy of its body.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
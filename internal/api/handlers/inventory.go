@@ -0,0 +1,446 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	transform "pp-jurassic-park-api/internal/api/transform"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// inventoryContentTypes are the media types the inventory is exported and imported as.
+var inventoryContentTypes = map[apimodels.InventoryFormat]string{
+	apimodels.CSV:    "text/csv",
+	apimodels.NDJSON: "application/x-ndjson",
+}
+
+// inventoryColumns are the columns of the CSV format, in the order they are exported.
+var inventoryColumns = []string{"kind", "id", "capacity", "power_status", "name", "species", "type", "cage_id", "cage_ref"}
+
+const (
+	maxImportRecords = 10000
+	// maxImportLineLength is the length of the longest NDJSON line read.
+	maxImportLineLength = 64 * 1024
+)
+
+// InventoryHandler serves the export and import of the park inventory, as kept in spreadsheets by park operations.
+type InventoryHandler struct {
+	inventory *service.InventoryService
+}
+
+func NewInventoryHandler(inventory *service.InventoryService) *InventoryHandler {
+	return &InventoryHandler{inventory: inventory}
+}
+
+// ExportInventory streams all cages of the park, followed by all dinosaurs, in the requested format.
+// Used by park operations to take the census of the Jurassic Park into their spreadsheets.
+func (h *InventoryHandler) ExportInventory(c *gin.Context) {
+	format, ok := inventoryFormat(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", inventoryContentTypes[format])
+	c.Header("Content-Disposition", `attachment; filename="inventory.`+string(format)+`"`)
+	c.Status(http.StatusOK)
+
+	writer := newInventoryWriter(c.Writer, format)
+	err := h.inventory.Export(c.Request.Context(),
+		func(cage dbmodels.Cage) error { return writer.write(transform.CageToInventory(cage)) },
+		func(dinosaur dbmodels.Dinosaur) error { return writer.write(transform.DinosaurToInventory(dinosaur)) },
+	)
+	if err == nil {
+		err = writer.flush()
+	}
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			respondWithError(c, err, "Failed to export inventory.")
+			return
+		}
+		// Part of the inventory has been sent already, so the export can only be cut short.
+		_ = c.Error(err)
+	}
+}
+
+// inventoryFormat reads the format query parameter, which defaults to CSV.
+// Responds with 400 and returns false when the format is unknown.
+func inventoryFormat(c *gin.Context) (apimodels.InventoryFormat, bool) {
+	format := apimodels.InventoryFormat(c.DefaultQuery("format", string(apimodels.CSV)))
+	if _, known := inventoryContentTypes[format]; !known {
+		invalidParameter(c, "format")
+		return "", false
+	}
+	return format, true
+}
+
+// inventoryWriter writes inventory records in one of the formats, buffering them until flushed.
+type inventoryWriter struct {
+	csv  *csv.Writer
+	json *json.Encoder
+	buf  *bufio.Writer
+}
+
+// newInventoryWriter returns a writer of the format. CSV starts with the header row.
+func newInventoryWriter(w io.Writer, format apimodels.InventoryFormat) *inventoryWriter {
+	if format == apimodels.CSV {
+		writer := &inventoryWriter{csv: csv.NewWriter(w)}
+		_ = writer.csv.Write(inventoryColumns)
+		return writer
+	}
+	buf := bufio.NewWriter(w)
+	return &inventoryWriter{json: json.NewEncoder(buf), buf: buf}
+}
+
+func (w *inventoryWriter) write(record apimodels.InventoryRecord) error {
+	if w.json != nil {
+		return w.json.Encode(record)
+	}
+	return w.csv.Write([]string{
+		string(record.Kind),
+		formatOptional(record.ID),
+		formatOptional(uint(record.Capacity)),
+		string(record.PowerStatus),
+		record.Name,
+		record.Species,
+		string(record.Type),
+		formatOptional(record.CageID),
+		record.CageRef,
+	})
+}
+
+func (w *inventoryWriter) flush() error {
+	if w.buf != nil {
+		return w.buf.Flush()
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
+
+// formatOptional leaves zero values of columns not belonging to the kind of the record empty.
+func formatOptional(value uint) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(value), 10)
+}
+
+// ImportInventory adds the cages and dinosaurs of an inventory in the requested format, all of them or none.
+// Every record is validated and checked with the placement rules, and the report lists the line and the reason
+// of each rejected record. An import with any record rejected is answered with 422 and adds nothing.
+// A dry run validates the records in the same way without adding them.
+// Used by park operations to enter the census of the Jurassic Park kept in their spreadsheets.
+func (h *InventoryHandler) ImportInventory(c *gin.Context) {
+	format, ok := inventoryFormat(c)
+	if !ok {
+		return
+	}
+	dryRun, ok := queryBool(c, "dry_run")
+	if !ok {
+		return
+	}
+
+	var rows []importRow
+	var err error
+	if format == apimodels.CSV {
+		rows, err = readCSVImport(c.Request.Body)
+	} else {
+		rows, err = readNDJSONImport(c.Request.Body)
+	}
+	if err != nil {
+		respondWithError(c, err, "Failed to read import.")
+		return
+	}
+	if len(rows) == 0 {
+		respondWithValidationError(c, "", "Invalid import. An import should hold at least one record.")
+		return
+	}
+
+	checkOnly := dryRun != nil && *dryRun
+	imp, unknownKinds := newImport(rows)
+	// Records of unknown kinds are left out of the import, which is then only checked, so that the report is complete.
+	err = h.inventory.Import(c.Request.Context(), &imp, checkOnly || unknownKinds)
+	if err != nil && !errors.Is(err, service.ErrImportRejected) {
+		respondWithError(c, err, "Failed to import inventory.")
+		return
+	}
+	rejected := err != nil || unknownKinds
+
+	resp := apimodels.ImportInventoryResponse{DryRun: checkOnly, Errors: importErrors(rows, imp)}
+	if !rejected {
+		resp.Cages, resp.Dinosaurs = len(imp.Cages), len(imp.Dinosaurs)
+	}
+	c.JSON(batchStatus(rejected && !checkOnly), resp)
+}
+
+// importRow is a record of an import, along with the line it was read from.
+type importRow struct {
+	line   int
+	record apimodels.InventoryRecord
+	// err is set for records which cannot be read.
+	err error
+	// index is the position of the record among the cages or the dinosaurs of the import.
+	index int
+}
+
+// readCSVImport reads the records of a CSV import. The header row names the columns, in any order,
+// and only the kind column is required. Rows with a different number of columns than the header are rejected.
+// Returns an error when the header is invalid or the CSV is malformed.
+func readCSVImport(body io.Reader) ([]importRow, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, csvError(err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		// Spreadsheets may save the CSV with a byte order mark.
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(inventoryColumns, name) {
+			return nil, invalidInputError{message: fmt.Sprintf("Invalid header. Unknown column %q.", name)}
+		}
+		if _, found := columns[name]; found {
+			return nil, invalidInputError{message: fmt.Sprintf("Invalid header. Duplicate column %q.", name)}
+		}
+		columns[name] = i
+	}
+	if _, found := columns["kind"]; !found {
+		return nil, invalidInputError{message: `Invalid header. Missing column "kind".`}
+	}
+
+	rows := []importRow{}
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if len(rows) == maxImportRecords {
+			return nil, errTooManyRecords
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			rows = append(rows, importRow{line: parseErr.StartLine, err: invalidInputError{
+				message: fmt.Sprintf("Invalid row. It has %d columns, the header %d.", len(fields), len(header)),
+			}})
+			continue
+		}
+		if err != nil {
+			return nil, csvError(err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := importRow{line: line}
+		row.record, row.err = csvRecord(fields, columns)
+		rows = append(rows, row)
+	}
+}
+
+// csvError reports malformed CSV, after which no further rows can be read.
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return invalidInputError{message: fmt.Sprintf("Invalid CSV on line %d.", parseErr.Line)}
+	}
+	return err
+}
+
+var errTooManyRecords = invalidInputError{
+	message: fmt.Sprintf("Invalid import. An import should hold at most %d records.", maxImportRecords),
+}
+
+// csvRecord returns the record of the row. Numeric columns left empty are 0.
+func csvRecord(fields []string, columns map[string]int) (apimodels.InventoryRecord, error) {
+	value := func(column string) string {
+		if i, found := columns[column]; found {
+			return strings.TrimSpace(fields[i])
+		}
+		return ""
+	}
+	number := func(column string) (uint64, error) {
+		if value(column) == "" {
+			return 0, nil
+		}
+		parsed, err := strconv.ParseUint(value(column), 10, 32)
+		if err != nil {
+			return 0, invalidInputError{field: column, message: "Invalid " + column + "."}
+		}
+		return parsed, nil
+	}
+
+	record := apimodels.InventoryRecord{
+		Kind:        apimodels.InventoryKind(value("kind")),
+		PowerStatus: apimodels.PowerStatus(value("power_status")),
+		Name:        value("name"),
+		Species:     value("species"),
+		Type:        apimodels.DinosaurType(value("type")),
+		CageRef:     value("cage_ref"),
+	}
+	id, err := number("id")
+	if err != nil {
+		return record, err
+	}
+	capacity, err := number("capacity")
+	if err != nil {
+		return record, err
+	}
+	cageID, err := number("cage_id")
+	if err != nil {
+		return record, err
+	}
+	record.ID, record.Capacity, record.CageID = uint(id), int(capacity), uint(cageID)
+	return record, nil
+}
+
+// readNDJSONImport reads the records of an NDJSON import, one JSON object per line. Blank lines are skipped.
+// Returns an error when a line is too long to be read.
+func readNDJSONImport(body io.Reader) ([]importRow, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(nil, maxImportLineLength)
+	rows := []importRow{}
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		if len(rows) == maxImportRecords {
+			return nil, errTooManyRecords
+		}
+
+		row := importRow{line: line}
+		if err := json.Unmarshal(text, &row.record); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) && typeErr.Field != "" {
+				row.err = invalidInputError{field: typeErr.Field, message: "Invalid " + typeErr.Field + "."}
+			} else {
+				row.err = invalidInputError{message: "Invalid JSON."}
+			}
+		}
+		rows = append(rows, row)
+	}
+	if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+		return nil, invalidInputError{message: fmt.Sprintf("Invalid NDJSON. Line %d is too long.", line+1)}
+	}
+	return rows, scanner.Err()
+}
+
+// newImport validates the records and splits them into the cages and dinosaurs of the import,
+// which are handed in rejected if invalid. Reports whether any record is of an unknown kind.
+func newImport(rows []importRow) (service.Import, bool) {
+	imp := service.Import{}
+	unknownKinds := false
+	refs := map[string]bool{}
+	for i := range rows {
+		row := &rows[i]
+		switch row.record.Kind {
+		case apimodels.InventoryCage:
+			row.index = len(imp.Cages)
+			item := service.ImportCage{Ref: row.record.CageRef, Err: row.err}
+			if item.Err == nil && item.Ref != "" && refs[item.Ref] {
+				item.Err = invalidInputError{field: "cage_ref", message: "Duplicate cage_ref. Cage refs should be unique within the import."}
+			}
+			if item.Err == nil {
+				item.Cage, item.Err = newImportCage(row.record)
+			}
+			refs[item.Ref] = true
+			imp.Cages = append(imp.Cages, item)
+		case apimodels.InventoryDinosaur:
+			row.index = len(imp.Dinosaurs)
+			item := service.ImportDinosaur{CageRef: row.record.CageRef, Err: row.err}
+			if item.Err == nil {
+				item.Dinosaur, item.Err = newImportDinosaur(row.record)
+			}
+			imp.Dinosaurs = append(imp.Dinosaurs, item)
+		default:
+			unknownKinds = true
+			if row.err == nil {
+				row.err = invalidInputError{field: "kind", message: "Invalid kind. Kind should be cage or dinosaur."}
+			}
+		}
+	}
+	return imp, unknownKinds
+}
+
+// newImportCage validates a cage of an import like a cage created through the API.
+func newImportCage(record apimodels.InventoryRecord) (dbmodels.Cage, error) {
+	if record.PowerStatus != apimodels.Active && record.PowerStatus != apimodels.Down {
+		return dbmodels.Cage{}, invalidInputError{field: "power_status", message: "Invalid power status."}
+	}
+	if record.Capacity <= 0 {
+		return dbmodels.Cage{}, invalidInputError{field: "capacity", message: "Capacity should be greater than 0."}
+	}
+	return dbmodels.Cage{Capacity: record.Capacity, PowerStatus: string(record.PowerStatus)}, nil
+}
+
+// newImportDinosaur validates a dinosaur of an import like a dinosaur added through the API.
+// A type given along with the species has to match it, so that a record edited inconsistently is not taken
+// for either of them. The cage_id is only used without a cage_ref.
+func newImportDinosaur(record apimodels.InventoryRecord) (dbmodels.Dinosaur, error) {
+	cageID := record.CageID
+	if record.CageRef != "" {
+		cageID = 0
+	}
+	dinosaur, err := newDinosaur(apimodels.AddDinosaurRequest{Name: record.Name, Species: record.Species, CageID: cageID})
+	if err != nil {
+		return dbmodels.Dinosaur{}, err
+	}
+	if record.Type != "" && string(record.Type) != dinosaur.Type {
+		return dbmodels.Dinosaur{}, invalidInputError{field: "type", message: "Invalid type. Type should match the diet of the species."}
+	}
+	if record.CageID == 0 && record.CageRef == "" {
+		return dbmodels.Dinosaur{}, invalidInputError{
+			field: "cage_id", message: "Invalid cage_id. Every dinosaur should live in a cage, named by cage_ref or cage_id.",
+		}
+	}
+	return dinosaur, nil
+}
+
+// importErrors reports the rejected records in the order of their lines.
+func importErrors(rows []importRow, imp service.Import) []apimodels.ImportError {
+	result := []apimodels.ImportError{}
+	for _, row := range rows {
+		err, kind := row.err, row.record.Kind
+		switch kind {
+		case apimodels.InventoryCage:
+			err = imp.Cages[row.index].Err
+		case apimodels.InventoryDinosaur:
+			err = imp.Dinosaurs[row.index].Err
+		default:
+			kind = ""
+		}
+		if err == nil {
+			continue
+		}
+
+		problem, _ := errorProblem(err)
+		importErr := apimodels.ImportError{Line: row.line, Kind: kind, Code: problem.Code, Field: problem.Field, Error: problem.Detail}
+		// Cages of the import are rolled back along with it, so they are identified by their reference.
+		switch {
+		case row.record.CageRef != "" && (problem.CageID != 0 || problem.Field == "cage_ref"):
+			importErr.CageRef = row.record.CageRef
+		case problem.CageID != 0:
+			importErr.CageID = row.record.CageID
+		}
+		result = append(result, importErr)
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
+)
+
+// batchLoader collects the keys requested while a level of a GraphQL query is resolved, and loads all of them
+// with a single read once the first of their values is needed. Loaded values are kept for the rest of the request.
+type batchLoader[K comparable, V any] struct {
+	fetch   func(ctx context.Context, keys []K) (map[K]V, error)
+	mu      sync.Mutex
+	pending []K
+	values  map[K]V
+	errs    map[K]error
+}
+
+func newBatchLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *batchLoader[K, V] {
+	return &batchLoader[K, V]{fetch: fetch, values: map[K]V{}, errs: map[K]error{}}
+}
+
+// load queues the key and returns a thunk resolving to its value, which GraphQL calls only after
+// the sibling fields have queued their keys. Keys without a value resolve to the zero value.
+func (l *batchLoader[K, V]) load(ctx context.Context, key K) func() (interface{}, error) {
+	l.mu.Lock()
+	if _, loaded := l.values[key]; !loaded {
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(l.pending) > 0 {
+			keys := l.pending
+			l.pending = nil
+			values, err := l.fetch(ctx, keys)
+			for _, k := range keys {
+				if err != nil {
+					l.errs[k] = err
+				} else {
+					l.values[k] = values[k]
+				}
+			}
+		}
+		if err, failed := l.errs[key]; failed {
+			return nil, err
+		}
+		return l.values[key], nil
+	}
+}
+
+// prime stores a value which has already been read, so it is not loaded again.
+func (l *batchLoader[K, V]) prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.values[key] = value
+}
+
+// graphQLLoaders batch the nested reads of a single GraphQL request.
+type graphQLLoaders struct {
+	cages              *batchLoader[uint, *dbmodels.Cage]
+	dinosaursByCage    *batchLoader[uint, []dbmodels.Dinosaur]
+	dinosaursBySpecies *batchLoader[string, []dbmodels.Dinosaur]
+}
+
+func newGraphQLLoaders(cages *service.CageService, dinosaurs *service.DinosaurService) *graphQLLoaders {
+	return &graphQLLoaders{
+		cages: newBatchLoader(func(ctx context.Context, ids []uint) (map[uint]*dbmodels.Cage, error) {
+			found, err := cages.ListCages(ctx, repository.CageFilter{IDs: ids}, repository.Page{Limit: len(ids)}, false, repository.CageRelations{})
+			if err != nil {
+				return nil, graphQLError(err, "Failed to retrieve cages.")
+			}
+			result := map[uint]*dbmodels.Cage{}
+			for i := range found {
+				result[found[i].ID] = &found[i]
+			}
+			return result, nil
+		}),
+		dinosaursByCage: newBatchLoader(func(ctx context.Context, ids []uint) (map[uint][]dbmodels.Dinosaur, error) {
+			found, err := dinosaurs.ListDinosaurs(ctx, repository.DinosaurFilter{CageIDs: ids}, repository.Page{}, false, repository.DinosaurRelations{})
+			if err != nil {
+				return nil, graphQLError(err, "Failed to retrieve dinosaurs.")
+			}
+			return groupDinosaurs(ids, found, func(dinosaur dbmodels.Dinosaur) uint { return dinosaur.CageID }), nil
+		}),
+		dinosaursBySpecies: newBatchLoader(func(ctx context.Context, species []string) (map[string][]dbmodels.Dinosaur, error) {
+			found, err := dinosaurs.ListDinosaurs(ctx, repository.DinosaurFilter{Species: species}, repository.Page{}, false, repository.DinosaurRelations{})
+			if err != nil {
+				return nil, graphQLError(err, "Failed to retrieve dinosaurs.")
+			}
+			return groupDinosaurs(species, found, func(dinosaur dbmodels.Dinosaur) string { return dinosaur.Species }), nil
+		}),
+	}
+}
+
+// groupDinosaurs groups the dinosaurs by key, with an empty group for every key without dinosaurs.
+func groupDinosaurs[K comparable](keys []K, dinosaurs []dbmodels.Dinosaur, key func(dbmodels.Dinosaur) K) map[K][]dbmodels.Dinosaur {
+	groups := map[K][]dbmodels.Dinosaur{}
+	for _, k := range keys {
+		groups[k] = []dbmodels.Dinosaur{}
+	}
+	for _, dinosaur := range dinosaurs {
+		groups[key(dinosaur)] = append(groups[key(dinosaur)], dinosaur)
+	}
+	return groups
+}
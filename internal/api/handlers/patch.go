@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"slices"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MergePatchContentType is the media type of JSON Merge Patch (RFC 7396) documents.
+const MergePatchContentType = "application/merge-patch+json"
+
+// bindMergePatch binds a JSON Merge Patch into obj, whose fields are pointers left nil for members missing from the patch.
+// Plain JSON bodies are accepted as well, as clients have always sent them to PATCH routes.
+// Members have to name one of the mutable fields, and as no field can be removed, null values are rejected.
+// Responds with 400 or 415 and returns false when the patch cannot be applied.
+func bindMergePatch(c *gin.Context, obj any, mutableFields ...string) bool {
+	switch c.ContentType() {
+	case "", gin.MIMEJSON, MergePatchContentType:
+	default:
+		problem := apimodels.UnsupportedMediaTypeProblem.New("Unsupported content type. Use " + MergePatchContentType + ".")
+		problem.Field = "Content-Type"
+		respondWithProblem(c, problem)
+		return false
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	var members map[string]json.RawMessage
+	if err != nil || json.Unmarshal(body, &members) != nil || members == nil {
+		respondWithValidationError(c, "", "Invalid input.")
+		return false
+	}
+	for name, value := range members {
+		if !slices.Contains(mutableFields, name) {
+			respondWithValidationError(c, name, "Field "+name+" cannot be changed.")
+			return false
+		}
+		if string(value) == "null" {
+			respondWithValidationError(c, name, "Field "+name+" cannot be removed.")
+			return false
+		}
+	}
+
+	if err := json.Unmarshal(body, obj); err != nil {
+		respondWithValidationError(c, "", "Invalid input.")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,347 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// includeDeleted reads the include_deleted query parameter, which makes reads return deleted records as well.
+// Responds with 400 and returns false when the value is not a boolean.
+func includeDeleted(c *gin.Context) (bool, bool) {
+	include, ok := queryBool(c, "include_deleted")
+	if include == nil {
+		return false, ok
+	}
+	return *include, true
+}
+
+// bindOptionalJSON binds the request body like ShouldBindJSON, but accepts requests without a body.
+// Responds with 400 and returns false when the body is not valid JSON.
+func bindOptionalJSON(c *gin.Context, obj any) bool {
+	if c.Request.Body == nil || c.Request.ContentLength == 0 {
+		return true
+	}
+	if err := c.ShouldBindJSON(obj); err != nil && !errors.Is(err, io.EOF) {
+		respondWithValidationError(c, "", "Invalid input.")
+		return false
+	}
+	return true
+}
+
+// cageFilterParams reads the cage filters from the query string.
+// Responds with 400 and returns false when any of them is malformed.
+func cageFilterParams(c *gin.Context) (repository.CageFilter, bool) {
+	filter := repository.CageFilter{}
+	for _, value := range queryList(c, "power_status") {
+		powerStatus := apimodels.PowerStatus(value)
+		if powerStatus != apimodels.Active && powerStatus != apimodels.Down {
+			invalidParameter(c, "power_status")
+			return filter, false
+		}
+		filter.PowerStatuses = append(filter.PowerStatuses, value)
+	}
+
+	var ok bool
+	if filter.HasFreeCapacity, ok = queryBool(c, "has_free_capacity"); !ok {
+		return filter, false
+	}
+	if filter.CapacityGTE, ok = queryCapacity(c, "capacity_gte"); !ok {
+		return filter, false
+	}
+	if filter.CapacityLTE, ok = queryCapacity(c, "capacity_lte"); !ok {
+		return filter, false
+	}
+	return filter, true
+}
+
+// dinosaurFilterParams reads the dinosaur filters from the query string.
+// Responds with 400 and returns false when any of them is malformed.
+func dinosaurFilterParams(c *gin.Context) (repository.DinosaurFilter, bool) {
+	filter := repository.DinosaurFilter{}
+	for _, value := range queryList(c, "species") {
+		if knownSpecies, _, _ := apimodels.LookupSpeciesType(value); !knownSpecies {
+			invalidParameter(c, "species")
+			return filter, false
+		}
+		filter.Species = append(filter.Species, value)
+	}
+
+	for _, value := range queryList(c, "type") {
+		dinosaurType := apimodels.DinosaurType(value)
+		if dinosaurType != apimodels.Herbivore && dinosaurType != apimodels.Carnivore {
+			invalidParameter(c, "type")
+			return filter, false
+		}
+		filter.Types = append(filter.Types, value)
+	}
+
+	for _, value := range queryList(c, "cage_id") {
+		cageID, err := strconv.ParseUint(value, 10, 0)
+		if err != nil || cageID == 0 {
+			invalidParameter(c, "cage_id")
+			return filter, false
+		}
+		filter.CageIDs = append(filter.CageIDs, uint(cageID))
+	}
+
+	// Names may contain commas, so several prefixes can only be given by repeating the parameter.
+	for _, value := range c.QueryArray("name") {
+		if value != "" {
+			filter.NamePrefixes = append(filter.NamePrefixes, value)
+		}
+	}
+	return filter, true
+}
+
+// queryList returns all values of a query parameter, given either repeatedly (?species=A&species=B)
+// or comma-separated (?species=A,B). Empty values are skipped.
+func queryList(c *gin.Context, name string) []string {
+	var values []string
+	for _, param := range c.QueryArray(name) {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// queryBool returns nil when the parameter is not set.
+// Responds with 400 and returns false when the value is not a boolean.
+func queryBool(c *gin.Context, name string) (*bool, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		invalidParameter(c, name)
+		return nil, false
+	}
+	return &parsed, true
+}
+
+// queryCapacity returns nil when the parameter is not set.
+// Responds with 400 and returns false when the value is not a non-negative number.
+func queryCapacity(c *gin.Context, name string) (*int, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		invalidParameter(c, name)
+		return nil, false
+	}
+	return &parsed, true
+}
+
+func invalidParameter(c *gin.Context, name string) {
+	respondWithValidationError(c, name, "Invalid "+name+" parameter.")
+}
+
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 100
+)
+
+// pageCursor is the position within a paged list, handed to clients as an opaque cursor.
+type pageCursor struct {
+	AfterID uint `json:"after_id"`
+}
+
+// pageParams reads the limit and cursor query parameters of a paged list.
+// Responds with 400 and returns false when either of them is malformed.
+func pageParams(c *gin.Context) (int, uint, bool) {
+	limit, ok := pageLimit(c)
+	if !ok {
+		return 0, 0, false
+	}
+
+	afterID, err := decodeCursor(c.Query("cursor"))
+	if err != nil {
+		respondWithError(c, err, "Invalid cursor.")
+		return 0, 0, false
+	}
+	return limit, afterID, true
+}
+
+// decodeCursor returns the position a cursor returned by encodeCursor points at, 0 for an empty cursor.
+func decodeCursor(value string) (uint, error) {
+	if value == "" {
+		return 0, nil
+	}
+	var cursor pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || json.Unmarshal(data, &cursor) != nil || cursor.AfterID == 0 {
+		return 0, errInvalidCursor
+	}
+	return cursor.AfterID, nil
+}
+
+var (
+	errInvalidCursor = invalidInputError{field: "cursor", message: "Invalid cursor."}
+	errInvalidLimit  = invalidInputError{field: "limit", message: "Invalid limit. Limit should be between 1 and 100."}
+)
+
+// pageLimit reads the limit query parameter of a paged list.
+// Responds with 400 and returns false when it is out of range.
+func pageLimit(c *gin.Context) (int, bool) {
+	value := c.Query("limit")
+	if value == "" {
+		return defaultPageLimit, true
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 || limit > maxPageLimit {
+		respondWithError(c, errInvalidLimit, "Invalid limit.")
+		return 0, false
+	}
+	return limit, true
+}
+
+// listCursor is the position within a sorted list: the sort values of the last record of the previous page,
+// along with the sort order they belong to.
+type listCursor struct {
+	Sort  string `json:"sort"`
+	After []any  `json:"after"`
+}
+
+// listPageParams reads the limit, sort and cursor query parameters of a list sortable by the columns.
+// Responds with 400 and returns false when any of them is malformed.
+func listPageParams[T any](c *gin.Context, columns map[string]repository.SortColumn[T]) (repository.Page, bool) {
+	limit, ok := pageLimit(c)
+	if !ok {
+		return repository.Page{}, false
+	}
+
+	page, err := sortedPage(limit, queryList(c, "sort"), c.Query("cursor"), columns)
+	if err != nil {
+		respondWithError(c, err, "Invalid page.")
+		return repository.Page{}, false
+	}
+	return page, true
+}
+
+// sortedPage returns the page of limit records of a list sortable by the columns, ordered by the sort keys
+// and starting after the cursor. Sorting always ends with the ID, which keeps the order stable for records
+// with equal values. Fails with an invalidInputError when the sort keys or the cursor are malformed.
+func sortedPage[T any](limit int, sort []string, cursorValue string, columns map[string]repository.SortColumn[T]) (repository.Page, error) {
+	page := repository.Page{Limit: limit}
+	for _, value := range sort {
+		key := repository.SortKey{Column: strings.TrimPrefix(value, "-"), Desc: strings.HasPrefix(value, "-")}
+		_, known := columns[key.Column]
+		if !known || slices.ContainsFunc(page.Sort, func(other repository.SortKey) bool { return other.Column == key.Column }) {
+			return repository.Page{}, invalidInputError{field: "sort", message: "Invalid sort parameter."}
+		}
+		page.Sort = append(page.Sort, key)
+	}
+	if !slices.ContainsFunc(page.Sort, func(key repository.SortKey) bool { return key.Column == "id" }) {
+		page.Sort = append(page.Sort, repository.SortKey{Column: "id"})
+	}
+
+	if cursorValue == "" {
+		return page, nil
+	}
+
+	// A cursor is only valid for the sort order it was issued for.
+	var cursor listCursor
+	data, err := base64.RawURLEncoding.DecodeString(cursorValue)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err != nil || decoder.Decode(&cursor) != nil || cursor.Sort != formatSort(page.Sort) || len(cursor.After) != len(page.Sort) {
+		return repository.Page{}, errInvalidCursor
+	}
+	for i, key := range page.Sort {
+		var ok bool
+		if columns[key.Column].Numeric {
+			var number json.Number
+			if number, ok = cursor.After[i].(json.Number); ok {
+				cursor.After[i], err = number.Int64()
+				ok = err == nil
+			}
+		} else {
+			_, ok = cursor.After[i].(string)
+		}
+		if !ok {
+			return repository.Page{}, errInvalidCursor
+		}
+	}
+	page.After = cursor.After
+	return page, nil
+}
+
+// lookahead returns the page with room for one more record, which tells whether another page follows.
+func lookahead(page repository.Page) repository.Page {
+	page.Limit++
+	return page
+}
+
+// listPage is a page of a list, along with the number of all matching records if it was requested.
+type listPage[T any] struct {
+	records    []T
+	nextCursor string
+	total      *int
+}
+
+// nextPage trims records fetched with lookahead to the page, and returns the cursor of the following page,
+// or an empty string if there is none.
+func nextPage[T any](records []T, page repository.Page, columns map[string]repository.SortColumn[T]) ([]T, string) {
+	if len(records) <= page.Limit {
+		return records, ""
+	}
+
+	records = records[:page.Limit]
+	return records, recordCursor(records[len(records)-1], page.Sort, columns)
+}
+
+// recordCursor returns the cursor of the page following the record in the sort order.
+func recordCursor[T any](record T, sort []repository.SortKey, columns map[string]repository.SortColumn[T]) string {
+	data, _ := json.Marshal(listCursor{Sort: formatSort(sort), After: repository.SortValues(columns, record, sort)})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// formatSort returns the sort order in the format of the sort query parameter, e.g. "-capacity,id".
+func formatSort(sort []repository.SortKey) string {
+	keys := make([]string, len(sort))
+	for i, key := range sort {
+		keys[i] = key.Column
+		if key.Desc {
+			keys[i] = "-" + key.Column
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+// includeTotal reads the include_total query parameter, which adds the number of all matching records to lists.
+// Responds with 400 and returns false when the value is not a boolean.
+func includeTotal(c *gin.Context) (bool, bool) {
+	include, ok := queryBool(c, "include_total")
+	if include == nil {
+		return false, ok
+	}
+	return *include, true
+}
+
+// encodeCursor returns the cursor of the page following afterID, or an empty string if there is none.
+func encodeCursor(afterID uint) string {
+	if afterID == 0 {
+		return ""
+	}
+	data, _ := json.Marshal(pageCursor{AfterID: afterID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"net/http"
+	"reflect"
+	"runtime"
+	"strings"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/api/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Route is an endpoint of the API, served by the handler and described by the OpenAPI document.
+type Route struct {
+	openapi.Endpoint
+	Handler gin.HandlerFunc
+}
+
+// RegisterRoutes serves the routes on the router.
+func RegisterRoutes(router gin.IRoutes, routes []Route) {
+	for _, route := range routes {
+		router.Handle(route.Method, route.Path, route.Handler)
+	}
+}
+
+// Parameters shared by several routes.
+var (
+	actorHeader   = openapi.Header(ActorHeader, "", "Who makes the change, recorded in the history. Defaults to anonymous.")
+	ifMatchHeader = openapi.Header("If-Match", "", "Version from the ETag header. The request fails if the resource has changed since.")
+	dryRunParam   = openapi.Query("dry_run", false, "Check the placement rules without changing anything.")
+
+	ifNoneMatchHeader = openapi.Header("If-None-Match", "",
+		"ETag of the response held by the client. Responds with 304 if it is still current.")
+	ifModifiedSinceHeader = openapi.Header("If-Modified-Since", "",
+		"Last-Modified of the response held by the client. Responds with 304 if nothing has changed since.")
+
+	idempotencyKeyHeader = openapi.Header(IdempotencyKeyHeader, "",
+		"Key of the request, at most 255 characters, shared by all versions of the API. Retries with the same key return the original response.")
+
+	includeDeletedParam = openapi.Query("include_deleted", false, "Include deleted records.")
+	includeTotalParam   = openapi.Query("include_total", false, "Return the number of all matching records in total.")
+	limitParam          = openapi.Query("limit", 0, "Number of records per page, 50 by default and at most 100.")
+	cursorParam         = openapi.Query("cursor", "", "The next_cursor of the previous page.")
+	sortParam           = openapi.Query("sort", "", "Comma-separated columns, each prefixed with - for descending order.")
+
+	fieldsParam           = openapi.Query("fields", []string{}, "Fields of each record to return, e.g. id,capacity,power_status. All fields by default.")
+	includeDinosaursParam = openapi.Query("include", "", "Set to dinosaurs to embed the dinosaurs of each cage.")
+	includeCageParam      = openapi.Query("include", "", "Set to cage to embed the enclosing cage of each dinosaur.")
+)
+
+// routesV1 returns the routes of the v1 API, relative to its prefix.
+func routesV1(cages *CageHandler, dinosaurs *DinosaurHandler) []Route {
+	return []Route{
+		// Cages API
+		route(http.MethodGet, "/cages", cages.GetCages, openapi.Endpoint{
+			Tag:     "cages",
+			Summary: "Query all cage details, including enclosed dinosaurs.",
+			Parameters: []openapi.Param{
+				openapi.Query("power_status", []apimodels.PowerStatus{}, "Cages with any of the power statuses."),
+				openapi.Query("has_free_capacity", false, "Cages with room for another dinosaur, or full cages."),
+				openapi.Query("capacity_gte", 0, "Cages with at least the capacity."),
+				openapi.Query("capacity_lte", 0, "Cages with at most the capacity."),
+				includeDeletedParam, includeTotalParam, limitParam, sortParam, cursorParam, fieldsParam, includeDinosaursParam,
+				ifNoneMatchHeader, ifModifiedSinceHeader,
+			},
+			Responses: okOrNotModified(apimodels.GetCagesResponse{}),
+			Problems:  []int{http.StatusBadRequest, http.StatusInternalServerError},
+		}),
+		route(http.MethodGet, "/cages/:id", cages.GetCage, openapi.Endpoint{
+			Tag:        "cages",
+			Summary:    "Query single cage details, including enclosed dinosaurs.",
+			Parameters: []openapi.Param{includeDeletedParam, fieldsParam, includeDinosaursParam, ifNoneMatchHeader, ifModifiedSinceHeader},
+			Responses:  okOrNotModified(apimodels.GetCageResponse{}),
+			Problems:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		}),
+		route(http.MethodPost, "/cages", cages.CreateCage, openapi.Endpoint{
+			Tag:        "cages",
+			Summary:    "Create a new cage.",
+			Parameters: []openapi.Param{idempotencyKeyHeader, actorHeader},
+			Request:    apimodels.CreateCageRequest{},
+			Responses:  ok(apimodels.CreateCageResponse{}),
+			Problems: []int{http.StatusBadRequest, http.StatusConflict, http.StatusUnprocessableEntity,
+				http.StatusInternalServerError},
+		}),
+		route(http.MethodPatch, "/cages/:id", cages.UpdateCage, openapi.Endpoint{
+			Tag:          "cages",
+			Summary:      "Update power status or capacity of the existing cage.",
+			Parameters:   []openapi.Param{ifMatchHeader, actorHeader},
+			Request:      apimodels.UpdateCageRequest{},
+			RequestTypes: []string{MergePatchContentType, gin.MIMEJSON},
+			Responses:    ok(apimodels.UpdateCageResponse{}),
+			Problems: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed,
+				http.StatusUnsupportedMediaType, http.StatusInternalServerError},
+		}),
+		route(http.MethodDelete, "/cages/:id", cages.DeleteCage, openapi.Endpoint{
+			Tag:        "cages",
+			Summary:    "Delete the cage.",
+			Parameters: []openapi.Param{ifMatchHeader, actorHeader},
+			Responses:  ok(apimodels.DeleteCageResponse{}),
+			Problems: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed,
+				http.StatusInternalServerError},
+		}),
+		route(http.MethodPost, "/cages/:id/restore", cages.RestoreCage, openapi.Endpoint{
+			Tag:        "cages",
+			Summary:    "Restore the deleted cage.",
+			Parameters: []openapi.Param{ifMatchHeader, actorHeader},
+			Responses:  ok(apimodels.RestoreCageResponse{}),
+			Problems:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusPreconditionFailed, http.StatusInternalServerError},
+		}),
+		route(http.MethodGet, "/cages/:id/history", cages.GetCageHistory, openapi.Endpoint{
+			Tag:        "cages",
+			Summary:    "Query the change history of the cage, oldest first.",
+			Parameters: []openapi.Param{limitParam, cursorParam},
+			Responses:  ok(apimodels.GetHistoryResponse{}),
+			Problems:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		}),
+		route(http.MethodGet, "/cages/:id/compatibility", dinosaurs.GetCompatibility, openapi.Endpoint{
+			Tag:     "cages",
+			Summary: "Check whether a dinosaur of the species, or the existing dinosaur, can be placed in the cage.",
+			Parameters: []openapi.Param{
+				openapi.Query("species", apimodels.Species(""), "Species of a new dinosaur. Exclusive with dinosaur_id."),
+				openapi.Query("dinosaur_id", 0, "ID of an existing dinosaur. Exclusive with species."),
+			},
+			Responses: ok(apimodels.GetCompatibilityResponse{}),
+			Problems:  []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		}),
+
+		// Dinosaur API
+		route(http.MethodGet, "/dinosaurs", dinosaurs.GetDinosaurs, openapi.Endpoint{
+			Tag:     "dinosaurs",
+			Summary: "Query all dinosaur details.",
+			Parameters: []openapi.Param{
+				openapi.Query("species", []apimodels.Species{}, "Dinosaurs of any of the species."),
+				openapi.Query("type", []apimodels.DinosaurType{}, "Dinosaurs of any of the types."),
+				openapi.Query("cage_id", []int{}, "Dinosaurs living in any of the cages."),
+				openapi.Query("name", []string{}, "Dinosaurs whose name starts with any of the prefixes. Repeat the parameter for several prefixes."),
+				includeDeletedParam, includeTotalParam, limitParam, sortParam, cursorParam, fieldsParam, includeCageParam,
+				ifNoneMatchHeader, ifModifiedSinceHeader,
+			},
+			Responses: okOrNotModified(apimodels.GetDinosaursResponse{}),
+			Problems:  []int{http.StatusBadRequest, http.StatusInternalServerError},
+		}),
+		route(http.MethodGet, "/dinosaurs/:id", dinosaurs.GetDinosaur, openapi.Endpoint{
+			Tag:        "dinosaurs",
+			Summary:    "Query single dinosaur details.",
+			Parameters: []openapi.Param{includeDeletedParam, fieldsParam, includeCageParam, ifNoneMatchHeader, ifModifiedSinceHeader},
+			Responses:  okOrNotModified(apimodels.GetDinosaurResponse{}),
+			Problems:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		}),
+		route(http.MethodPost, "/dinosaurs", dinosaurs.AddDinosaur, openapi.Endpoint{
+			Tag:        "dinosaurs",
+			Summary:    "Add new dinosaur to existing cage in the Park.",
+			Parameters: []openapi.Param{dryRunParam, idempotencyKeyHeader, actorHeader},
+			Request:    apimodels.AddDinosaurRequest{},
+			Responses:  ok(openapi.OneOf{apimodels.AddDinosaurResponse{}, apimodels.DryRunDinosaurResponse{}}),
+			Problems: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity,
+				http.StatusInternalServerError},
+		}),
+		route(http.MethodPost, "/dinosaurs/batch", dinosaurs.AddDinosaurs, openapi.Endpoint{
+			Tag:        "dinosaurs",
+			Summary:    "Add up to 100 dinosaurs at once.",
+			Parameters: []openapi.Param{actorHeader},
+			Request:    apimodels.AddDinosaursRequest{},
+			Responses: map[int]any{
+				http.StatusOK:                  apimodels.AddDinosaursResponse{},
+				http.StatusUnprocessableEntity: apimodels.AddDinosaursResponse{},
+			},
+			Problems: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		}),
+		route(http.MethodPatch, "/dinosaurs/:id", dinosaurs.UpdateDinosaur, openapi.Endpoint{
+			Tag:          "dinosaurs",
+			Summary:      "Move dinosaur from one cage to another, or correct its name and species.",
+			Parameters:   []openapi.Param{dryRunParam, ifMatchHeader, actorHeader},
+			Request:      apimodels.UpdateDinosaurRequest{},
+			RequestTypes: []string{MergePatchContentType, gin.MIMEJSON},
+			Responses:    ok(openapi.OneOf{apimodels.UpdateDinosaurResponse{}, apimodels.DryRunDinosaurResponse{}}),
+			Problems: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed,
+				http.StatusUnsupportedMediaType, http.StatusInternalServerError},
+		}),
+		route(http.MethodDelete, "/dinosaurs/:id", dinosaurs.RemoveDinosaur, openapi.Endpoint{
+			Tag:        "dinosaurs",
+			Summary:    "Remove dinosaur from the Park.",
+			Parameters: []openapi.Param{ifMatchHeader, actorHeader},
+			Responses:  ok(apimodels.RemoveDinosaurResponse{}),
+			Problems:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusPreconditionFailed, http.StatusInternalServerError},
+		}),
+		route(http.MethodPost, "/dinosaurs/:id/restore", dinosaurs.RestoreDinosaur, openapi.Endpoint{
+			Tag:        "dinosaurs",
+			Summary:    "Bring the removed dinosaur back into its cage.",
+			Parameters: []openapi.Param{ifMatchHeader, actorHeader},
+			Responses:  ok(apimodels.RestoreDinosaurResponse{}),
+			Problems: []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusPreconditionFailed,
+				http.StatusInternalServerError},
+		}),
+		route(http.MethodGet, "/dinosaurs/:id/history", dinosaurs.GetDinosaurHistory, openapi.Endpoint{
+			Tag:        "dinosaurs",
+			Summary:    "Query the change history of the dinosaur, oldest first.",
+			Parameters: []openapi.Param{limitParam, cursorParam},
+			Responses:  ok(apimodels.GetHistoryResponse{}),
+			Problems:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError},
+		}),
+		route(http.MethodPost, "/moves", dinosaurs.MoveDinosaurs, openapi.Endpoint{
+			Tag:        "dinosaurs",
+			Summary:    "Move several dinosaurs at once, e.g. to swap them between cages.",
+			Parameters: []openapi.Param{actorHeader},
+			Request:    apimodels.MoveDinosaursRequest{},
+			Responses:  ok(apimodels.MoveDinosaursResponse{}),
+			Problems:   []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict, http.StatusInternalServerError},
+		}),
+	}
+}
+
+// DebugRoutes returns the routes of the debug API, enabled by the debug_endpoints feature toggle.
+func DebugRoutes(debug *DebugHandler) []Route {
+	return []Route{
+		route(http.MethodGet, "/debug/db", debug.GetDBStats, openapi.Endpoint{
+			Tag:       "debug",
+			Summary:   "Query database connection pool statistics.",
+			Responses: ok(apimodels.GetDBStatsResponse{}),
+		}),
+	}
+}
+
+// InventoryRoutes returns the routes exporting and importing the park inventory, served under /v1.
+// The inventory has the same format in every version of the API, and was never served at the root.
+func InventoryRoutes(inventory *InventoryHandler) []Route {
+	formatParam := openapi.Query("format", apimodels.InventoryFormat(""), "Format of the inventory, csv by default.")
+	return mount("/v1", "V1", []Route{
+		route(http.MethodGet, "/export", inventory.ExportInventory, openapi.Endpoint{
+			Tag:           "inventory",
+			Summary:       "Export all cages, followed by all dinosaurs, one record per row or line.",
+			Parameters:    []openapi.Param{formatParam},
+			Responses:     ok(""),
+			ResponseTypes: []string{inventoryContentTypes[apimodels.CSV], inventoryContentTypes[apimodels.NDJSON]},
+			Problems:      []int{http.StatusBadRequest, http.StatusInternalServerError},
+		}),
+		route(http.MethodPost, "/import", inventory.ImportInventory, openapi.Endpoint{
+			Tag:          "inventory",
+			Summary:      "Import cages and dinosaurs in the format of the export, all of them or none.",
+			Parameters:   []openapi.Param{formatParam, openapi.Query("dry_run", false, "Validate the records without importing them."), actorHeader},
+			Request:      "",
+			RequestTypes: []string{inventoryContentTypes[apimodels.CSV], inventoryContentTypes[apimodels.NDJSON]},
+			Responses: map[int]any{
+				http.StatusOK:                  apimodels.ImportInventoryResponse{},
+				http.StatusUnprocessableEntity: apimodels.ImportInventoryResponse{},
+			},
+			Problems: []int{http.StatusBadRequest, http.StatusInternalServerError},
+		}),
+	})
+}
+
+// route returns the route served by the handler. The operation is named after the handler.
+func route(method string, path string, handler gin.HandlerFunc, endpoint openapi.Endpoint) Route {
+	endpoint.Method = method
+	endpoint.Path = path
+	endpoint.OperationID = handlerName(handler)
+	return Route{Endpoint: endpoint, Handler: handler}
+}
+
+// handlerName returns the name of the handler method, e.g. GetCages.
+func handlerName(handler gin.HandlerFunc) string {
+	name := runtime.FuncForPC(reflect.ValueOf(handler).Pointer()).Name()
+	return strings.TrimSuffix(name[strings.LastIndex(name, ".")+1:], "-fm")
+}
+
+func ok(body any) map[int]any {
+	return map[int]any{http.StatusOK: body}
+}
+
+// okOrNotModified describes reads answering conditional requests with 304 when the client's copy is current.
+func okOrNotModified(body any) map[int]any {
+	return map[int]any{http.StatusOK: body, http.StatusNotModified: nil}
+}
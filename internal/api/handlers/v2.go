@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"net/http"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	transform "pp-jurassic-park-api/internal/api/transform"
+	"pp-jurassic-park-api/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// cageHandlerV2 serves the v2 cages API. It shares the request handling of v1, but returns cage summaries
+// linking to the dinosaurs instead of embedding them.
+type cageHandlerV2 struct {
+	*CageHandler
+}
+
+// GetCage embeds the dinosaurs only if they are included explicitly.
+func (h cageHandlerV2) GetCage(c *gin.Context) {
+	view, ok := viewParams(c, apimodels.CageSummary{}, "dinosaurs")
+	if !ok {
+		return
+	}
+	if cage, ok := h.getCage(c, view, repository.CageRelations{Dinosaurs: view.includes("dinosaurs")}); ok {
+		respondWithView(c, "cage", apimodels.CageSummaryResponse{Cage: transform.CageSummaryToApi(cage)}, view)
+	}
+}
+
+func (h cageHandlerV2) GetCages(c *gin.Context) {
+	view, ok := viewParams(c, apimodels.CageSummary{}, "dinosaurs")
+	if !ok {
+		return
+	}
+	if page, ok := h.listCages(c, repository.CageRelations{Dinosaurs: view.includes("dinosaurs")}); ok {
+		respondWithPage(c, "cages", apimodels.CageSummariesResponse{
+			Cages:      transform.CageSummariesToApi(page.records),
+			NextCursor: page.nextCursor,
+			Total:      page.total,
+		}, view)
+	}
+}
+
+func (h cageHandlerV2) CreateCage(c *gin.Context) {
+	idempotent(c, h.keys, func(c *gin.Context) {
+		if cage, ok := h.createCage(c); ok {
+			c.JSON(http.StatusOK, apimodels.CageSummaryResponse{Cage: transform.CageSummaryToApi(cage)})
+		}
+	})
+}
+
+func (h cageHandlerV2) UpdateCage(c *gin.Context) {
+	if cage, ok := h.updateCage(c); ok {
+		c.JSON(http.StatusOK, apimodels.CageSummaryResponse{Cage: transform.CageSummaryToApi(cage)})
+	}
+}
+
+func (h cageHandlerV2) RestoreCage(c *gin.Context) {
+	if cage, ok := h.restoreCage(c); ok {
+		c.JSON(http.StatusOK, apimodels.CageSummaryResponse{Cage: transform.CageSummaryToApi(cage)})
+	}
+}
+
+// dinosaurHandlerV2 serves the v2 dinosaurs API. It shares the request handling of v1,
+// but returns dinosaurs linking to their cages.
+type dinosaurHandlerV2 struct {
+	*DinosaurHandler
+}
+
+// GetDinosaur embeds the summary of the cage, without its dinosaurs, if it is included.
+func (h dinosaurHandlerV2) GetDinosaur(c *gin.Context) {
+	view, ok := viewParams(c, apimodels.DinosaurSummary{}, "cage")
+	if !ok {
+		return
+	}
+	if dinosaur, ok := h.getDinosaur(c, view, enclosingCage(view, repository.CageRelations{})); ok {
+		respondWithView(c, "dinosaur", apimodels.DinosaurSummaryResponse{Dinosaur: transform.DinosaurSummaryToApi(dinosaur)}, view)
+	}
+}
+
+func (h dinosaurHandlerV2) GetDinosaurs(c *gin.Context) {
+	view, ok := viewParams(c, apimodels.DinosaurSummary{}, "cage")
+	if !ok {
+		return
+	}
+	if page, ok := h.listDinosaurs(c, enclosingCage(view, repository.CageRelations{})); ok {
+		respondWithPage(c, "dinosaurs", apimodels.DinosaurSummariesResponse{
+			Dinosaurs:  transform.DinosaurSummariesToApi(page.records),
+			NextCursor: page.nextCursor,
+			Total:      page.total,
+		}, view)
+	}
+}
+
+func (h dinosaurHandlerV2) AddDinosaur(c *gin.Context) {
+	idempotent(c, h.keys, func(c *gin.Context) {
+		if result, ok := h.addDinosaur(c); ok {
+			respondWithPlacementV2(c, result)
+		}
+	})
+}
+
+func (h dinosaurHandlerV2) AddDinosaurs(c *gin.Context) {
+	items, rejected, ok := h.addDinosaurs(c)
+	if !ok {
+		return
+	}
+
+	results := make([]apimodels.AddDinosaurSummaryResult, len(items))
+	for i, result := range batchResults(items, rejected) {
+		results[i] = apimodels.AddDinosaurSummaryResult{Index: result.Index, Status: result.Status, Code: result.Code, Error: result.Error}
+		if result.Status == apimodels.BatchItemCreated {
+			dinosaur := transform.DinosaurSummaryToApi(items[i].Dinosaur)
+			results[i].Dinosaur = &dinosaur
+		}
+	}
+	c.JSON(batchStatus(rejected), apimodels.AddDinosaurSummariesResponse{Results: results})
+}
+
+func (h dinosaurHandlerV2) UpdateDinosaur(c *gin.Context) {
+	if result, ok := h.updateDinosaur(c); ok {
+		respondWithPlacementV2(c, result)
+	}
+}
+
+func (h dinosaurHandlerV2) MoveDinosaurs(c *gin.Context) {
+	if dinosaurs, ok := h.moveDinosaurs(c); ok {
+		c.JSON(http.StatusOK, apimodels.DinosaurSummariesResponse{Dinosaurs: transform.DinosaurSummariesToApi(dinosaurs)})
+	}
+}
+
+func (h dinosaurHandlerV2) RestoreDinosaur(c *gin.Context) {
+	if dinosaur, ok := h.restoreDinosaur(c); ok {
+		c.JSON(http.StatusOK, apimodels.DinosaurSummaryResponse{Dinosaur: transform.DinosaurSummaryToApi(dinosaur)})
+	}
+}
+
+// respondWithPlacementV2 returns the added or updated dinosaur, or the outcome of the dry run.
+func respondWithPlacementV2(c *gin.Context, result placement) {
+	if !result.dryRun {
+		c.JSON(http.StatusOK, apimodels.DinosaurSummaryResponse{Dinosaur: transform.DinosaurSummaryToApi(result.dinosaur)})
+		return
+	}
+	c.JSON(http.StatusOK, apimodels.DryRunDinosaurSummaryResponse{
+		Dinosaur:   transform.DinosaurSummaryToApi(result.dinosaur),
+		Compatible: len(result.violations) == 0,
+		Violations: violationsToApi(result.violations),
+	})
+}
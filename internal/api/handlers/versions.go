@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/api/openapi"
+
+	"github.com/gin-gonic/gin"
+)
+
+// The unversioned routes are deprecated aliases of the v1 routes, and are going to be removed at the sunset.
+var (
+	aliasesDeprecatedAt = time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	aliasesSunset       = time.Date(2027, time.April, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Routes returns the routes of the cages and dinosaurs API: every version under its prefix,
+// along with the v1 routes at the root, where they were served before the API was versioned.
+func Routes(cages *CageHandler, dinosaurs *DinosaurHandler) []Route {
+	v1 := routesV1(cages, dinosaurs)
+	routes := mount("/v1", "V1", v1)
+	routes = append(routes, mount("/v2", "V2", routesV2(cages, dinosaurs))...)
+	return append(routes, deprecatedAliases(v1)...)
+}
+
+// routesV2 returns the routes of the v2 API, relative to its prefix. They take the same requests as the v1 routes,
+// but return cage summaries and dinosaurs linking to their cages.
+func routesV2(cages *CageHandler, dinosaurs *DinosaurHandler) []Route {
+	cagesV2, dinosaursV2 := cageHandlerV2{cages}, dinosaurHandlerV2{dinosaurs}
+	dinosaurOrDryRun := ok(openapi.OneOf{apimodels.DinosaurSummaryResponse{}, apimodels.DryRunDinosaurSummaryResponse{}})
+	changed := map[string]Route{
+		"GET /cages": {Handler: cagesV2.GetCages, Endpoint: openapi.Endpoint{
+			Summary:   "Query all cage summaries, linking to the enclosed dinosaurs.",
+			Responses: okOrNotModified(apimodels.CageSummariesResponse{}),
+		}},
+		"GET /cages/:id": {Handler: cagesV2.GetCage, Endpoint: openapi.Endpoint{
+			Summary:   "Query single cage summary, linking to the enclosed dinosaurs.",
+			Responses: okOrNotModified(apimodels.CageSummaryResponse{}),
+		}},
+		"POST /cages":             v2(cagesV2.CreateCage, ok(apimodels.CageSummaryResponse{})),
+		"PATCH /cages/:id":        v2(cagesV2.UpdateCage, ok(apimodels.CageSummaryResponse{})),
+		"POST /cages/:id/restore": v2(cagesV2.RestoreCage, ok(apimodels.CageSummaryResponse{})),
+		"GET /dinosaurs":          v2(dinosaursV2.GetDinosaurs, okOrNotModified(apimodels.DinosaurSummariesResponse{})),
+		"GET /dinosaurs/:id":      v2(dinosaursV2.GetDinosaur, okOrNotModified(apimodels.DinosaurSummaryResponse{})),
+		"POST /dinosaurs":         v2(dinosaursV2.AddDinosaur, dinosaurOrDryRun),
+		"POST /dinosaurs/batch": v2(dinosaursV2.AddDinosaurs, map[int]any{
+			http.StatusOK:                  apimodels.AddDinosaurSummariesResponse{},
+			http.StatusUnprocessableEntity: apimodels.AddDinosaurSummariesResponse{},
+		}),
+		"PATCH /dinosaurs/:id":        v2(dinosaursV2.UpdateDinosaur, dinosaurOrDryRun),
+		"POST /dinosaurs/:id/restore": v2(dinosaursV2.RestoreDinosaur, ok(apimodels.DinosaurSummaryResponse{})),
+		"POST /moves":                 v2(dinosaursV2.MoveDinosaurs, ok(apimodels.DinosaurSummariesResponse{})),
+	}
+
+	routes := routesV1(cages, dinosaurs)
+	for i, r := range routes {
+		if v2Route, found := changed[r.Method+" "+r.Path]; found {
+			r.Responses = v2Route.Responses
+			if v2Route.Summary != "" {
+				r.Summary = v2Route.Summary
+			}
+			routes[i] = route(r.Method, r.Path, v2Route.Handler, r.Endpoint)
+		}
+	}
+	return routes
+}
+
+// v2 describes a route whose v2 handler returns different responses than v1.
+func v2(handler gin.HandlerFunc, responses map[int]any) Route {
+	return Route{Endpoint: openapi.Endpoint{Responses: responses}, Handler: handler}
+}
+
+// mount returns the routes under the prefix, their operations named with the version.
+func mount(prefix string, version string, routes []Route) []Route {
+	mounted := make([]Route, len(routes))
+	for i, route := range routes {
+		route.Path = prefix + route.Path
+		route.OperationID += version
+		mounted[i] = route
+	}
+	return mounted
+}
+
+// unversioned splits the route of a request into the version of the API serving it and the route within the API.
+// The deprecated aliases at the root are served by v1.
+func unversioned(route string) (string, string) {
+	for _, version := range []string{"v1", "v2"} {
+		if path, found := strings.CutPrefix(route, "/"+version+"/"); found {
+			return version, "/" + path
+		}
+	}
+	return "v1", route
+}
+
+// deprecatedAliases returns the v1 routes served at the root. Their responses announce the deprecation
+// (Deprecation, RFC 9745) and removal (Sunset, RFC 8594) of the alias, and link to the v1 route succeeding it.
+func deprecatedAliases(routes []Route) []Route {
+	deprecation := "@" + strconv.FormatInt(aliasesDeprecatedAt.Unix(), 10)
+	sunset := aliasesSunset.Format(http.TimeFormat)
+
+	aliases := make([]Route, len(routes))
+	for i, route := range routes {
+		handler := route.Handler
+		route.Deprecated = true
+		route.Handler = func(c *gin.Context) {
+			c.Header("Deprecation", deprecation)
+			c.Header("Sunset", sunset)
+			c.Header("Link", `</v1`+c.Request.URL.RequestURI()+`>; rel="successor-version"`)
+			handler(c)
+		}
+		aliases[i] = route
+	}
+	return aliases
+}
@@ -1,5 +1,7 @@
 package apimodels
 
+import "time"
+
 type PowerStatus string
 
 const (
@@ -13,6 +15,7 @@ type Cage struct {
 	CurrentCount int         `json:"current_count"`
 	PowerStatus  PowerStatus `json:"power_status"`
 	Dinosaurs    []Dinosaur  `json:"dinosaurs"`
+	DeletedAt    *time.Time  `json:"deleted_at,omitempty"`
 }
 
 type CreateCageRequest struct {
@@ -23,10 +26,12 @@ type CreateCageResponse struct {
 	Cage Cage `json:"cage"`
 }
 
-type UpdateCagePowerStatusRequest struct {
-	PowerStatus PowerStatus `json:"power_status"`
+// UpdateCageRequest is a JSON Merge Patch of a cage. Fields left out keep their current value.
+type UpdateCageRequest struct {
+	Capacity    *int         `json:"capacity,omitempty"`
+	PowerStatus *PowerStatus `json:"power_status,omitempty"`
 }
-type UpdateCagePowerStatusResponse struct {
+type UpdateCageResponse struct {
 	Cage Cage `json:"cage"`
 }
 
@@ -35,11 +40,20 @@ type DeleteCageRequest struct {
 type DeleteCageResponse struct {
 }
 
+type RestoreCageRequest struct {
+}
+type RestoreCageResponse struct {
+	Cage Cage `json:"cage"`
+}
+
 type GetCagesRequest struct {
 	FilteredPowerStatuses []PowerStatus `json:"filtered_power_status,omitempty"`
 }
 type GetCagesResponse struct {
-	Cages []Cage `json:"cages"`
+	Cages      []Cage `json:"cages"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	// Total is the number of all matching cages, only set if requested with include_total.
+	Total *int `json:"total,omitempty"`
 }
 
 type GetCageRequest struct {
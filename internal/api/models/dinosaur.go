@@ -1,5 +1,7 @@
 package apimodels
 
+import "time"
+
 type DinosaurType string
 
 const (
@@ -20,6 +22,9 @@ const (
 	Triceratops   Species = "Triceratops"
 )
 
+// AllSpecies lists every species living in the Jurassic Park.
+var AllSpecies = []Species{Tyrannosaurus, Velociraptor, Spinosaurus, Megalosaurus, Brachiosaurus, Stegosaurus, Ankylosaurus, Triceratops}
+
 var speciesToTypeLookup = map[Species]DinosaurType{
 	Tyrannosaurus: Carnivore,
 	Velociraptor:  Carnivore,
@@ -37,11 +42,14 @@ func LookupSpeciesType(species string) (bool, Species, DinosaurType) {
 }
 
 type Dinosaur struct {
-	ID      uint         `json:"id"`
-	Name    string       `json:"name"`
-	Species Species      `json:"species"`
-	Type    DinosaurType `json:"type"`
-	CageID  uint         `json:"cage_id"`
+	ID        uint         `json:"id"`
+	Name      string       `json:"name"`
+	Species   Species      `json:"species"`
+	Type      DinosaurType `json:"type"`
+	CageID    uint         `json:"cage_id"`
+	DeletedAt *time.Time   `json:"deleted_at,omitempty"`
+	// Cage is only embedded if requested with include=cage.
+	Cage *Cage `json:"cage,omitempty"`
 }
 
 type AddDinosaurRequest struct {
@@ -53,18 +61,79 @@ type AddDinosaurResponse struct {
 	Dinosaur Dinosaur `json:"dinosaur"`
 }
 
-type MoveDinosaurRequest struct {
-	CageID uint `json:"cage_id"`
+type BatchMode string
+
+const (
+	// AllOrNothing adds the batch only if every dinosaur in it can be added.
+	AllOrNothing BatchMode = "all_or_nothing"
+	// BestEffort adds every dinosaur of the batch that can be added.
+	BestEffort BatchMode = "best_effort"
+)
+
+type BatchItemStatus string
+
+const (
+	BatchItemCreated  BatchItemStatus = "created"
+	BatchItemRejected BatchItemStatus = "rejected"
+	// BatchItemRolledBack marks valid dinosaurs left out because the all-or-nothing batch was rejected.
+	BatchItemRolledBack BatchItemStatus = "rolled_back"
+)
+
+type AddDinosaursRequest struct {
+	// Mode defaults to all_or_nothing.
+	Mode      BatchMode            `json:"mode,omitempty"`
+	Dinosaurs []AddDinosaurRequest `json:"dinosaurs"`
+}
+type AddDinosaursResponse struct {
+	Results []AddDinosaursResult `json:"results"`
+}
+
+// AddDinosaursResult is the outcome for the dinosaur at the same position of the batch.
+type AddDinosaursResult struct {
+	Index    int             `json:"index"`
+	Status   BatchItemStatus `json:"status"`
+	Dinosaur *Dinosaur       `json:"dinosaur,omitempty"`
+	// Code and Error report why a rejected dinosaur was not added.
+	Code  ErrorCode `json:"code,omitempty"`
+	Error string    `json:"error,omitempty"`
 }
-type MoveDinosaurResponse struct {
+
+// UpdateDinosaurRequest is a JSON Merge Patch of a dinosaur. Fields left out keep their current value.
+// Setting cage_id moves the dinosaur, its type follows the species.
+type UpdateDinosaurRequest struct {
+	Name    *string `json:"name,omitempty"`
+	Species *string `json:"species,omitempty"`
+	CageID  *uint   `json:"cage_id,omitempty"`
+}
+type UpdateDinosaurResponse struct {
 	Dinosaur Dinosaur `json:"dinosaur"`
 }
 
+type Move struct {
+	DinosaurID   uint `json:"dinosaur_id"`
+	TargetCageID uint `json:"target_cage_id"`
+}
+
+// MoveDinosaursRequest lists moves applied together. Only the park as it stands after all of them has to follow the rules.
+type MoveDinosaursRequest struct {
+	Moves []Move `json:"moves"`
+}
+type MoveDinosaursResponse struct {
+	// Dinosaurs are the moved dinosaurs, in the order of the moves.
+	Dinosaurs []Dinosaur `json:"dinosaurs"`
+}
+
 type RemoveDinosaurRequest struct {
 }
 type RemoveDinosaurResponse struct {
 }
 
+type RestoreDinosaurRequest struct {
+}
+type RestoreDinosaurResponse struct {
+	Dinosaur Dinosaur `json:"dinosaur"`
+}
+
 type GetDinosaurRequest struct {
 }
 type GetDinosaurResponse struct {
@@ -75,5 +144,8 @@ type GetDinosaursRequest struct {
 	FilteredSpecies []Species `json:"filtered_species,omitempty"`
 }
 type GetDinosaursResponse struct {
-	Dinosaurs []Dinosaur `json:"dinosaurs"`
+	Dinosaurs  []Dinosaur `json:"dinosaurs"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+	// Total is the number of all matching dinosaurs, only set if requested with include_total.
+	Total *int `json:"total,omitempty"`
 }
@@ -1,5 +1,76 @@
 package apimodels
 
-type ErrorResponse struct {
-	Error string `json:"error"`
+import (
+	"net/http"
+	"strings"
+)
+
+// ProblemContentType is the media type of error responses, which follow RFC 7807.
+const ProblemContentType = "application/problem+json"
+
+// ErrorCode identifies the kind of an error. Unlike the messages, codes are stable and can be relied upon by clients.
+type ErrorCode string
+
+const (
+	CageFull               ErrorCode = "CAGE_FULL"
+	CageUnpowered          ErrorCode = "CAGE_UNPOWERED"
+	DietConflict           ErrorCode = "DIET_CONFLICT"
+	SpeciesConflict        ErrorCode = "SPECIES_CONFLICT"
+	CageNotEmpty           ErrorCode = "CAGE_NOT_EMPTY"
+	CapacityBelowOccupancy ErrorCode = "CAPACITY_BELOW_OCCUPANCY"
+	NotFound               ErrorCode = "NOT_FOUND"
+	ValidationFailed       ErrorCode = "VALIDATION_FAILED"
+	PreconditionFailed     ErrorCode = "PRECONDITION_FAILED"
+	UnsupportedMediaType   ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	IdempotencyKeyReused   ErrorCode = "IDEMPOTENCY_KEY_REUSED"
+	IdempotencyKeyInUse    ErrorCode = "IDEMPOTENCY_KEY_IN_USE"
+	InternalError          ErrorCode = "INTERNAL_ERROR"
+)
+
+// ProblemType is an entry of the error catalogue, holding the status and title reported for an error code.
+type ProblemType struct {
+	Code   ErrorCode
+	Status int
+	Title  string
+}
+
+// The error catalogue, listing every kind of error the API reports.
+var (
+	CageFullProblem               = ProblemType{Code: CageFull, Status: http.StatusConflict, Title: "Cage is full"}
+	CageUnpoweredProblem          = ProblemType{Code: CageUnpowered, Status: http.StatusConflict, Title: "Cage has no power"}
+	DietConflictProblem           = ProblemType{Code: DietConflict, Status: http.StatusConflict, Title: "Herbivores cannot live with carnivores"}
+	SpeciesConflictProblem        = ProblemType{Code: SpeciesConflict, Status: http.StatusConflict, Title: "Carnivores cannot live with other species"}
+	CageNotEmptyProblem           = ProblemType{Code: CageNotEmpty, Status: http.StatusConflict, Title: "Cage is not empty"}
+	CapacityBelowOccupancyProblem = ProblemType{Code: CapacityBelowOccupancy, Status: http.StatusConflict, Title: "Capacity is below occupancy"}
+	NotFoundProblem               = ProblemType{Code: NotFound, Status: http.StatusNotFound, Title: "Resource not found"}
+	ValidationFailedProblem       = ProblemType{Code: ValidationFailed, Status: http.StatusBadRequest, Title: "Validation failed"}
+	PreconditionFailedProblem     = ProblemType{Code: PreconditionFailed, Status: http.StatusPreconditionFailed, Title: "Precondition failed"}
+	UnsupportedMediaTypeProblem   = ProblemType{Code: UnsupportedMediaType, Status: http.StatusUnsupportedMediaType, Title: "Unsupported media type"}
+	IdempotencyKeyReusedProblem   = ProblemType{Code: IdempotencyKeyReused, Status: http.StatusUnprocessableEntity, Title: "Idempotency key reused"}
+	IdempotencyKeyInUseProblem    = ProblemType{Code: IdempotencyKeyInUse, Status: http.StatusConflict, Title: "Idempotency key in use"}
+	InternalErrorProblem          = ProblemType{Code: InternalError, Status: http.StatusInternalServerError, Title: "Internal error"}
+)
+
+// URI identifies the problem type in the type member of problems.
+func (t ProblemType) URI() string {
+	return "urn:jurassic-park:problem:" + strings.ToLower(strings.ReplaceAll(string(t.Code), "_", "-"))
+}
+
+// New returns a problem of the type, with the detail explaining this occurrence.
+func (t ProblemType) New(detail string) Problem {
+	return Problem{Type: t.URI(), Title: t.Title, Status: t.Status, Detail: detail, Code: t.Code}
+}
+
+// Problem is an error response in the problem details format of RFC 7807,
+// extended with the error code and structured details of the error.
+type Problem struct {
+	Type   string    `json:"type"`
+	Title  string    `json:"title"`
+	Status int       `json:"status"`
+	Detail string    `json:"detail"`
+	Code   ErrorCode `json:"code"`
+	// Field names the request field, query parameter or header at fault, if any.
+	Field string `json:"field,omitempty"`
+	// CageID identifies the cage at fault, if any.
+	CageID uint `json:"cage_id,omitempty"`
 }
@@ -0,0 +1,33 @@
+package apimodels
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type HistoryAction string
+
+const (
+	Created      HistoryAction = "created"
+	PowerChanged HistoryAction = "power_changed"
+	Moved        HistoryAction = "moved"
+	Updated      HistoryAction = "updated"
+	Deleted      HistoryAction = "deleted"
+	Restored     HistoryAction = "restored"
+)
+
+type HistoryEntry struct {
+	ID         uint            `json:"id"`
+	Action     HistoryAction   `json:"action"`
+	Actor      string          `json:"actor"`
+	RecordedAt time.Time       `json:"recorded_at"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+}
+
+type GetHistoryRequest struct {
+}
+type GetHistoryResponse struct {
+	History    []HistoryEntry `json:"history"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
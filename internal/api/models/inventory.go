@@ -0,0 +1,57 @@
+package apimodels
+
+type InventoryFormat string
+
+const (
+	// CSV holds a header row naming the columns of the records, followed by one row per record.
+	CSV InventoryFormat = "csv"
+	// NDJSON holds one JSON record per line.
+	NDJSON InventoryFormat = "ndjson"
+)
+
+type InventoryKind string
+
+const (
+	InventoryCage     InventoryKind = "cage"
+	InventoryDinosaur InventoryKind = "dinosaur"
+)
+
+// InventoryRecord is a cage or a dinosaur of the park inventory. Fields not belonging to its kind are left empty.
+// The id and cage_id are IDs in the park, while cage_ref names a cage within the inventory: a cage is exported
+// with its ID as its cage_ref, and a dinosaur with the cage_ref of its cage.
+// On import, the id is ignored and cages are created with new IDs. A dinosaur with a cage_ref is placed into the cage
+// of the import with the same cage_ref, and its cage_id is ignored. A dinosaur without one is placed into the existing
+// cage of its cage_id. The type of a dinosaur follows its species, and a type given along has to match it.
+type InventoryRecord struct {
+	Kind        InventoryKind `json:"kind"`
+	ID          uint          `json:"id,omitempty"`
+	Capacity    int           `json:"capacity,omitempty"`
+	PowerStatus PowerStatus   `json:"power_status,omitempty"`
+	Name        string        `json:"name,omitempty"`
+	Species     string        `json:"species,omitempty"`
+	Type        DinosaurType  `json:"type,omitempty"`
+	CageID      uint          `json:"cage_id,omitempty"`
+	CageRef     string        `json:"cage_ref,omitempty"`
+}
+
+type ImportInventoryResponse struct {
+	DryRun bool `json:"dry_run"`
+	// Cages and Dinosaurs are the numbers of records imported, or that a dry run would import.
+	// Both are 0 if any record is rejected, as the import then adds nothing.
+	Cages     int           `json:"cages"`
+	Dinosaurs int           `json:"dinosaurs"`
+	Errors    []ImportError `json:"errors"`
+}
+
+// ImportError reports why the record on the line of the import is rejected. Lines are numbered from 1.
+type ImportError struct {
+	Line  int           `json:"line"`
+	Kind  InventoryKind `json:"kind,omitempty"`
+	Code  ErrorCode     `json:"code"`
+	Field string        `json:"field,omitempty"`
+	// CageID identifies the existing cage at fault, as the cage_id of the record refers to it.
+	CageID uint `json:"cage_id,omitempty"`
+	// CageRef identifies the cage of the import at fault, as the cage_ref of the record refers to it.
+	CageRef string `json:"cage_ref,omitempty"`
+	Error   string `json:"error"`
+}
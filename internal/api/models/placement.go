@@ -0,0 +1,24 @@
+package apimodels
+
+// Violation is a placement rule a dinosaur would break, identified by the code of the error reported for it.
+type Violation struct {
+	Rule    ErrorCode `json:"rule"`
+	Message string    `json:"message"`
+}
+
+type GetCompatibilityRequest struct {
+}
+
+// GetCompatibilityResponse reports whether a dinosaur can be placed in the cage, listing every rule it would break.
+type GetCompatibilityResponse struct {
+	Compatible bool        `json:"compatible"`
+	Violations []Violation `json:"violations"`
+}
+
+// DryRunDinosaurResponse answers a dry run of adding or updating a dinosaur, which changes nothing.
+type DryRunDinosaurResponse struct {
+	// Dinosaur is the dinosaur as it would be stored.
+	Dinosaur   Dinosaur    `json:"dinosaur"`
+	Compatible bool        `json:"compatible"`
+	Violations []Violation `json:"violations"`
+}
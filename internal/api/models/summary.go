@@ -0,0 +1,85 @@
+package apimodels
+
+import "time"
+
+// CageSummary is a cage as returned by the v2 API. Instead of embedding its dinosaurs, it links to them.
+type CageSummary struct {
+	ID           uint        `json:"id"`
+	Capacity     int         `json:"capacity"`
+	CurrentCount int         `json:"current_count"`
+	PowerStatus  PowerStatus `json:"power_status"`
+	DeletedAt    *time.Time  `json:"deleted_at,omitempty"`
+	Links        CageLinks   `json:"links"`
+	// Dinosaurs are only embedded if requested with include=dinosaurs.
+	Dinosaurs *[]DinosaurSummary `json:"dinosaurs,omitempty"`
+}
+
+type CageLinks struct {
+	Self string `json:"self"`
+	// Dinosaurs lists the dinosaurs living in the cage.
+	Dinosaurs string `json:"dinosaurs"`
+}
+
+// DinosaurSummary is a dinosaur as returned by the v2 API, linking to its cage.
+type DinosaurSummary struct {
+	ID        uint          `json:"id"`
+	Name      string        `json:"name"`
+	Species   Species       `json:"species"`
+	Type      DinosaurType  `json:"type"`
+	CageID    uint          `json:"cage_id"`
+	DeletedAt *time.Time    `json:"deleted_at,omitempty"`
+	Links     DinosaurLinks `json:"links"`
+	// Cage is only embedded if requested with include=cage.
+	Cage *CageSummary `json:"cage,omitempty"`
+}
+
+type DinosaurLinks struct {
+	Self string `json:"self"`
+	Cage string `json:"cage"`
+}
+
+// CageSummaryResponse returns a single cage from the v2 API.
+type CageSummaryResponse struct {
+	Cage CageSummary `json:"cage"`
+}
+
+type CageSummariesResponse struct {
+	Cages      []CageSummary `json:"cages"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+	// Total is the number of all matching cages, only set if requested with include_total.
+	Total *int `json:"total,omitempty"`
+}
+
+// DinosaurSummaryResponse returns a single dinosaur from the v2 API.
+type DinosaurSummaryResponse struct {
+	Dinosaur DinosaurSummary `json:"dinosaur"`
+}
+
+type DinosaurSummariesResponse struct {
+	Dinosaurs  []DinosaurSummary `json:"dinosaurs"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	// Total is the number of all matching dinosaurs, only set if requested with include_total.
+	Total *int `json:"total,omitempty"`
+}
+
+type AddDinosaurSummariesResponse struct {
+	Results []AddDinosaurSummaryResult `json:"results"`
+}
+
+// AddDinosaurSummaryResult is the outcome for the dinosaur at the same position of the batch.
+type AddDinosaurSummaryResult struct {
+	Index    int              `json:"index"`
+	Status   BatchItemStatus  `json:"status"`
+	Dinosaur *DinosaurSummary `json:"dinosaur,omitempty"`
+	// Code and Error report why a rejected dinosaur was not added.
+	Code  ErrorCode `json:"code,omitempty"`
+	Error string    `json:"error,omitempty"`
+}
+
+// DryRunDinosaurSummaryResponse answers a dry run of adding or updating a dinosaur in the v2 API.
+type DryRunDinosaurSummaryResponse struct {
+	// Dinosaur is the dinosaur as it would be stored.
+	Dinosaur   DinosaurSummary `json:"dinosaur"`
+	Compatible bool            `json:"compatible"`
+	Violations []Violation     `json:"violations"`
+}
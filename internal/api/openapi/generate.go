@@ -0,0 +1,271 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Endpoint describes an operation of the API.
+type Endpoint struct {
+	Method string
+	// Path is the route in gin syntax, e.g. /cages/:id. Path parameters are numeric IDs.
+	Path        string
+	OperationID string
+	Tag         string
+	Summary     string
+	Deprecated  bool
+	// Parameters lists the query and header parameters. Path parameters are taken from the path.
+	Parameters []Param
+	// Request is a value of the request body type, nil for operations without a body.
+	Request any
+	// RequestTypes lists the accepted media types of the request body, application/json by default.
+	RequestTypes []string
+	// Responses maps the status of every successful response to a value of its body type, or to nil
+	// for responses without a body.
+	Responses map[int]any
+	// ResponseTypes lists the media types of successful responses, application/json by default.
+	ResponseTypes []string
+	// Problems lists the statuses of errors reported by the operation.
+	Problems []int
+}
+
+// Param describes a query or header parameter. Type is a value of the parameter type,
+// slices describing parameters which take several values.
+type Param struct {
+	Name        string
+	In          string
+	Description string
+	Required    bool
+	Type        any
+}
+
+// Query returns an optional query parameter.
+func Query(name string, typ any, description string) Param {
+	return Param{Name: name, In: "query", Description: description, Type: typ}
+}
+
+// Header returns an optional request header.
+func Header(name string, typ any, description string) Param {
+	return Param{Name: name, In: "header", Description: description, Type: typ}
+}
+
+// OneOf is a response body which is a value of one of the types.
+type OneOf []any
+
+// Enum lists the values of a string type limited to a fixed set of values.
+type Enum struct {
+	typ    reflect.Type
+	values []string
+}
+
+// EnumOf returns the enum of the type of values.
+func EnumOf[T ~string](values ...T) Enum {
+	enum := Enum{typ: reflect.TypeOf(values).Elem()}
+	for _, value := range values {
+		enum.values = append(enum.values, string(value))
+	}
+	return enum
+}
+
+// Generator describes endpoints in OpenAPI documents.
+type Generator struct {
+	Info Info
+	Tags []Tag
+	// Problem is a value of the error response type, served as ProblemContentType.
+	Problem            any
+	ProblemContentType string
+	Enums              []Enum
+}
+
+// Generate returns the document describing the endpoints. Named structs and enums are described once, in the components.
+// Panics when a model cannot be described, which is a programming error.
+func (g Generator) Generate(endpoints []Endpoint) Document {
+	schemas := &schemaRegistry{enums: map[reflect.Type][]string{}, types: map[string]reflect.Type{}, schemas: map[string]*Schema{}}
+	for _, enum := range g.Enums {
+		schemas.enums[enum.typ] = enum.values
+	}
+
+	document := Document{OpenAPI: Version, Info: g.Info, Tags: g.Tags, Paths: map[string]PathItem{}}
+	for _, endpoint := range endpoints {
+		path, operation := g.operation(schemas, endpoint)
+		if document.Paths[path] == nil {
+			document.Paths[path] = PathItem{}
+		}
+		method := strings.ToLower(endpoint.Method)
+		if document.Paths[path][method] != nil {
+			panic(fmt.Sprintf("openapi: %s %s described twice", endpoint.Method, endpoint.Path))
+		}
+		document.Paths[path][method] = operation
+	}
+	document.Components.Schemas = schemas.schemas
+	return document
+}
+
+func (g Generator) operation(schemas *schemaRegistry, endpoint Endpoint) (string, *Operation) {
+	operation := &Operation{
+		Summary:     endpoint.Summary,
+		OperationID: endpoint.OperationID,
+		Deprecated:  endpoint.Deprecated,
+		Responses:   map[string]Response{},
+	}
+	if endpoint.Tag != "" {
+		operation.Tags = []string{endpoint.Tag}
+	}
+
+	segments := strings.Split(endpoint.Path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+			operation.Parameters = append(operation.Parameters,
+				Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "integer"}})
+		}
+	}
+	for _, param := range endpoint.Parameters {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:        param.Name,
+			In:          param.In,
+			Description: param.Description,
+			Required:    param.Required,
+			Schema:      schemas.of(reflect.TypeOf(param.Type)),
+		})
+	}
+
+	if endpoint.Request != nil {
+		contentTypes := endpoint.RequestTypes
+		if len(contentTypes) == 0 {
+			contentTypes = []string{"application/json"}
+		}
+		operation.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{}}
+		for _, contentType := range contentTypes {
+			operation.RequestBody.Content[contentType] = MediaType{Schema: schemas.of(reflect.TypeOf(endpoint.Request))}
+		}
+	}
+
+	for status, body := range endpoint.Responses {
+		if body == nil {
+			operation.Responses[strconv.Itoa(status)] = Response{Description: http.StatusText(status)}
+			continue
+		}
+		schema := &Schema{}
+		if oneOf, ok := body.(OneOf); ok {
+			for _, option := range oneOf {
+				schema.OneOf = append(schema.OneOf, schemas.of(reflect.TypeOf(option)))
+			}
+		} else {
+			schema = schemas.of(reflect.TypeOf(body))
+		}
+		contentTypes := endpoint.ResponseTypes
+		if len(contentTypes) == 0 {
+			contentTypes = []string{"application/json"}
+		}
+		response := Response{Description: http.StatusText(status), Content: map[string]MediaType{}}
+		for _, contentType := range contentTypes {
+			response.Content[contentType] = MediaType{Schema: schema}
+		}
+		operation.Responses[strconv.Itoa(status)] = response
+	}
+	for _, status := range endpoint.Problems {
+		operation.Responses[strconv.Itoa(status)] = Response{
+			Description: http.StatusText(status),
+			Content:     map[string]MediaType{g.ProblemContentType: {Schema: schemas.of(reflect.TypeOf(g.Problem))}},
+		}
+	}
+	return strings.Join(segments, "/"), operation
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRegistry describes Go types the way encoding/json encodes them, collecting the schemas of named types.
+type schemaRegistry struct {
+	enums   map[reflect.Type][]string
+	types   map[string]reflect.Type
+	schemas map[string]*Schema
+}
+
+// of returns the schema of the type, referencing the components for named structs and enums.
+func (r *schemaRegistry) of(t reflect.Type) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case r.enums[t] != nil:
+		return r.ref(t, func() *Schema { return &Schema{Type: "string", Enum: r.enums[t]} })
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return r.of(t.Elem())
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.of(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.object(t)
+		}
+		return r.ref(t, func() *Schema { return r.object(t) })
+	default:
+		panic(fmt.Sprintf("openapi: cannot describe type %s", t))
+	}
+}
+
+// ref registers the schema of the named type in the components, and returns a reference to it.
+func (r *schemaRegistry) ref(t reflect.Type, describe func() *Schema) *Schema {
+	if registered, ok := r.types[t.Name()]; ok && registered != t {
+		panic(fmt.Sprintf("openapi: types %s and %s share the schema name %s", registered, t, t.Name()))
+	}
+	if _, ok := r.types[t.Name()]; !ok {
+		r.types[t.Name()] = t
+		r.schemas[t.Name()] = describe()
+	}
+	return &Schema{Ref: "#/components/schemas/" + t.Name()}
+}
+
+// object describes the struct by its JSON fields. Fields are required unless they are pointers or omitted when empty.
+func (r *schemaRegistry) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			embedded := r.object(field.Type)
+			for name, property := range embedded.Properties {
+				schema.Properties[name] = property
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = r.of(field.Type)
+		if field.Type.Kind() != reflect.Pointer && !strings.Contains(options, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
@@ -0,0 +1,4322 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: jurassicpark/v1/park.proto
+
+package parkpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PowerStatus int32
+
+const (
+	PowerStatus_POWER_STATUS_UNSPECIFIED PowerStatus = 0
+	PowerStatus_POWER_STATUS_ACTIVE      PowerStatus = 1
+	PowerStatus_POWER_STATUS_DOWN        PowerStatus = 2
+)
+
+// Enum value maps for PowerStatus.
+var (
+	PowerStatus_name = map[int32]string{
+		0: "POWER_STATUS_UNSPECIFIED",
+		1: "POWER_STATUS_ACTIVE",
+		2: "POWER_STATUS_DOWN",
+	}
+	PowerStatus_value = map[string]int32{
+		"POWER_STATUS_UNSPECIFIED": 0,
+		"POWER_STATUS_ACTIVE":      1,
+		"POWER_STATUS_DOWN":        2,
+	}
+)
+
+func (x PowerStatus) Enum() *PowerStatus {
+	p := new(PowerStatus)
+	*p = x
+	return p
+}
+
+func (x PowerStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PowerStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_jurassicpark_v1_park_proto_enumTypes[0].Descriptor()
+}
+
+func (PowerStatus) Type() protoreflect.EnumType {
+	return &file_jurassicpark_v1_park_proto_enumTypes[0]
+}
+
+func (x PowerStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PowerStatus.Descriptor instead.
+func (PowerStatus) EnumDescriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{0}
+}
+
+type DinosaurType int32
+
+const (
+	DinosaurType_DINOSAUR_TYPE_UNSPECIFIED DinosaurType = 0
+	DinosaurType_DINOSAUR_TYPE_HERBIVORE   DinosaurType = 1
+	DinosaurType_DINOSAUR_TYPE_CARNIVORE   DinosaurType = 2
+)
+
+// Enum value maps for DinosaurType.
+var (
+	DinosaurType_name = map[int32]string{
+		0: "DINOSAUR_TYPE_UNSPECIFIED",
+		1: "DINOSAUR_TYPE_HERBIVORE",
+		2: "DINOSAUR_TYPE_CARNIVORE",
+	}
+	DinosaurType_value = map[string]int32{
+		"DINOSAUR_TYPE_UNSPECIFIED": 0,
+		"DINOSAUR_TYPE_HERBIVORE":   1,
+		"DINOSAUR_TYPE_CARNIVORE":   2,
+	}
+)
+
+func (x DinosaurType) Enum() *DinosaurType {
+	p := new(DinosaurType)
+	*p = x
+	return p
+}
+
+func (x DinosaurType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DinosaurType) Descriptor() protoreflect.EnumDescriptor {
+	return file_jurassicpark_v1_park_proto_enumTypes[1].Descriptor()
+}
+
+func (DinosaurType) Type() protoreflect.EnumType {
+	return &file_jurassicpark_v1_park_proto_enumTypes[1]
+}
+
+func (x DinosaurType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DinosaurType.Descriptor instead.
+func (DinosaurType) EnumDescriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{1}
+}
+
+type Species int32
+
+const (
+	Species_SPECIES_UNSPECIFIED   Species = 0
+	Species_SPECIES_TYRANNOSAURUS Species = 1
+	Species_SPECIES_VELOCIRAPTOR  Species = 2
+	Species_SPECIES_SPINOSAURUS   Species = 3
+	Species_SPECIES_MEGALOSAURUS  Species = 4
+	Species_SPECIES_BRACHIOSAURUS Species = 5
+	Species_SPECIES_STEGOSAURUS   Species = 6
+	Species_SPECIES_ANKYLOSAURUS  Species = 7
+	Species_SPECIES_TRICERATOPS   Species = 8
+)
+
+// Enum value maps for Species.
+var (
+	Species_name = map[int32]string{
+		0: "SPECIES_UNSPECIFIED",
+		1: "SPECIES_TYRANNOSAURUS",
+		2: "SPECIES_VELOCIRAPTOR",
+		3: "SPECIES_SPINOSAURUS",
+		4: "SPECIES_MEGALOSAURUS",
+		5: "SPECIES_BRACHIOSAURUS",
+		6: "SPECIES_STEGOSAURUS",
+		7: "SPECIES_ANKYLOSAURUS",
+		8: "SPECIES_TRICERATOPS",
+	}
+	Species_value = map[string]int32{
+		"SPECIES_UNSPECIFIED":   0,
+		"SPECIES_TYRANNOSAURUS": 1,
+		"SPECIES_VELOCIRAPTOR":  2,
+		"SPECIES_SPINOSAURUS":   3,
+		"SPECIES_MEGALOSAURUS":  4,
+		"SPECIES_BRACHIOSAURUS": 5,
+		"SPECIES_STEGOSAURUS":   6,
+		"SPECIES_ANKYLOSAURUS":  7,
+		"SPECIES_TRICERATOPS":   8,
+	}
+)
+
+func (x Species) Enum() *Species {
+	p := new(Species)
+	*p = x
+	return p
+}
+
+func (x Species) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Species) Descriptor() protoreflect.EnumDescriptor {
+	return file_jurassicpark_v1_park_proto_enumTypes[2].Descriptor()
+}
+
+func (Species) Type() protoreflect.EnumType {
+	return &file_jurassicpark_v1_park_proto_enumTypes[2]
+}
+
+func (x Species) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Species.Descriptor instead.
+func (Species) EnumDescriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{2}
+}
+
+type BatchMode int32
+
+const (
+	// All or nothing, the default.
+	BatchMode_BATCH_MODE_UNSPECIFIED    BatchMode = 0
+	BatchMode_BATCH_MODE_ALL_OR_NOTHING BatchMode = 1
+	BatchMode_BATCH_MODE_BEST_EFFORT    BatchMode = 2
+)
+
+// Enum value maps for BatchMode.
+var (
+	BatchMode_name = map[int32]string{
+		0: "BATCH_MODE_UNSPECIFIED",
+		1: "BATCH_MODE_ALL_OR_NOTHING",
+		2: "BATCH_MODE_BEST_EFFORT",
+	}
+	BatchMode_value = map[string]int32{
+		"BATCH_MODE_UNSPECIFIED":    0,
+		"BATCH_MODE_ALL_OR_NOTHING": 1,
+		"BATCH_MODE_BEST_EFFORT":    2,
+	}
+)
+
+func (x BatchMode) Enum() *BatchMode {
+	p := new(BatchMode)
+	*p = x
+	return p
+}
+
+func (x BatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_jurassicpark_v1_park_proto_enumTypes[3].Descriptor()
+}
+
+func (BatchMode) Type() protoreflect.EnumType {
+	return &file_jurassicpark_v1_park_proto_enumTypes[3]
+}
+
+func (x BatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchMode.Descriptor instead.
+func (BatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{3}
+}
+
+type CageEvent_Type int32
+
+const (
+	CageEvent_TYPE_UNSPECIFIED CageEvent_Type = 0
+	// The cage as it was when the watch started.
+	CageEvent_TYPE_CURRENT  CageEvent_Type = 1
+	CageEvent_TYPE_CREATED  CageEvent_Type = 2
+	CageEvent_TYPE_UPDATED  CageEvent_Type = 3
+	CageEvent_TYPE_DELETED  CageEvent_Type = 4
+	CageEvent_TYPE_RESTORED CageEvent_Type = 5
+)
+
+// Enum value maps for CageEvent_Type.
+var (
+	CageEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_CURRENT",
+		2: "TYPE_CREATED",
+		3: "TYPE_UPDATED",
+		4: "TYPE_DELETED",
+		5: "TYPE_RESTORED",
+	}
+	CageEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_CURRENT":     1,
+		"TYPE_CREATED":     2,
+		"TYPE_UPDATED":     3,
+		"TYPE_DELETED":     4,
+		"TYPE_RESTORED":    5,
+	}
+)
+
+func (x CageEvent_Type) Enum() *CageEvent_Type {
+	p := new(CageEvent_Type)
+	*p = x
+	return p
+}
+
+func (x CageEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CageEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_jurassicpark_v1_park_proto_enumTypes[4].Descriptor()
+}
+
+func (CageEvent_Type) Type() protoreflect.EnumType {
+	return &file_jurassicpark_v1_park_proto_enumTypes[4]
+}
+
+func (x CageEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CageEvent_Type.Descriptor instead.
+func (CageEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{19, 0}
+}
+
+type AddDinosaursResult_Status int32
+
+const (
+	AddDinosaursResult_STATUS_UNSPECIFIED AddDinosaursResult_Status = 0
+	AddDinosaursResult_STATUS_CREATED     AddDinosaursResult_Status = 1
+	AddDinosaursResult_STATUS_REJECTED    AddDinosaursResult_Status = 2
+	AddDinosaursResult_STATUS_ROLLED_BACK AddDinosaursResult_Status = 3
+)
+
+// Enum value maps for AddDinosaursResult_Status.
+var (
+	AddDinosaursResult_Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_CREATED",
+		2: "STATUS_REJECTED",
+		3: "STATUS_ROLLED_BACK",
+	}
+	AddDinosaursResult_Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_CREATED":     1,
+		"STATUS_REJECTED":    2,
+		"STATUS_ROLLED_BACK": 3,
+	}
+)
+
+func (x AddDinosaursResult_Status) Enum() *AddDinosaursResult_Status {
+	p := new(AddDinosaursResult_Status)
+	*p = x
+	return p
+}
+
+func (x AddDinosaursResult_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AddDinosaursResult_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_jurassicpark_v1_park_proto_enumTypes[5].Descriptor()
+}
+
+func (AddDinosaursResult_Status) Type() protoreflect.EnumType {
+	return &file_jurassicpark_v1_park_proto_enumTypes[5]
+}
+
+func (x AddDinosaursResult_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AddDinosaursResult_Status.Descriptor instead.
+func (AddDinosaursResult_Status) EnumDescriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{29, 0}
+}
+
+type Cage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           uint64      `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Capacity     int32       `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	CurrentCount int32       `protobuf:"varint,3,opt,name=current_count,json=currentCount,proto3" json:"current_count,omitempty"`
+	PowerStatus  PowerStatus `protobuf:"varint,4,opt,name=power_status,json=powerStatus,proto3,enum=jurassicpark.v1.PowerStatus" json:"power_status,omitempty"`
+	// Only set when requested with include_dinosaurs.
+	Dinosaurs []*Dinosaur `protobuf:"bytes,5,rep,name=dinosaurs,proto3" json:"dinosaurs,omitempty"`
+	// Changes with every change of the cage's own fields, but not with its dinosaurs, see expected_version.
+	Version   uint64                 `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Only set for deleted cages.
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+}
+
+func (x *Cage) Reset() {
+	*x = Cage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Cage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Cage) ProtoMessage() {}
+
+func (x *Cage) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Cage.ProtoReflect.Descriptor instead.
+func (*Cage) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Cage) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Cage) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Cage) GetCurrentCount() int32 {
+	if x != nil {
+		return x.CurrentCount
+	}
+	return 0
+}
+
+func (x *Cage) GetPowerStatus() PowerStatus {
+	if x != nil {
+		return x.PowerStatus
+	}
+	return PowerStatus_POWER_STATUS_UNSPECIFIED
+}
+
+func (x *Cage) GetDinosaurs() []*Dinosaur {
+	if x != nil {
+		return x.Dinosaurs
+	}
+	return nil
+}
+
+func (x *Cage) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Cage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Cage) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Cage) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type Dinosaur struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      uint64       `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name    string       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Species Species      `protobuf:"varint,3,opt,name=species,proto3,enum=jurassicpark.v1.Species" json:"species,omitempty"`
+	Type    DinosaurType `protobuf:"varint,4,opt,name=type,proto3,enum=jurassicpark.v1.DinosaurType" json:"type,omitempty"`
+	CageId  uint64       `protobuf:"varint,5,opt,name=cage_id,json=cageId,proto3" json:"cage_id,omitempty"`
+	// Only set when requested with include_cage. The cage holds its dinosaurs.
+	Cage *Cage `protobuf:"bytes,6,opt,name=cage,proto3" json:"cage,omitempty"`
+	// Changes with every change of the dinosaur, see expected_version.
+	Version   uint64                 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Only set for deleted dinosaurs.
+	DeletedAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+}
+
+func (x *Dinosaur) Reset() {
+	*x = Dinosaur{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Dinosaur) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dinosaur) ProtoMessage() {}
+
+func (x *Dinosaur) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dinosaur.ProtoReflect.Descriptor instead.
+func (*Dinosaur) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Dinosaur) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Dinosaur) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Dinosaur) GetSpecies() Species {
+	if x != nil {
+		return x.Species
+	}
+	return Species_SPECIES_UNSPECIFIED
+}
+
+func (x *Dinosaur) GetType() DinosaurType {
+	if x != nil {
+		return x.Type
+	}
+	return DinosaurType_DINOSAUR_TYPE_UNSPECIFIED
+}
+
+func (x *Dinosaur) GetCageId() uint64 {
+	if x != nil {
+		return x.CageId
+	}
+	return 0
+}
+
+func (x *Dinosaur) GetCage() *Cage {
+	if x != nil {
+		return x.Cage
+	}
+	return nil
+}
+
+func (x *Dinosaur) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Dinosaur) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Dinosaur) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Dinosaur) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type HistoryEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// One of created, power_changed, moved, updated, deleted or restored.
+	Action     string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Actor      string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	RecordedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=recorded_at,json=recordedAt,proto3" json:"recorded_at,omitempty"`
+	// JSON snapshots of the record, before is empty for creations.
+	Before string `protobuf:"bytes,5,opt,name=before,proto3" json:"before,omitempty"`
+	After  string `protobuf:"bytes,6,opt,name=after,proto3" json:"after,omitempty"`
+}
+
+func (x *HistoryEntry) Reset() {
+	*x = HistoryEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEntry) ProtoMessage() {}
+
+func (x *HistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEntry.ProtoReflect.Descriptor instead.
+func (*HistoryEntry) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *HistoryEntry) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *HistoryEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetRecordedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+// Violation is a placement rule a dinosaur would break.
+type Violation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// CAGE_FULL, CAGE_UNPOWERED, DIET_CONFLICT or SPECIES_CONFLICT.
+	Rule    string `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *Violation) Reset() {
+	*x = Violation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Violation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Violation) ProtoMessage() {}
+
+func (x *Violation) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Violation.ProtoReflect.Descriptor instead.
+func (*Violation) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Violation) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *Violation) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListCagesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PowerStatus      []PowerStatus `protobuf:"varint,1,rep,packed,name=power_status,json=powerStatus,proto3,enum=jurassicpark.v1.PowerStatus" json:"power_status,omitempty"`
+	HasFreeCapacity  *bool         `protobuf:"varint,2,opt,name=has_free_capacity,json=hasFreeCapacity,proto3,oneof" json:"has_free_capacity,omitempty"`
+	CapacityGte      *int32        `protobuf:"varint,3,opt,name=capacity_gte,json=capacityGte,proto3,oneof" json:"capacity_gte,omitempty"`
+	CapacityLte      *int32        `protobuf:"varint,4,opt,name=capacity_lte,json=capacityLte,proto3,oneof" json:"capacity_lte,omitempty"`
+	IncludeDeleted   bool          `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	IncludeTotal     bool          `protobuf:"varint,6,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"`
+	IncludeDinosaurs bool          `protobuf:"varint,7,opt,name=include_dinosaurs,json=includeDinosaurs,proto3" json:"include_dinosaurs,omitempty"`
+	Limit            int32         `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	Sort             []string      `protobuf:"bytes,9,rep,name=sort,proto3" json:"sort,omitempty"`
+	Cursor           string        `protobuf:"bytes,10,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *ListCagesRequest) Reset() {
+	*x = ListCagesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCagesRequest) ProtoMessage() {}
+
+func (x *ListCagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCagesRequest.ProtoReflect.Descriptor instead.
+func (*ListCagesRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListCagesRequest) GetPowerStatus() []PowerStatus {
+	if x != nil {
+		return x.PowerStatus
+	}
+	return nil
+}
+
+func (x *ListCagesRequest) GetHasFreeCapacity() bool {
+	if x != nil && x.HasFreeCapacity != nil {
+		return *x.HasFreeCapacity
+	}
+	return false
+}
+
+func (x *ListCagesRequest) GetCapacityGte() int32 {
+	if x != nil && x.CapacityGte != nil {
+		return *x.CapacityGte
+	}
+	return 0
+}
+
+func (x *ListCagesRequest) GetCapacityLte() int32 {
+	if x != nil && x.CapacityLte != nil {
+		return *x.CapacityLte
+	}
+	return 0
+}
+
+func (x *ListCagesRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListCagesRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+func (x *ListCagesRequest) GetIncludeDinosaurs() bool {
+	if x != nil {
+		return x.IncludeDinosaurs
+	}
+	return false
+}
+
+func (x *ListCagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCagesRequest) GetSort() []string {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *ListCagesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListCagesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cages      []*Cage `protobuf:"bytes,1,rep,name=cages,proto3" json:"cages,omitempty"`
+	NextCursor string  `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// Only set when requested with include_total.
+	Total *int32 `protobuf:"varint,3,opt,name=total,proto3,oneof" json:"total,omitempty"`
+}
+
+func (x *ListCagesResponse) Reset() {
+	*x = ListCagesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCagesResponse) ProtoMessage() {}
+
+func (x *ListCagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCagesResponse.ProtoReflect.Descriptor instead.
+func (*ListCagesResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListCagesResponse) GetCages() []*Cage {
+	if x != nil {
+		return x.Cages
+	}
+	return nil
+}
+
+func (x *ListCagesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListCagesResponse) GetTotal() int32 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+type GetCageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeDeleted   bool   `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	IncludeDinosaurs bool   `protobuf:"varint,3,opt,name=include_dinosaurs,json=includeDinosaurs,proto3" json:"include_dinosaurs,omitempty"`
+}
+
+func (x *GetCageRequest) Reset() {
+	*x = GetCageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCageRequest) ProtoMessage() {}
+
+func (x *GetCageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCageRequest.ProtoReflect.Descriptor instead.
+func (*GetCageRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCageRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetCageRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *GetCageRequest) GetIncludeDinosaurs() bool {
+	if x != nil {
+		return x.IncludeDinosaurs
+	}
+	return false
+}
+
+type GetCageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cage *Cage `protobuf:"bytes,1,opt,name=cage,proto3" json:"cage,omitempty"`
+}
+
+func (x *GetCageResponse) Reset() {
+	*x = GetCageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCageResponse) ProtoMessage() {}
+
+func (x *GetCageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCageResponse.ProtoReflect.Descriptor instead.
+func (*GetCageResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCageResponse) GetCage() *Cage {
+	if x != nil {
+		return x.Cage
+	}
+	return nil
+}
+
+type CreateCageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Capacity    int32       `protobuf:"varint,1,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	PowerStatus PowerStatus `protobuf:"varint,2,opt,name=power_status,json=powerStatus,proto3,enum=jurassicpark.v1.PowerStatus" json:"power_status,omitempty"`
+}
+
+func (x *CreateCageRequest) Reset() {
+	*x = CreateCageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateCageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCageRequest) ProtoMessage() {}
+
+func (x *CreateCageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCageRequest.ProtoReflect.Descriptor instead.
+func (*CreateCageRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CreateCageRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *CreateCageRequest) GetPowerStatus() PowerStatus {
+	if x != nil {
+		return x.PowerStatus
+	}
+	return PowerStatus_POWER_STATUS_UNSPECIFIED
+}
+
+type CreateCageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cage *Cage `protobuf:"bytes,1,opt,name=cage,proto3" json:"cage,omitempty"`
+}
+
+func (x *CreateCageResponse) Reset() {
+	*x = CreateCageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateCageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCageResponse) ProtoMessage() {}
+
+func (x *CreateCageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCageResponse.ProtoReflect.Descriptor instead.
+func (*CreateCageResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateCageResponse) GetCage() *Cage {
+	if x != nil {
+		return x.Cage
+	}
+	return nil
+}
+
+type UpdateCageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              uint64       `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Capacity        *int32       `protobuf:"varint,2,opt,name=capacity,proto3,oneof" json:"capacity,omitempty"`
+	PowerStatus     *PowerStatus `protobuf:"varint,3,opt,name=power_status,json=powerStatus,proto3,enum=jurassicpark.v1.PowerStatus,oneof" json:"power_status,omitempty"`
+	ExpectedVersion uint64       `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+}
+
+func (x *UpdateCageRequest) Reset() {
+	*x = UpdateCageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateCageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCageRequest) ProtoMessage() {}
+
+func (x *UpdateCageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCageRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCageRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateCageRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCageRequest) GetCapacity() int32 {
+	if x != nil && x.Capacity != nil {
+		return *x.Capacity
+	}
+	return 0
+}
+
+func (x *UpdateCageRequest) GetPowerStatus() PowerStatus {
+	if x != nil && x.PowerStatus != nil {
+		return *x.PowerStatus
+	}
+	return PowerStatus_POWER_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateCageRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateCageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cage *Cage `protobuf:"bytes,1,opt,name=cage,proto3" json:"cage,omitempty"`
+}
+
+func (x *UpdateCageResponse) Reset() {
+	*x = UpdateCageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateCageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCageResponse) ProtoMessage() {}
+
+func (x *UpdateCageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCageResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCageResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateCageResponse) GetCage() *Cage {
+	if x != nil {
+		return x.Cage
+	}
+	return nil
+}
+
+type DeleteCageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion uint64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+}
+
+func (x *DeleteCageRequest) Reset() {
+	*x = DeleteCageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCageRequest) ProtoMessage() {}
+
+func (x *DeleteCageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCageRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCageRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteCageRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteCageRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type DeleteCageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteCageResponse) Reset() {
+	*x = DeleteCageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteCageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCageResponse) ProtoMessage() {}
+
+func (x *DeleteCageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCageResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCageResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{13}
+}
+
+type RestoreCageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion uint64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+}
+
+func (x *RestoreCageRequest) Reset() {
+	*x = RestoreCageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreCageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCageRequest) ProtoMessage() {}
+
+func (x *RestoreCageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCageRequest.ProtoReflect.Descriptor instead.
+func (*RestoreCageRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RestoreCageRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RestoreCageRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type RestoreCageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cage *Cage `protobuf:"bytes,1,opt,name=cage,proto3" json:"cage,omitempty"`
+}
+
+func (x *RestoreCageResponse) Reset() {
+	*x = RestoreCageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreCageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCageResponse) ProtoMessage() {}
+
+func (x *RestoreCageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCageResponse.ProtoReflect.Descriptor instead.
+func (*RestoreCageResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RestoreCageResponse) GetCage() *Cage {
+	if x != nil {
+		return x.Cage
+	}
+	return nil
+}
+
+type GetCageHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *GetCageHistoryRequest) Reset() {
+	*x = GetCageHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCageHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCageHistoryRequest) ProtoMessage() {}
+
+func (x *GetCageHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCageHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetCageHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetCageHistoryRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetCageHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetCageHistoryRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	History    []*HistoryEntry `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
+	NextCursor string          `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+}
+
+func (x *GetHistoryResponse) Reset() {
+	*x = GetHistoryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHistoryResponse) ProtoMessage() {}
+
+func (x *GetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetHistoryResponse) GetHistory() []*HistoryEntry {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *GetHistoryResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type WatchCagesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Cages to watch, all cages when empty.
+	Ids              []uint64 `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	IncludeDinosaurs bool     `protobuf:"varint,2,opt,name=include_dinosaurs,json=includeDinosaurs,proto3" json:"include_dinosaurs,omitempty"`
+}
+
+func (x *WatchCagesRequest) Reset() {
+	*x = WatchCagesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchCagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchCagesRequest) ProtoMessage() {}
+
+func (x *WatchCagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchCagesRequest.ProtoReflect.Descriptor instead.
+func (*WatchCagesRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *WatchCagesRequest) GetIds() []uint64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *WatchCagesRequest) GetIncludeDinosaurs() bool {
+	if x != nil {
+		return x.IncludeDinosaurs
+	}
+	return false
+}
+
+type CageEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type CageEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=jurassicpark.v1.CageEvent_Type" json:"type,omitempty"`
+	Cage *Cage          `protobuf:"bytes,2,opt,name=cage,proto3" json:"cage,omitempty"`
+}
+
+func (x *CageEvent) Reset() {
+	*x = CageEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CageEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CageEvent) ProtoMessage() {}
+
+func (x *CageEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CageEvent.ProtoReflect.Descriptor instead.
+func (*CageEvent) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CageEvent) GetType() CageEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return CageEvent_TYPE_UNSPECIFIED
+}
+
+func (x *CageEvent) GetCage() *Cage {
+	if x != nil {
+		return x.Cage
+	}
+	return nil
+}
+
+type ListDinosaursRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Species []Species      `protobuf:"varint,1,rep,packed,name=species,proto3,enum=jurassicpark.v1.Species" json:"species,omitempty"`
+	Type    []DinosaurType `protobuf:"varint,2,rep,packed,name=type,proto3,enum=jurassicpark.v1.DinosaurType" json:"type,omitempty"`
+	CageId  []uint64       `protobuf:"varint,3,rep,packed,name=cage_id,json=cageId,proto3" json:"cage_id,omitempty"`
+	// Name prefixes, case-sensitive.
+	Name           []string `protobuf:"bytes,4,rep,name=name,proto3" json:"name,omitempty"`
+	IncludeDeleted bool     `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	IncludeTotal   bool     `protobuf:"varint,6,opt,name=include_total,json=includeTotal,proto3" json:"include_total,omitempty"`
+	IncludeCage    bool     `protobuf:"varint,7,opt,name=include_cage,json=includeCage,proto3" json:"include_cage,omitempty"`
+	Limit          int32    `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"`
+	Sort           []string `protobuf:"bytes,9,rep,name=sort,proto3" json:"sort,omitempty"`
+	Cursor         string   `protobuf:"bytes,10,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *ListDinosaursRequest) Reset() {
+	*x = ListDinosaursRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDinosaursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDinosaursRequest) ProtoMessage() {}
+
+func (x *ListDinosaursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDinosaursRequest.ProtoReflect.Descriptor instead.
+func (*ListDinosaursRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListDinosaursRequest) GetSpecies() []Species {
+	if x != nil {
+		return x.Species
+	}
+	return nil
+}
+
+func (x *ListDinosaursRequest) GetType() []DinosaurType {
+	if x != nil {
+		return x.Type
+	}
+	return nil
+}
+
+func (x *ListDinosaursRequest) GetCageId() []uint64 {
+	if x != nil {
+		return x.CageId
+	}
+	return nil
+}
+
+func (x *ListDinosaursRequest) GetName() []string {
+	if x != nil {
+		return x.Name
+	}
+	return nil
+}
+
+func (x *ListDinosaursRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *ListDinosaursRequest) GetIncludeTotal() bool {
+	if x != nil {
+		return x.IncludeTotal
+	}
+	return false
+}
+
+func (x *ListDinosaursRequest) GetIncludeCage() bool {
+	if x != nil {
+		return x.IncludeCage
+	}
+	return false
+}
+
+func (x *ListDinosaursRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDinosaursRequest) GetSort() []string {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *ListDinosaursRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListDinosaursResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dinosaurs  []*Dinosaur `protobuf:"bytes,1,rep,name=dinosaurs,proto3" json:"dinosaurs,omitempty"`
+	NextCursor string      `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// Only set when requested with include_total.
+	Total *int32 `protobuf:"varint,3,opt,name=total,proto3,oneof" json:"total,omitempty"`
+}
+
+func (x *ListDinosaursResponse) Reset() {
+	*x = ListDinosaursResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDinosaursResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDinosaursResponse) ProtoMessage() {}
+
+func (x *ListDinosaursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDinosaursResponse.ProtoReflect.Descriptor instead.
+func (*ListDinosaursResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListDinosaursResponse) GetDinosaurs() []*Dinosaur {
+	if x != nil {
+		return x.Dinosaurs
+	}
+	return nil
+}
+
+func (x *ListDinosaursResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListDinosaursResponse) GetTotal() int32 {
+	if x != nil && x.Total != nil {
+		return *x.Total
+	}
+	return 0
+}
+
+type GetDinosaurRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	IncludeDeleted bool   `protobuf:"varint,2,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	IncludeCage    bool   `protobuf:"varint,3,opt,name=include_cage,json=includeCage,proto3" json:"include_cage,omitempty"`
+}
+
+func (x *GetDinosaurRequest) Reset() {
+	*x = GetDinosaurRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDinosaurRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDinosaurRequest) ProtoMessage() {}
+
+func (x *GetDinosaurRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDinosaurRequest.ProtoReflect.Descriptor instead.
+func (*GetDinosaurRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetDinosaurRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetDinosaurRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *GetDinosaurRequest) GetIncludeCage() bool {
+	if x != nil {
+		return x.IncludeCage
+	}
+	return false
+}
+
+type GetDinosaurResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dinosaur *Dinosaur `protobuf:"bytes,1,opt,name=dinosaur,proto3" json:"dinosaur,omitempty"`
+}
+
+func (x *GetDinosaurResponse) Reset() {
+	*x = GetDinosaurResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDinosaurResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDinosaurResponse) ProtoMessage() {}
+
+func (x *GetDinosaurResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDinosaurResponse.ProtoReflect.Descriptor instead.
+func (*GetDinosaurResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetDinosaurResponse) GetDinosaur() *Dinosaur {
+	if x != nil {
+		return x.Dinosaur
+	}
+	return nil
+}
+
+type NewDinosaur struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Species Species `protobuf:"varint,2,opt,name=species,proto3,enum=jurassicpark.v1.Species" json:"species,omitempty"`
+	CageId  uint64  `protobuf:"varint,3,opt,name=cage_id,json=cageId,proto3" json:"cage_id,omitempty"`
+}
+
+func (x *NewDinosaur) Reset() {
+	*x = NewDinosaur{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NewDinosaur) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NewDinosaur) ProtoMessage() {}
+
+func (x *NewDinosaur) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NewDinosaur.ProtoReflect.Descriptor instead.
+func (*NewDinosaur) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *NewDinosaur) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NewDinosaur) GetSpecies() Species {
+	if x != nil {
+		return x.Species
+	}
+	return Species_SPECIES_UNSPECIFIED
+}
+
+func (x *NewDinosaur) GetCageId() uint64 {
+	if x != nil {
+		return x.CageId
+	}
+	return 0
+}
+
+type AddDinosaurRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dinosaur *NewDinosaur `protobuf:"bytes,1,opt,name=dinosaur,proto3" json:"dinosaur,omitempty"`
+	// Only checks the placement, without adding the dinosaur.
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (x *AddDinosaurRequest) Reset() {
+	*x = AddDinosaurRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddDinosaurRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDinosaurRequest) ProtoMessage() {}
+
+func (x *AddDinosaurRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDinosaurRequest.ProtoReflect.Descriptor instead.
+func (*AddDinosaurRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AddDinosaurRequest) GetDinosaur() *NewDinosaur {
+	if x != nil {
+		return x.Dinosaur
+	}
+	return nil
+}
+
+func (x *AddDinosaurRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// PlacementCheck reports every rule a placement would break, for dry runs.
+type PlacementCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Compatible bool         `protobuf:"varint,1,opt,name=compatible,proto3" json:"compatible,omitempty"`
+	Violations []*Violation `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+}
+
+func (x *PlacementCheck) Reset() {
+	*x = PlacementCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PlacementCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlacementCheck) ProtoMessage() {}
+
+func (x *PlacementCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlacementCheck.ProtoReflect.Descriptor instead.
+func (*PlacementCheck) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PlacementCheck) GetCompatible() bool {
+	if x != nil {
+		return x.Compatible
+	}
+	return false
+}
+
+func (x *PlacementCheck) GetViolations() []*Violation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+type AddDinosaurResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The dinosaur as it has been stored, or as it would be stored in a dry run.
+	Dinosaur *Dinosaur `protobuf:"bytes,1,opt,name=dinosaur,proto3" json:"dinosaur,omitempty"`
+	// Only set for dry runs.
+	Check *PlacementCheck `protobuf:"bytes,2,opt,name=check,proto3" json:"check,omitempty"`
+}
+
+func (x *AddDinosaurResponse) Reset() {
+	*x = AddDinosaurResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddDinosaurResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDinosaurResponse) ProtoMessage() {}
+
+func (x *AddDinosaurResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDinosaurResponse.ProtoReflect.Descriptor instead.
+func (*AddDinosaurResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AddDinosaurResponse) GetDinosaur() *Dinosaur {
+	if x != nil {
+		return x.Dinosaur
+	}
+	return nil
+}
+
+func (x *AddDinosaurResponse) GetCheck() *PlacementCheck {
+	if x != nil {
+		return x.Check
+	}
+	return nil
+}
+
+type AddDinosaursRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dinosaurs []*NewDinosaur `protobuf:"bytes,1,rep,name=dinosaurs,proto3" json:"dinosaurs,omitempty"`
+	Mode      BatchMode      `protobuf:"varint,2,opt,name=mode,proto3,enum=jurassicpark.v1.BatchMode" json:"mode,omitempty"`
+}
+
+func (x *AddDinosaursRequest) Reset() {
+	*x = AddDinosaursRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddDinosaursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDinosaursRequest) ProtoMessage() {}
+
+func (x *AddDinosaursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDinosaursRequest.ProtoReflect.Descriptor instead.
+func (*AddDinosaursRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AddDinosaursRequest) GetDinosaurs() []*NewDinosaur {
+	if x != nil {
+		return x.Dinosaurs
+	}
+	return nil
+}
+
+func (x *AddDinosaursRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_BATCH_MODE_UNSPECIFIED
+}
+
+type AddDinosaursResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index  int32                     `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Status AddDinosaursResult_Status `protobuf:"varint,2,opt,name=status,proto3,enum=jurassicpark.v1.AddDinosaursResult_Status" json:"status,omitempty"`
+	// Only set for created dinosaurs.
+	Dinosaur *Dinosaur `protobuf:"bytes,3,opt,name=dinosaur,proto3" json:"dinosaur,omitempty"`
+	// Only set for rejected dinosaurs.
+	Code  string `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *AddDinosaursResult) Reset() {
+	*x = AddDinosaursResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddDinosaursResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDinosaursResult) ProtoMessage() {}
+
+func (x *AddDinosaursResult) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDinosaursResult.ProtoReflect.Descriptor instead.
+func (*AddDinosaursResult) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AddDinosaursResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *AddDinosaursResult) GetStatus() AddDinosaursResult_Status {
+	if x != nil {
+		return x.Status
+	}
+	return AddDinosaursResult_STATUS_UNSPECIFIED
+}
+
+func (x *AddDinosaursResult) GetDinosaur() *Dinosaur {
+	if x != nil {
+		return x.Dinosaur
+	}
+	return nil
+}
+
+func (x *AddDinosaursResult) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AddDinosaursResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type AddDinosaursResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*AddDinosaursResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// Whether an all-or-nothing batch has been rolled back.
+	Rejected bool `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"`
+}
+
+func (x *AddDinosaursResponse) Reset() {
+	*x = AddDinosaursResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddDinosaursResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDinosaursResponse) ProtoMessage() {}
+
+func (x *AddDinosaursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDinosaursResponse.ProtoReflect.Descriptor instead.
+func (*AddDinosaursResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *AddDinosaursResponse) GetResults() []*AddDinosaursResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *AddDinosaursResponse) GetRejected() bool {
+	if x != nil {
+		return x.Rejected
+	}
+	return false
+}
+
+type UpdateDinosaurRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              uint64   `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name            *string  `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Species         *Species `protobuf:"varint,3,opt,name=species,proto3,enum=jurassicpark.v1.Species,oneof" json:"species,omitempty"`
+	CageId          *uint64  `protobuf:"varint,4,opt,name=cage_id,json=cageId,proto3,oneof" json:"cage_id,omitempty"`
+	ExpectedVersion uint64   `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	// Only checks the update, without changing the dinosaur.
+	DryRun bool `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+}
+
+func (x *UpdateDinosaurRequest) Reset() {
+	*x = UpdateDinosaurRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateDinosaurRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDinosaurRequest) ProtoMessage() {}
+
+func (x *UpdateDinosaurRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDinosaurRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDinosaurRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateDinosaurRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateDinosaurRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateDinosaurRequest) GetSpecies() Species {
+	if x != nil && x.Species != nil {
+		return *x.Species
+	}
+	return Species_SPECIES_UNSPECIFIED
+}
+
+func (x *UpdateDinosaurRequest) GetCageId() uint64 {
+	if x != nil && x.CageId != nil {
+		return *x.CageId
+	}
+	return 0
+}
+
+func (x *UpdateDinosaurRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *UpdateDinosaurRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type UpdateDinosaurResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The dinosaur as it has been stored, or as it would be stored in a dry run.
+	Dinosaur *Dinosaur `protobuf:"bytes,1,opt,name=dinosaur,proto3" json:"dinosaur,omitempty"`
+	// Only set for dry runs.
+	Check *PlacementCheck `protobuf:"bytes,2,opt,name=check,proto3" json:"check,omitempty"`
+}
+
+func (x *UpdateDinosaurResponse) Reset() {
+	*x = UpdateDinosaurResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateDinosaurResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDinosaurResponse) ProtoMessage() {}
+
+func (x *UpdateDinosaurResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDinosaurResponse.ProtoReflect.Descriptor instead.
+func (*UpdateDinosaurResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdateDinosaurResponse) GetDinosaur() *Dinosaur {
+	if x != nil {
+		return x.Dinosaur
+	}
+	return nil
+}
+
+func (x *UpdateDinosaurResponse) GetCheck() *PlacementCheck {
+	if x != nil {
+		return x.Check
+	}
+	return nil
+}
+
+type RemoveDinosaurRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion uint64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+}
+
+func (x *RemoveDinosaurRequest) Reset() {
+	*x = RemoveDinosaurRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveDinosaurRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDinosaurRequest) ProtoMessage() {}
+
+func (x *RemoveDinosaurRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDinosaurRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDinosaurRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RemoveDinosaurRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RemoveDinosaurRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type RemoveDinosaurResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RemoveDinosaurResponse) Reset() {
+	*x = RemoveDinosaurResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveDinosaurResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDinosaurResponse) ProtoMessage() {}
+
+func (x *RemoveDinosaurResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDinosaurResponse.ProtoReflect.Descriptor instead.
+func (*RemoveDinosaurResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{34}
+}
+
+type RestoreDinosaurRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id              uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ExpectedVersion uint64 `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+}
+
+func (x *RestoreDinosaurRequest) Reset() {
+	*x = RestoreDinosaurRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreDinosaurRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreDinosaurRequest) ProtoMessage() {}
+
+func (x *RestoreDinosaurRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreDinosaurRequest.ProtoReflect.Descriptor instead.
+func (*RestoreDinosaurRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RestoreDinosaurRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RestoreDinosaurRequest) GetExpectedVersion() uint64 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+type RestoreDinosaurResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dinosaur *Dinosaur `protobuf:"bytes,1,opt,name=dinosaur,proto3" json:"dinosaur,omitempty"`
+}
+
+func (x *RestoreDinosaurResponse) Reset() {
+	*x = RestoreDinosaurResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreDinosaurResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreDinosaurResponse) ProtoMessage() {}
+
+func (x *RestoreDinosaurResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreDinosaurResponse.ProtoReflect.Descriptor instead.
+func (*RestoreDinosaurResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RestoreDinosaurResponse) GetDinosaur() *Dinosaur {
+	if x != nil {
+		return x.Dinosaur
+	}
+	return nil
+}
+
+type GetDinosaurHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     uint64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *GetDinosaurHistoryRequest) Reset() {
+	*x = GetDinosaurHistoryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetDinosaurHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDinosaurHistoryRequest) ProtoMessage() {}
+
+func (x *GetDinosaurHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDinosaurHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetDinosaurHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetDinosaurHistoryRequest) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetDinosaurHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetDinosaurHistoryRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Checks either a dinosaur of the species, or the existing dinosaur, against the cage.
+type GetCompatibilityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CageId uint64 `protobuf:"varint,1,opt,name=cage_id,json=cageId,proto3" json:"cage_id,omitempty"`
+	// Types that are assignable to Dinosaur:
+	//	*GetCompatibilityRequest_Species
+	//	*GetCompatibilityRequest_DinosaurId
+	Dinosaur isGetCompatibilityRequest_Dinosaur `protobuf_oneof:"dinosaur"`
+}
+
+func (x *GetCompatibilityRequest) Reset() {
+	*x = GetCompatibilityRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCompatibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCompatibilityRequest) ProtoMessage() {}
+
+func (x *GetCompatibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCompatibilityRequest.ProtoReflect.Descriptor instead.
+func (*GetCompatibilityRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetCompatibilityRequest) GetCageId() uint64 {
+	if x != nil {
+		return x.CageId
+	}
+	return 0
+}
+
+func (m *GetCompatibilityRequest) GetDinosaur() isGetCompatibilityRequest_Dinosaur {
+	if m != nil {
+		return m.Dinosaur
+	}
+	return nil
+}
+
+func (x *GetCompatibilityRequest) GetSpecies() Species {
+	if x, ok := x.GetDinosaur().(*GetCompatibilityRequest_Species); ok {
+		return x.Species
+	}
+	return Species_SPECIES_UNSPECIFIED
+}
+
+func (x *GetCompatibilityRequest) GetDinosaurId() uint64 {
+	if x, ok := x.GetDinosaur().(*GetCompatibilityRequest_DinosaurId); ok {
+		return x.DinosaurId
+	}
+	return 0
+}
+
+type isGetCompatibilityRequest_Dinosaur interface {
+	isGetCompatibilityRequest_Dinosaur()
+}
+
+type GetCompatibilityRequest_Species struct {
+	Species Species `protobuf:"varint,2,opt,name=species,proto3,enum=jurassicpark.v1.Species,oneof"`
+}
+
+type GetCompatibilityRequest_DinosaurId struct {
+	DinosaurId uint64 `protobuf:"varint,3,opt,name=dinosaur_id,json=dinosaurId,proto3,oneof"`
+}
+
+func (*GetCompatibilityRequest_Species) isGetCompatibilityRequest_Dinosaur() {}
+
+func (*GetCompatibilityRequest_DinosaurId) isGetCompatibilityRequest_Dinosaur() {}
+
+type GetCompatibilityResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Compatible bool         `protobuf:"varint,1,opt,name=compatible,proto3" json:"compatible,omitempty"`
+	Violations []*Violation `protobuf:"bytes,2,rep,name=violations,proto3" json:"violations,omitempty"`
+}
+
+func (x *GetCompatibilityResponse) Reset() {
+	*x = GetCompatibilityResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCompatibilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCompatibilityResponse) ProtoMessage() {}
+
+func (x *GetCompatibilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCompatibilityResponse.ProtoReflect.Descriptor instead.
+func (*GetCompatibilityResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetCompatibilityResponse) GetCompatible() bool {
+	if x != nil {
+		return x.Compatible
+	}
+	return false
+}
+
+func (x *GetCompatibilityResponse) GetViolations() []*Violation {
+	if x != nil {
+		return x.Violations
+	}
+	return nil
+}
+
+type Move struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DinosaurId   uint64 `protobuf:"varint,1,opt,name=dinosaur_id,json=dinosaurId,proto3" json:"dinosaur_id,omitempty"`
+	TargetCageId uint64 `protobuf:"varint,2,opt,name=target_cage_id,json=targetCageId,proto3" json:"target_cage_id,omitempty"`
+}
+
+func (x *Move) Reset() {
+	*x = Move{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Move) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Move) ProtoMessage() {}
+
+func (x *Move) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Move.ProtoReflect.Descriptor instead.
+func (*Move) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Move) GetDinosaurId() uint64 {
+	if x != nil {
+		return x.DinosaurId
+	}
+	return 0
+}
+
+func (x *Move) GetTargetCageId() uint64 {
+	if x != nil {
+		return x.TargetCageId
+	}
+	return 0
+}
+
+type MoveDinosaursRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Moves []*Move `protobuf:"bytes,1,rep,name=moves,proto3" json:"moves,omitempty"`
+}
+
+func (x *MoveDinosaursRequest) Reset() {
+	*x = MoveDinosaursRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveDinosaursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveDinosaursRequest) ProtoMessage() {}
+
+func (x *MoveDinosaursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveDinosaursRequest.ProtoReflect.Descriptor instead.
+func (*MoveDinosaursRequest) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *MoveDinosaursRequest) GetMoves() []*Move {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+type MoveDinosaursResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Dinosaurs []*Dinosaur `protobuf:"bytes,1,rep,name=dinosaurs,proto3" json:"dinosaurs,omitempty"`
+}
+
+func (x *MoveDinosaursResponse) Reset() {
+	*x = MoveDinosaursResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jurassicpark_v1_park_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MoveDinosaursResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveDinosaursResponse) ProtoMessage() {}
+
+func (x *MoveDinosaursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_jurassicpark_v1_park_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveDinosaursResponse.ProtoReflect.Descriptor instead.
+func (*MoveDinosaursResponse) Descriptor() ([]byte, []int) {
+	return file_jurassicpark_v1_park_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *MoveDinosaursResponse) GetDinosaurs() []*Dinosaur {
+	if x != nil {
+		return x.Dinosaurs
+	}
+	return nil
+}
+
+var File_jurassicpark_v1_park_proto protoreflect.FileDescriptor
+
+var file_jurassicpark_v1_park_proto_rawDesc = []byte{
+	0x0a, 0x1a, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2f, 0x76,
+	0x31, 0x2f, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x6a, 0x75,
+	0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9c,
+	0x03, 0x0a, 0x04, 0x43, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63,
+	0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63,
+	0x69, 0x74, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x0c, 0x70, 0x6f, 0x77, 0x65,
+	0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c,
+	0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0b, 0x70, 0x6f,
+	0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x64, 0x69, 0x6e,
+	0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a,
+	0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x44,
+	0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x09, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75,
+	0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xa4, 0x03,
+	0x0a, 0x08, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x32,
+	0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x18, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x52, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69,
+	0x65, 0x73, 0x12, 0x31, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1d, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x63, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x29,
+	0x0a, 0x04, 0x63, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6a,
+	0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x61, 0x67, 0x65, 0x52, 0x04, 0x63, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39,
+	0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x64, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x22, 0xb7, 0x01, 0x0a, 0x0c, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x63,
+	0x74, 0x6f, 0x72, 0x12, 0x3b, 0x0a, 0x0b, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65,
+	0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x22, 0x39,
+	0x0a, 0x09, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72,
+	0x75, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x75, 0x6c, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xc9, 0x03, 0x0a, 0x10, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f,
+	0x0a, 0x0c, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70,
+	0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x0b, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x2f, 0x0a, 0x11, 0x68, 0x61, 0x73, 0x5f, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x63, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0f, 0x68, 0x61,
+	0x73, 0x46, 0x72, 0x65, 0x65, 0x43, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01,
+	0x12, 0x26, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x5f, 0x67, 0x74, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x0b, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69,
+	0x74, 0x79, 0x47, 0x74, 0x65, 0x88, 0x01, 0x01, 0x12, 0x26, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61,
+	0x63, 0x69, 0x74, 0x79, 0x5f, 0x6c, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x02,
+	0x52, 0x0b, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x4c, 0x74, 0x65, 0x88, 0x01, 0x01,
+	0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2b,
+	0x0a, 0x11, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61,
+	0x75, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x42, 0x14, 0x0a,
+	0x12, 0x5f, 0x68, 0x61, 0x73, 0x5f, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63,
+	0x69, 0x74, 0x79, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79,
+	0x5f, 0x67, 0x74, 0x65, 0x42, 0x0f, 0x0a, 0x0d, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74,
+	0x79, 0x5f, 0x6c, 0x74, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x61,
+	0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x05, 0x63,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6a, 0x75, 0x72,
+	0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x67,
+	0x65, 0x52, 0x05, 0x63, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x78, 0x74,
+	0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6e,
+	0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x19, 0x0a, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x76,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x5f, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x69, 0x6e,
+	0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x22, 0x3c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x63, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73,
+	0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x67, 0x65, 0x52, 0x04,
+	0x63, 0x61, 0x67, 0x65, 0x22, 0x70, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x61, 0x70,
+	0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x61, 0x70,
+	0x61, 0x63, 0x69, 0x74, 0x79, 0x12, 0x3f, 0x0a, 0x0c, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6a, 0x75,
+	0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f,
+	0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x0b, 0x70, 0x6f, 0x77, 0x65, 0x72,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x3f, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x04,
+	0x63, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6a, 0x75, 0x72,
+	0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x67,
+	0x65, 0x52, 0x04, 0x63, 0x61, 0x67, 0x65, 0x22, 0xd3, 0x01, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a,
+	0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x48,
+	0x00, 0x52, 0x08, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x88, 0x01, 0x01, 0x12, 0x44,
+	0x0a, 0x0c, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70,
+	0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x48, 0x01, 0x52, 0x0b, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x88, 0x01, 0x01, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42,
+	0x0b, 0x0a, 0x09, 0x5f, 0x63, 0x61, 0x70, 0x61, 0x63, 0x69, 0x74, 0x79, 0x42, 0x0f, 0x0a, 0x0d,
+	0x5f, 0x70, 0x6f, 0x77, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x3f, 0x0a,
+	0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x63, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x67, 0x65, 0x52, 0x04, 0x63, 0x61, 0x67, 0x65, 0x22, 0x4e,
+	0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x14,
+	0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x4f, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x43,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78,
+	0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x40, 0x0a, 0x13, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x04,
+	0x63, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6a, 0x75, 0x72,
+	0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x67,
+	0x65, 0x52, 0x04, 0x63, 0x61, 0x67, 0x65, 0x22, 0x55, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x61,
+	0x67, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x6e,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63,
+	0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x52,
+	0x0a, 0x11, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04,
+	0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x5f, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75,
+	0x72, 0x73, 0x22, 0xe4, 0x01, 0x0a, 0x09, 0x43, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x33, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f,
+	0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x29, 0x0a, 0x04, 0x63, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61,
+	0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x67, 0x65, 0x52, 0x04, 0x63, 0x61, 0x67, 0x65,
+	0x22, 0x77, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10,
+	0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x55, 0x52, 0x52, 0x45, 0x4e, 0x54, 0x10, 0x01,
+	0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44,
+	0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54,
+	0x45, 0x44, 0x10, 0x03, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x45, 0x4c,
+	0x45, 0x54, 0x45, 0x44, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52,
+	0x45, 0x53, 0x54, 0x4f, 0x52, 0x45, 0x44, 0x10, 0x05, 0x22, 0xdd, 0x02, 0x0a, 0x14, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x32, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61,
+	0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x52, 0x07, 0x73,
+	0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70,
+	0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x67,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x04, 0x52, 0x06, 0x63, 0x61, 0x67, 0x65,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64,
+	0x65, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x12,
+	0x23, 0x0a, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f,
+	0x63, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x43, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x96, 0x01, 0x0a, 0x15, 0x4c, 0x69,
+	0x73, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69,
+	0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75,
+	0x72, 0x52, 0x09, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6e, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x19, 0x0a,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x05,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x22, 0x70, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x63, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x43, 0x61, 0x67, 0x65, 0x22, 0x4c, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73,
+	0x61, 0x75, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x64,
+	0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x08, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61,
+	0x75, 0x72, 0x22, 0x6e, 0x0a, 0x0b, 0x4e, 0x65, 0x77, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69,
+	0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73,
+	0x52, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x67,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x63, 0x61, 0x67, 0x65,
+	0x49, 0x64, 0x22, 0x67, 0x0a, 0x12, 0x41, 0x64, 0x64, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x08, 0x64, 0x69, 0x6e, 0x6f,
+	0x73, 0x61, 0x75, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6a, 0x75, 0x72,
+	0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x77,
+	0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x08, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61,
+	0x75, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x22, 0x6c, 0x0a, 0x0e, 0x50,
+	0x6c, 0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x3a, 0x0a,
+	0x0a, 0x76, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b,
+	0x2e, 0x76, 0x31, 0x2e, 0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x76,
+	0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x13, 0x41, 0x64,
+	0x64, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61,
+	0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x08,
+	0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x12, 0x35, 0x0a, 0x05, 0x63, 0x68, 0x65, 0x63,
+	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73,
+	0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c, 0x61, 0x63, 0x65, 0x6d,
+	0x65, 0x6e, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x05, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x22,
+	0x81, 0x01, 0x0a, 0x13, 0x41, 0x64, 0x64, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3a, 0x0a, 0x09, 0x64, 0x69, 0x6e, 0x6f, 0x73,
+	0x61, 0x75, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6a, 0x75, 0x72,
+	0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x65, 0x77,
+	0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x09, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61,
+	0x75, 0x72, 0x73, 0x12, 0x2e, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1a, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x6d,
+	0x6f, 0x64, 0x65, 0x22, 0xb2, 0x02, 0x0a, 0x12, 0x41, 0x64, 0x64, 0x44, 0x69, 0x6e, 0x6f, 0x73,
+	0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x42, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x2a, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69,
+	0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75,
+	0x72, 0x52, 0x08, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x61, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x16, 0x0a, 0x12, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x53,
+	0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x02,
+	0x12, 0x16, 0x0a, 0x12, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x52, 0x4f, 0x4c, 0x4c, 0x45,
+	0x44, 0x5f, 0x42, 0x41, 0x43, 0x4b, 0x10, 0x03, 0x22, 0x71, 0x0a, 0x14, 0x41, 0x64, 0x64, 0x44,
+	0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3d, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0xfc, 0x01, 0x0a, 0x15,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x37,
+	0x0a, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x18, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x48, 0x01, 0x52, 0x07, 0x73, 0x70, 0x65,
+	0x63, 0x69, 0x65, 0x73, 0x88, 0x01, 0x01, 0x12, 0x1c, 0x0a, 0x07, 0x63, 0x61, 0x67, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x48, 0x02, 0x52, 0x06, 0x63, 0x61, 0x67, 0x65,
+	0x49, 0x64, 0x88, 0x01, 0x01, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x17, 0x0a, 0x07, 0x64, 0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x42, 0x0a,
+	0x0a, 0x08, 0x5f, 0x63, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x22, 0x86, 0x01, 0x0a, 0x16, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73,
+	0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61,
+	0x75, 0x72, 0x52, 0x08, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x12, 0x35, 0x0a, 0x05,
+	0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6a, 0x75,
+	0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6c,
+	0x61, 0x63, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x05, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x22, 0x52, 0x0a, 0x15, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x69, 0x6e,
+	0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x18, 0x0a, 0x16, 0x52, 0x65, 0x6d, 0x6f, 0x76,
+	0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x53, 0x0a, 0x16, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x69, 0x6e, 0x6f,
+	0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x65,
+	0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x50, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61,
+	0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x08,
+	0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x22, 0x59, 0x0a, 0x19, 0x47, 0x65, 0x74, 0x44,
+	0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x22, 0x97, 0x01, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x63, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x63, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x73, 0x70, 0x65, 0x63,
+	0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x6a, 0x75, 0x72, 0x61,
+	0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x70, 0x65, 0x63,
+	0x69, 0x65, 0x73, 0x48, 0x00, 0x52, 0x07, 0x73, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x12, 0x21,
+	0x0a, 0x0b, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x49,
+	0x64, 0x42, 0x0a, 0x0a, 0x08, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x22, 0x76, 0x0a,
+	0x18, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d,
+	0x70, 0x61, 0x74, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x6c, 0x65, 0x12, 0x3a, 0x0a, 0x0a, 0x76, 0x69, 0x6f,
+	0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x69, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x76, 0x69, 0x6f, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x4d, 0x0a, 0x04, 0x4d, 0x6f, 0x76, 0x65, 0x12, 0x1f, 0x0a,
+	0x0b, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x49, 0x64, 0x12, 0x24,
+	0x0a, 0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x63, 0x61, 0x67, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x43, 0x61,
+	0x67, 0x65, 0x49, 0x64, 0x22, 0x43, 0x0a, 0x14, 0x4d, 0x6f, 0x76, 0x65, 0x44, 0x69, 0x6e, 0x6f,
+	0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x05,
+	0x6d, 0x6f, 0x76, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6a, 0x75,
+	0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f,
+	0x76, 0x65, 0x52, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x73, 0x22, 0x50, 0x0a, 0x15, 0x4d, 0x6f, 0x76,
+	0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63,
+	0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72,
+	0x52, 0x09, 0x64, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x2a, 0x5b, 0x0a, 0x0b, 0x50,
+	0x6f, 0x77, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x4f,
+	0x57, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x50, 0x4f, 0x57, 0x45,
+	0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10,
+	0x01, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x4f, 0x57, 0x45, 0x52, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x02, 0x2a, 0x67, 0x0a, 0x0c, 0x44, 0x69, 0x6e, 0x6f,
+	0x73, 0x61, 0x75, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x19, 0x44, 0x49, 0x4e, 0x4f,
+	0x53, 0x41, 0x55, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x44, 0x49, 0x4e, 0x4f, 0x53,
+	0x41, 0x55, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x48, 0x45, 0x52, 0x42, 0x49, 0x56, 0x4f,
+	0x52, 0x45, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x44, 0x49, 0x4e, 0x4f, 0x53, 0x41, 0x55, 0x52,
+	0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x41, 0x52, 0x4e, 0x49, 0x56, 0x4f, 0x52, 0x45, 0x10,
+	0x02, 0x2a, 0xf1, 0x01, 0x0a, 0x07, 0x53, 0x70, 0x65, 0x63, 0x69, 0x65, 0x73, 0x12, 0x17, 0x0a,
+	0x13, 0x53, 0x50, 0x45, 0x43, 0x49, 0x45, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49,
+	0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x50, 0x45, 0x43, 0x49, 0x45,
+	0x53, 0x5f, 0x54, 0x59, 0x52, 0x41, 0x4e, 0x4e, 0x4f, 0x53, 0x41, 0x55, 0x52, 0x55, 0x53, 0x10,
+	0x01, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x50, 0x45, 0x43, 0x49, 0x45, 0x53, 0x5f, 0x56, 0x45, 0x4c,
+	0x4f, 0x43, 0x49, 0x52, 0x41, 0x50, 0x54, 0x4f, 0x52, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x53,
+	0x50, 0x45, 0x43, 0x49, 0x45, 0x53, 0x5f, 0x53, 0x50, 0x49, 0x4e, 0x4f, 0x53, 0x41, 0x55, 0x52,
+	0x55, 0x53, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x50, 0x45, 0x43, 0x49, 0x45, 0x53, 0x5f,
+	0x4d, 0x45, 0x47, 0x41, 0x4c, 0x4f, 0x53, 0x41, 0x55, 0x52, 0x55, 0x53, 0x10, 0x04, 0x12, 0x19,
+	0x0a, 0x15, 0x53, 0x50, 0x45, 0x43, 0x49, 0x45, 0x53, 0x5f, 0x42, 0x52, 0x41, 0x43, 0x48, 0x49,
+	0x4f, 0x53, 0x41, 0x55, 0x52, 0x55, 0x53, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x45, 0x53, 0x5f, 0x53, 0x54, 0x45, 0x47, 0x4f, 0x53, 0x41, 0x55, 0x52, 0x55, 0x53,
+	0x10, 0x06, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x50, 0x45, 0x43, 0x49, 0x45, 0x53, 0x5f, 0x41, 0x4e,
+	0x4b, 0x59, 0x4c, 0x4f, 0x53, 0x41, 0x55, 0x52, 0x55, 0x53, 0x10, 0x07, 0x12, 0x17, 0x0a, 0x13,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x45, 0x53, 0x5f, 0x54, 0x52, 0x49, 0x43, 0x45, 0x52, 0x41, 0x54,
+	0x4f, 0x50, 0x53, 0x10, 0x08, 0x2a, 0x62, 0x0a, 0x09, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1d,
+	0x0a, 0x19, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x41, 0x4c, 0x4c,
+	0x5f, 0x4f, 0x52, 0x5f, 0x4e, 0x4f, 0x54, 0x48, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x1a, 0x0a,
+	0x16, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x42, 0x45, 0x53, 0x54,
+	0x5f, 0x45, 0x46, 0x46, 0x4f, 0x52, 0x54, 0x10, 0x02, 0x32, 0xbd, 0x05, 0x0a, 0x0b, 0x43, 0x61,
+	0x67, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x52, 0x0a, 0x09, 0x4c, 0x69, 0x73,
+	0x74, 0x43, 0x61, 0x67, 0x65, 0x73, 0x12, 0x21, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69,
+	0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x61, 0x67,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x6a, 0x75, 0x72, 0x61,
+	0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x43, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a,
+	0x07, 0x47, 0x65, 0x74, 0x43, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73,
+	0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6a, 0x75, 0x72, 0x61,
+	0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43,
+	0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0a, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x61, 0x67, 0x65, 0x12, 0x22, 0x2e, 0x6a, 0x75, 0x72, 0x61,
+	0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
+	0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x55, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x67, 0x65,
+	0x12, 0x22, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70,
+	0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x61, 0x67,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x55, 0x0a, 0x0a, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x43, 0x61, 0x67, 0x65, 0x12, 0x22, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73,
+	0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6a, 0x75,
+	0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x58, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x61, 0x67, 0x65, 0x12,
+	0x23, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70,
+	0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x43, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5d, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x43, 0x61, 0x67, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x26, 0x2e, 0x6a,
+	0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x43, 0x61, 0x67, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70,
+	0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0a, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x43, 0x61, 0x67, 0x65, 0x73, 0x12, 0x22, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73,
+	0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x43,
+	0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x6a, 0x75,
+	0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61,
+	0x67, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x32, 0xde, 0x07, 0x0a, 0x0f, 0x44, 0x69,
+	0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5e, 0x0a,
+	0x0d, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x12, 0x25,
+	0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63,
+	0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x69, 0x6e, 0x6f,
+	0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a,
+	0x0b, 0x47, 0x65, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x12, 0x23, 0x2e, 0x6a,
+	0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x24, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x58, 0x0a, 0x0b, 0x41, 0x64, 0x64, 0x44, 0x69,
+	0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x12, 0x23, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69,
+	0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x44, 0x69, 0x6e, 0x6f,
+	0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x6a, 0x75,
+	0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64,
+	0x64, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x5b, 0x0a, 0x0c, 0x41, 0x64, 0x64, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72,
+	0x73, 0x12, 0x24, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73,
+	0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x44, 0x69, 0x6e,
+	0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61,
+	0x0a, 0x0e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72,
+	0x12, 0x26, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73,
+	0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x61, 0x0a, 0x0e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73,
+	0x61, 0x75, 0x72, 0x12, 0x26, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61,
+	0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x44, 0x69, 0x6e, 0x6f,
+	0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6a, 0x75,
+	0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x6d, 0x6f, 0x76, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x64, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x44,
+	0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x12, 0x27, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73,
+	0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x28, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61,
+	0x75, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x65, 0x0a, 0x12, 0x47, 0x65,
+	0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79,
+	0x12, 0x2a, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x48, 0x69,
+	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x6a,
+	0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x67, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x28, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63,
+	0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x74, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x29, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x74, 0x69, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x0d, 0x4d, 0x6f,
+	0x76, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x12, 0x25, 0x2e, 0x6a, 0x75,
+	0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f,
+	0x76, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x70, 0x61, 0x72,
+	0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x44, 0x69, 0x6e, 0x6f, 0x73, 0x61, 0x75,
+	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x31, 0x5a, 0x2f, 0x70, 0x70,
+	0x2d, 0x6a, 0x75, 0x72, 0x61, 0x73, 0x73, 0x69, 0x63, 0x2d, 0x70, 0x61, 0x72, 0x6b, 0x2d, 0x61,
+	0x70, 0x69, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x70, 0x61, 0x72, 0x6b, 0x70, 0x62, 0x3b, 0x70, 0x61, 0x72, 0x6b, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_jurassicpark_v1_park_proto_rawDescOnce sync.Once
+	file_jurassicpark_v1_park_proto_rawDescData = file_jurassicpark_v1_park_proto_rawDesc
+)
+
+func file_jurassicpark_v1_park_proto_rawDescGZIP() []byte {
+	file_jurassicpark_v1_park_proto_rawDescOnce.Do(func() {
+		file_jurassicpark_v1_park_proto_rawDescData = protoimpl.X.CompressGZIP(file_jurassicpark_v1_park_proto_rawDescData)
+	})
+	return file_jurassicpark_v1_park_proto_rawDescData
+}
+
+var file_jurassicpark_v1_park_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_jurassicpark_v1_park_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
+var file_jurassicpark_v1_park_proto_goTypes = []interface{}{
+	(PowerStatus)(0),                  // 0: jurassicpark.v1.PowerStatus
+	(DinosaurType)(0),                 // 1: jurassicpark.v1.DinosaurType
+	(Species)(0),                      // 2: jurassicpark.v1.Species
+	(BatchMode)(0),                    // 3: jurassicpark.v1.BatchMode
+	(CageEvent_Type)(0),               // 4: jurassicpark.v1.CageEvent.Type
+	(AddDinosaursResult_Status)(0),    // 5: jurassicpark.v1.AddDinosaursResult.Status
+	(*Cage)(nil),                      // 6: jurassicpark.v1.Cage
+	(*Dinosaur)(nil),                  // 7: jurassicpark.v1.Dinosaur
+	(*HistoryEntry)(nil),              // 8: jurassicpark.v1.HistoryEntry
+	(*Violation)(nil),                 // 9: jurassicpark.v1.Violation
+	(*ListCagesRequest)(nil),          // 10: jurassicpark.v1.ListCagesRequest
+	(*ListCagesResponse)(nil),         // 11: jurassicpark.v1.ListCagesResponse
+	(*GetCageRequest)(nil),            // 12: jurassicpark.v1.GetCageRequest
+	(*GetCageResponse)(nil),           // 13: jurassicpark.v1.GetCageResponse
+	(*CreateCageRequest)(nil),         // 14: jurassicpark.v1.CreateCageRequest
+	(*CreateCageResponse)(nil),        // 15: jurassicpark.v1.CreateCageResponse
+	(*UpdateCageRequest)(nil),         // 16: jurassicpark.v1.UpdateCageRequest
+	(*UpdateCageResponse)(nil),        // 17: jurassicpark.v1.UpdateCageResponse
+	(*DeleteCageRequest)(nil),         // 18: jurassicpark.v1.DeleteCageRequest
+	(*DeleteCageResponse)(nil),        // 19: jurassicpark.v1.DeleteCageResponse
+	(*RestoreCageRequest)(nil),        // 20: jurassicpark.v1.RestoreCageRequest
+	(*RestoreCageResponse)(nil),       // 21: jurassicpark.v1.RestoreCageResponse
+	(*GetCageHistoryRequest)(nil),     // 22: jurassicpark.v1.GetCageHistoryRequest
+	(*GetHistoryResponse)(nil),        // 23: jurassicpark.v1.GetHistoryResponse
+	(*WatchCagesRequest)(nil),         // 24: jurassicpark.v1.WatchCagesRequest
+	(*CageEvent)(nil),                 // 25: jurassicpark.v1.CageEvent
+	(*ListDinosaursRequest)(nil),      // 26: jurassicpark.v1.ListDinosaursRequest
+	(*ListDinosaursResponse)(nil),     // 27: jurassicpark.v1.ListDinosaursResponse
+	(*GetDinosaurRequest)(nil),        // 28: jurassicpark.v1.GetDinosaurRequest
+	(*GetDinosaurResponse)(nil),       // 29: jurassicpark.v1.GetDinosaurResponse
+	(*NewDinosaur)(nil),               // 30: jurassicpark.v1.NewDinosaur
+	(*AddDinosaurRequest)(nil),        // 31: jurassicpark.v1.AddDinosaurRequest
+	(*PlacementCheck)(nil),            // 32: jurassicpark.v1.PlacementCheck
+	(*AddDinosaurResponse)(nil),       // 33: jurassicpark.v1.AddDinosaurResponse
+	(*AddDinosaursRequest)(nil),       // 34: jurassicpark.v1.AddDinosaursRequest
+	(*AddDinosaursResult)(nil),        // 35: jurassicpark.v1.AddDinosaursResult
+	(*AddDinosaursResponse)(nil),      // 36: jurassicpark.v1.AddDinosaursResponse
+	(*UpdateDinosaurRequest)(nil),     // 37: jurassicpark.v1.UpdateDinosaurRequest
+	(*UpdateDinosaurResponse)(nil),    // 38: jurassicpark.v1.UpdateDinosaurResponse
+	(*RemoveDinosaurRequest)(nil),     // 39: jurassicpark.v1.RemoveDinosaurRequest
+	(*RemoveDinosaurResponse)(nil),    // 40: jurassicpark.v1.RemoveDinosaurResponse
+	(*RestoreDinosaurRequest)(nil),    // 41: jurassicpark.v1.RestoreDinosaurRequest
+	(*RestoreDinosaurResponse)(nil),   // 42: jurassicpark.v1.RestoreDinosaurResponse
+	(*GetDinosaurHistoryRequest)(nil), // 43: jurassicpark.v1.GetDinosaurHistoryRequest
+	(*GetCompatibilityRequest)(nil),   // 44: jurassicpark.v1.GetCompatibilityRequest
+	(*GetCompatibilityResponse)(nil),  // 45: jurassicpark.v1.GetCompatibilityResponse
+	(*Move)(nil),                      // 46: jurassicpark.v1.Move
+	(*MoveDinosaursRequest)(nil),      // 47: jurassicpark.v1.MoveDinosaursRequest
+	(*MoveDinosaursResponse)(nil),     // 48: jurassicpark.v1.MoveDinosaursResponse
+	(*timestamppb.Timestamp)(nil),     // 49: google.protobuf.Timestamp
+}
+var file_jurassicpark_v1_park_proto_depIdxs = []int32{
+	0,  // 0: jurassicpark.v1.Cage.power_status:type_name -> jurassicpark.v1.PowerStatus
+	7,  // 1: jurassicpark.v1.Cage.dinosaurs:type_name -> jurassicpark.v1.Dinosaur
+	49, // 2: jurassicpark.v1.Cage.created_at:type_name -> google.protobuf.Timestamp
+	49, // 3: jurassicpark.v1.Cage.updated_at:type_name -> google.protobuf.Timestamp
+	49, // 4: jurassicpark.v1.Cage.deleted_at:type_name -> google.protobuf.Timestamp
+	2,  // 5: jurassicpark.v1.Dinosaur.species:type_name -> jurassicpark.v1.Species
+	1,  // 6: jurassicpark.v1.Dinosaur.type:type_name -> jurassicpark.v1.DinosaurType
+	6,  // 7: jurassicpark.v1.Dinosaur.cage:type_name -> jurassicpark.v1.Cage
+	49, // 8: jurassicpark.v1.Dinosaur.created_at:type_name -> google.protobuf.Timestamp
+	49, // 9: jurassicpark.v1.Dinosaur.updated_at:type_name -> google.protobuf.Timestamp
+	49, // 10: jurassicpark.v1.Dinosaur.deleted_at:type_name -> google.protobuf.Timestamp
+	49, // 11: jurassicpark.v1.HistoryEntry.recorded_at:type_name -> google.protobuf.Timestamp
+	0,  // 12: jurassicpark.v1.ListCagesRequest.power_status:type_name -> jurassicpark.v1.PowerStatus
+	6,  // 13: jurassicpark.v1.ListCagesResponse.cages:type_name -> jurassicpark.v1.Cage
+	6,  // 14: jurassicpark.v1.GetCageResponse.cage:type_name -> jurassicpark.v1.Cage
+	0,  // 15: jurassicpark.v1.CreateCageRequest.power_status:type_name -> jurassicpark.v1.PowerStatus
+	6,  // 16: jurassicpark.v1.CreateCageResponse.cage:type_name -> jurassicpark.v1.Cage
+	0,  // 17: jurassicpark.v1.UpdateCageRequest.power_status:type_name -> jurassicpark.v1.PowerStatus
+	6,  // 18: jurassicpark.v1.UpdateCageResponse.cage:type_name -> jurassicpark.v1.Cage
+	6,  // 19: jurassicpark.v1.RestoreCageResponse.cage:type_name -> jurassicpark.v1.Cage
+	8,  // 20: jurassicpark.v1.GetHistoryResponse.history:type_name -> jurassicpark.v1.HistoryEntry
+	4,  // 21: jurassicpark.v1.CageEvent.type:type_name -> jurassicpark.v1.CageEvent.Type
+	6,  // 22: jurassicpark.v1.CageEvent.cage:type_name -> jurassicpark.v1.Cage
+	2,  // 23: jurassicpark.v1.ListDinosaursRequest.species:type_name -> jurassicpark.v1.Species
+	1,  // 24: jurassicpark.v1.ListDinosaursRequest.type:type_name -> jurassicpark.v1.DinosaurType
+	7,  // 25: jurassicpark.v1.ListDinosaursResponse.dinosaurs:type_name -> jurassicpark.v1.Dinosaur
+	7,  // 26: jurassicpark.v1.GetDinosaurResponse.dinosaur:type_name -> jurassicpark.v1.Dinosaur
+	2,  // 27: jurassicpark.v1.NewDinosaur.species:type_name -> jurassicpark.v1.Species
+	30, // 28: jurassicpark.v1.AddDinosaurRequest.dinosaur:type_name -> jurassicpark.v1.NewDinosaur
+	9,  // 29: jurassicpark.v1.PlacementCheck.violations:type_name -> jurassicpark.v1.Violation
+	7,  // 30: jurassicpark.v1.AddDinosaurResponse.dinosaur:type_name -> jurassicpark.v1.Dinosaur
+	32, // 31: jurassicpark.v1.AddDinosaurResponse.check:type_name -> jurassicpark.v1.PlacementCheck
+	30, // 32: jurassicpark.v1.AddDinosaursRequest.dinosaurs:type_name -> jurassicpark.v1.NewDinosaur
+	3,  // 33: jurassicpark.v1.AddDinosaursRequest.mode:type_name -> jurassicpark.v1.BatchMode
+	5,  // 34: jurassicpark.v1.AddDinosaursResult.status:type_name -> jurassicpark.v1.AddDinosaursResult.Status
+	7,  // 35: jurassicpark.v1.AddDinosaursResult.dinosaur:type_name -> jurassicpark.v1.Dinosaur
+	35, // 36: jurassicpark.v1.AddDinosaursResponse.results:type_name -> jurassicpark.v1.AddDinosaursResult
+	2,  // 37: jurassicpark.v1.UpdateDinosaurRequest.species:type_name -> jurassicpark.v1.Species
+	7,  // 38: jurassicpark.v1.UpdateDinosaurResponse.dinosaur:type_name -> jurassicpark.v1.Dinosaur
+	32, // 39: jurassicpark.v1.UpdateDinosaurResponse.check:type_name -> jurassicpark.v1.PlacementCheck
+	7,  // 40: jurassicpark.v1.RestoreDinosaurResponse.dinosaur:type_name -> jurassicpark.v1.Dinosaur
+	2,  // 41: jurassicpark.v1.GetCompatibilityRequest.species:type_name -> jurassicpark.v1.Species
+	9,  // 42: jurassicpark.v1.GetCompatibilityResponse.violations:type_name -> jurassicpark.v1.Violation
+	46, // 43: jurassicpark.v1.MoveDinosaursRequest.moves:type_name -> jurassicpark.v1.Move
+	7,  // 44: jurassicpark.v1.MoveDinosaursResponse.dinosaurs:type_name -> jurassicpark.v1.Dinosaur
+	10, // 45: jurassicpark.v1.CageService.ListCages:input_type -> jurassicpark.v1.ListCagesRequest
+	12, // 46: jurassicpark.v1.CageService.GetCage:input_type -> jurassicpark.v1.GetCageRequest
+	14, // 47: jurassicpark.v1.CageService.CreateCage:input_type -> jurassicpark.v1.CreateCageRequest
+	16, // 48: jurassicpark.v1.CageService.UpdateCage:input_type -> jurassicpark.v1.UpdateCageRequest
+	18, // 49: jurassicpark.v1.CageService.DeleteCage:input_type -> jurassicpark.v1.DeleteCageRequest
+	20, // 50: jurassicpark.v1.CageService.RestoreCage:input_type -> jurassicpark.v1.RestoreCageRequest
+	22, // 51: jurassicpark.v1.CageService.GetCageHistory:input_type -> jurassicpark.v1.GetCageHistoryRequest
+	24, // 52: jurassicpark.v1.CageService.WatchCages:input_type -> jurassicpark.v1.WatchCagesRequest
+	26, // 53: jurassicpark.v1.DinosaurService.ListDinosaurs:input_type -> jurassicpark.v1.ListDinosaursRequest
+	28, // 54: jurassicpark.v1.DinosaurService.GetDinosaur:input_type -> jurassicpark.v1.GetDinosaurRequest
+	31, // 55: jurassicpark.v1.DinosaurService.AddDinosaur:input_type -> jurassicpark.v1.AddDinosaurRequest
+	34, // 56: jurassicpark.v1.DinosaurService.AddDinosaurs:input_type -> jurassicpark.v1.AddDinosaursRequest
+	37, // 57: jurassicpark.v1.DinosaurService.UpdateDinosaur:input_type -> jurassicpark.v1.UpdateDinosaurRequest
+	39, // 58: jurassicpark.v1.DinosaurService.RemoveDinosaur:input_type -> jurassicpark.v1.RemoveDinosaurRequest
+	41, // 59: jurassicpark.v1.DinosaurService.RestoreDinosaur:input_type -> jurassicpark.v1.RestoreDinosaurRequest
+	43, // 60: jurassicpark.v1.DinosaurService.GetDinosaurHistory:input_type -> jurassicpark.v1.GetDinosaurHistoryRequest
+	44, // 61: jurassicpark.v1.DinosaurService.GetCompatibility:input_type -> jurassicpark.v1.GetCompatibilityRequest
+	47, // 62: jurassicpark.v1.DinosaurService.MoveDinosaurs:input_type -> jurassicpark.v1.MoveDinosaursRequest
+	11, // 63: jurassicpark.v1.CageService.ListCages:output_type -> jurassicpark.v1.ListCagesResponse
+	13, // 64: jurassicpark.v1.CageService.GetCage:output_type -> jurassicpark.v1.GetCageResponse
+	15, // 65: jurassicpark.v1.CageService.CreateCage:output_type -> jurassicpark.v1.CreateCageResponse
+	17, // 66: jurassicpark.v1.CageService.UpdateCage:output_type -> jurassicpark.v1.UpdateCageResponse
+	19, // 67: jurassicpark.v1.CageService.DeleteCage:output_type -> jurassicpark.v1.DeleteCageResponse
+	21, // 68: jurassicpark.v1.CageService.RestoreCage:output_type -> jurassicpark.v1.RestoreCageResponse
+	23, // 69: jurassicpark.v1.CageService.GetCageHistory:output_type -> jurassicpark.v1.GetHistoryResponse
+	25, // 70: jurassicpark.v1.CageService.WatchCages:output_type -> jurassicpark.v1.CageEvent
+	27, // 71: jurassicpark.v1.DinosaurService.ListDinosaurs:output_type -> jurassicpark.v1.ListDinosaursResponse
+	29, // 72: jurassicpark.v1.DinosaurService.GetDinosaur:output_type -> jurassicpark.v1.GetDinosaurResponse
+	33, // 73: jurassicpark.v1.DinosaurService.AddDinosaur:output_type -> jurassicpark.v1.AddDinosaurResponse
+	36, // 74: jurassicpark.v1.DinosaurService.AddDinosaurs:output_type -> jurassicpark.v1.AddDinosaursResponse
+	38, // 75: jurassicpark.v1.DinosaurService.UpdateDinosaur:output_type -> jurassicpark.v1.UpdateDinosaurResponse
+	40, // 76: jurassicpark.v1.DinosaurService.RemoveDinosaur:output_type -> jurassicpark.v1.RemoveDinosaurResponse
+	42, // 77: jurassicpark.v1.DinosaurService.RestoreDinosaur:output_type -> jurassicpark.v1.RestoreDinosaurResponse
+	23, // 78: jurassicpark.v1.DinosaurService.GetDinosaurHistory:output_type -> jurassicpark.v1.GetHistoryResponse
+	45, // 79: jurassicpark.v1.DinosaurService.GetCompatibility:output_type -> jurassicpark.v1.GetCompatibilityResponse
+	48, // 80: jurassicpark.v1.DinosaurService.MoveDinosaurs:output_type -> jurassicpark.v1.MoveDinosaursResponse
+	63, // [63:81] is the sub-list for method output_type
+	45, // [45:63] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
+}
+
+func init() { file_jurassicpark_v1_park_proto_init() }
+func file_jurassicpark_v1_park_proto_init() {
+	if File_jurassicpark_v1_park_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_jurassicpark_v1_park_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Cage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Dinosaur); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistoryEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Violation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCagesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCagesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateCageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateCageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateCageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateCageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteCageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreCageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreCageResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCageHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchCagesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CageEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDinosaursRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListDinosaursResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDinosaurRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDinosaurResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NewDinosaur); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddDinosaurRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PlacementCheck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddDinosaurResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddDinosaursRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddDinosaursResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddDinosaursResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDinosaurRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateDinosaurResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveDinosaurRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveDinosaurResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreDinosaurRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreDinosaurResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetDinosaurHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCompatibilityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCompatibilityResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Move); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveDinosaursRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jurassicpark_v1_park_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveDinosaursResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_jurassicpark_v1_park_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_jurassicpark_v1_park_proto_msgTypes[5].OneofWrappers = []interface{}{}
+	file_jurassicpark_v1_park_proto_msgTypes[10].OneofWrappers = []interface{}{}
+	file_jurassicpark_v1_park_proto_msgTypes[21].OneofWrappers = []interface{}{}
+	file_jurassicpark_v1_park_proto_msgTypes[31].OneofWrappers = []interface{}{}
+	file_jurassicpark_v1_park_proto_msgTypes[38].OneofWrappers = []interface{}{
+		(*GetCompatibilityRequest_Species)(nil),
+		(*GetCompatibilityRequest_DinosaurId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_jurassicpark_v1_park_proto_rawDesc,
+			NumEnums:      6,
+			NumMessages:   43,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_jurassicpark_v1_park_proto_goTypes,
+		DependencyIndexes: file_jurassicpark_v1_park_proto_depIdxs,
+		EnumInfos:         file_jurassicpark_v1_park_proto_enumTypes,
+		MessageInfos:      file_jurassicpark_v1_park_proto_msgTypes,
+	}.Build()
+	File_jurassicpark_v1_park_proto = out.File
+	file_jurassicpark_v1_park_proto_rawDesc = nil
+	file_jurassicpark_v1_park_proto_goTypes = nil
+	file_jurassicpark_v1_park_proto_depIdxs = nil
+}
@@ -0,0 +1,855 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: jurassicpark/v1/park.proto
+
+package parkpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	CageService_ListCages_FullMethodName      = "/jurassicpark.v1.CageService/ListCages"
+	CageService_GetCage_FullMethodName        = "/jurassicpark.v1.CageService/GetCage"
+	CageService_CreateCage_FullMethodName     = "/jurassicpark.v1.CageService/CreateCage"
+	CageService_UpdateCage_FullMethodName     = "/jurassicpark.v1.CageService/UpdateCage"
+	CageService_DeleteCage_FullMethodName     = "/jurassicpark.v1.CageService/DeleteCage"
+	CageService_RestoreCage_FullMethodName    = "/jurassicpark.v1.CageService/RestoreCage"
+	CageService_GetCageHistory_FullMethodName = "/jurassicpark.v1.CageService/GetCageHistory"
+	CageService_WatchCages_FullMethodName     = "/jurassicpark.v1.CageService/WatchCages"
+)
+
+// CageServiceClient is the client API for CageService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CageServiceClient interface {
+	// Lists the cages matching all given filters, a page at a time. Mirrors GET /cages.
+	ListCages(ctx context.Context, in *ListCagesRequest, opts ...grpc.CallOption) (*ListCagesResponse, error)
+	// Mirrors GET /cages/:id.
+	GetCage(ctx context.Context, in *GetCageRequest, opts ...grpc.CallOption) (*GetCageResponse, error)
+	// Mirrors POST /cages.
+	CreateCage(ctx context.Context, in *CreateCageRequest, opts ...grpc.CallOption) (*CreateCageResponse, error)
+	// Changes the fields set in the request and keeps all others. Mirrors PATCH /cages/:id.
+	UpdateCage(ctx context.Context, in *UpdateCageRequest, opts ...grpc.CallOption) (*UpdateCageResponse, error)
+	// Mirrors DELETE /cages/:id.
+	DeleteCage(ctx context.Context, in *DeleteCageRequest, opts ...grpc.CallOption) (*DeleteCageResponse, error)
+	// Mirrors POST /cages/:id/restore.
+	RestoreCage(ctx context.Context, in *RestoreCageRequest, opts ...grpc.CallOption) (*RestoreCageResponse, error)
+	// Mirrors GET /cages/:id/history.
+	GetCageHistory(ctx context.Context, in *GetCageHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// Sends the current state of the watched cages, followed by every change to them until the call is cancelled.
+	WatchCages(ctx context.Context, in *WatchCagesRequest, opts ...grpc.CallOption) (CageService_WatchCagesClient, error)
+}
+
+type cageServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCageServiceClient(cc grpc.ClientConnInterface) CageServiceClient {
+	return &cageServiceClient{cc}
+}
+
+func (c *cageServiceClient) ListCages(ctx context.Context, in *ListCagesRequest, opts ...grpc.CallOption) (*ListCagesResponse, error) {
+	out := new(ListCagesResponse)
+	err := c.cc.Invoke(ctx, CageService_ListCages_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cageServiceClient) GetCage(ctx context.Context, in *GetCageRequest, opts ...grpc.CallOption) (*GetCageResponse, error) {
+	out := new(GetCageResponse)
+	err := c.cc.Invoke(ctx, CageService_GetCage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cageServiceClient) CreateCage(ctx context.Context, in *CreateCageRequest, opts ...grpc.CallOption) (*CreateCageResponse, error) {
+	out := new(CreateCageResponse)
+	err := c.cc.Invoke(ctx, CageService_CreateCage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cageServiceClient) UpdateCage(ctx context.Context, in *UpdateCageRequest, opts ...grpc.CallOption) (*UpdateCageResponse, error) {
+	out := new(UpdateCageResponse)
+	err := c.cc.Invoke(ctx, CageService_UpdateCage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cageServiceClient) DeleteCage(ctx context.Context, in *DeleteCageRequest, opts ...grpc.CallOption) (*DeleteCageResponse, error) {
+	out := new(DeleteCageResponse)
+	err := c.cc.Invoke(ctx, CageService_DeleteCage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cageServiceClient) RestoreCage(ctx context.Context, in *RestoreCageRequest, opts ...grpc.CallOption) (*RestoreCageResponse, error) {
+	out := new(RestoreCageResponse)
+	err := c.cc.Invoke(ctx, CageService_RestoreCage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cageServiceClient) GetCageHistory(ctx context.Context, in *GetCageHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, CageService_GetCageHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *cageServiceClient) WatchCages(ctx context.Context, in *WatchCagesRequest, opts ...grpc.CallOption) (CageService_WatchCagesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &CageService_ServiceDesc.Streams[0], CageService_WatchCages_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &cageServiceWatchCagesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type CageService_WatchCagesClient interface {
+	Recv() (*CageEvent, error)
+	grpc.ClientStream
+}
+
+type cageServiceWatchCagesClient struct {
+	grpc.ClientStream
+}
+
+func (x *cageServiceWatchCagesClient) Recv() (*CageEvent, error) {
+	m := new(CageEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// CageServiceServer is the server API for CageService service.
+// All implementations must embed UnimplementedCageServiceServer
+// for forward compatibility
+type CageServiceServer interface {
+	// Lists the cages matching all given filters, a page at a time. Mirrors GET /cages.
+	ListCages(context.Context, *ListCagesRequest) (*ListCagesResponse, error)
+	// Mirrors GET /cages/:id.
+	GetCage(context.Context, *GetCageRequest) (*GetCageResponse, error)
+	// Mirrors POST /cages.
+	CreateCage(context.Context, *CreateCageRequest) (*CreateCageResponse, error)
+	// Changes the fields set in the request and keeps all others. Mirrors PATCH /cages/:id.
+	UpdateCage(context.Context, *UpdateCageRequest) (*UpdateCageResponse, error)
+	// Mirrors DELETE /cages/:id.
+	DeleteCage(context.Context, *DeleteCageRequest) (*DeleteCageResponse, error)
+	// Mirrors POST /cages/:id/restore.
+	RestoreCage(context.Context, *RestoreCageRequest) (*RestoreCageResponse, error)
+	// Mirrors GET /cages/:id/history.
+	GetCageHistory(context.Context, *GetCageHistoryRequest) (*GetHistoryResponse, error)
+	// Sends the current state of the watched cages, followed by every change to them until the call is cancelled.
+	WatchCages(*WatchCagesRequest, CageService_WatchCagesServer) error
+	mustEmbedUnimplementedCageServiceServer()
+}
+
+// UnimplementedCageServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCageServiceServer struct {
+}
+
+func (UnimplementedCageServiceServer) ListCages(context.Context, *ListCagesRequest) (*ListCagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCages not implemented")
+}
+func (UnimplementedCageServiceServer) GetCage(context.Context, *GetCageRequest) (*GetCageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCage not implemented")
+}
+func (UnimplementedCageServiceServer) CreateCage(context.Context, *CreateCageRequest) (*CreateCageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCage not implemented")
+}
+func (UnimplementedCageServiceServer) UpdateCage(context.Context, *UpdateCageRequest) (*UpdateCageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCage not implemented")
+}
+func (UnimplementedCageServiceServer) DeleteCage(context.Context, *DeleteCageRequest) (*DeleteCageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCage not implemented")
+}
+func (UnimplementedCageServiceServer) RestoreCage(context.Context, *RestoreCageRequest) (*RestoreCageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreCage not implemented")
+}
+func (UnimplementedCageServiceServer) GetCageHistory(context.Context, *GetCageHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCageHistory not implemented")
+}
+func (UnimplementedCageServiceServer) WatchCages(*WatchCagesRequest, CageService_WatchCagesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchCages not implemented")
+}
+func (UnimplementedCageServiceServer) mustEmbedUnimplementedCageServiceServer() {}
+
+// UnsafeCageServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CageServiceServer will
+// result in compilation errors.
+type UnsafeCageServiceServer interface {
+	mustEmbedUnimplementedCageServiceServer()
+}
+
+func RegisterCageServiceServer(s grpc.ServiceRegistrar, srv CageServiceServer) {
+	s.RegisterService(&CageService_ServiceDesc, srv)
+}
+
+func _CageService_ListCages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CageServiceServer).ListCages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CageService_ListCages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CageServiceServer).ListCages(ctx, req.(*ListCagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CageService_GetCage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CageServiceServer).GetCage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CageService_GetCage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CageServiceServer).GetCage(ctx, req.(*GetCageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CageService_CreateCage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CageServiceServer).CreateCage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CageService_CreateCage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CageServiceServer).CreateCage(ctx, req.(*CreateCageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CageService_UpdateCage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CageServiceServer).UpdateCage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CageService_UpdateCage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CageServiceServer).UpdateCage(ctx, req.(*UpdateCageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CageService_DeleteCage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CageServiceServer).DeleteCage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CageService_DeleteCage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CageServiceServer).DeleteCage(ctx, req.(*DeleteCageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CageService_RestoreCage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CageServiceServer).RestoreCage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CageService_RestoreCage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CageServiceServer).RestoreCage(ctx, req.(*RestoreCageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CageService_GetCageHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCageHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CageServiceServer).GetCageHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CageService_GetCageHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CageServiceServer).GetCageHistory(ctx, req.(*GetCageHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CageService_WatchCages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchCagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CageServiceServer).WatchCages(m, &cageServiceWatchCagesServer{stream})
+}
+
+type CageService_WatchCagesServer interface {
+	Send(*CageEvent) error
+	grpc.ServerStream
+}
+
+type cageServiceWatchCagesServer struct {
+	grpc.ServerStream
+}
+
+func (x *cageServiceWatchCagesServer) Send(m *CageEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// CageService_ServiceDesc is the grpc.ServiceDesc for CageService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CageService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jurassicpark.v1.CageService",
+	HandlerType: (*CageServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListCages",
+			Handler:    _CageService_ListCages_Handler,
+		},
+		{
+			MethodName: "GetCage",
+			Handler:    _CageService_GetCage_Handler,
+		},
+		{
+			MethodName: "CreateCage",
+			Handler:    _CageService_CreateCage_Handler,
+		},
+		{
+			MethodName: "UpdateCage",
+			Handler:    _CageService_UpdateCage_Handler,
+		},
+		{
+			MethodName: "DeleteCage",
+			Handler:    _CageService_DeleteCage_Handler,
+		},
+		{
+			MethodName: "RestoreCage",
+			Handler:    _CageService_RestoreCage_Handler,
+		},
+		{
+			MethodName: "GetCageHistory",
+			Handler:    _CageService_GetCageHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchCages",
+			Handler:       _CageService_WatchCages_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "jurassicpark/v1/park.proto",
+}
+
+const (
+	DinosaurService_ListDinosaurs_FullMethodName      = "/jurassicpark.v1.DinosaurService/ListDinosaurs"
+	DinosaurService_GetDinosaur_FullMethodName        = "/jurassicpark.v1.DinosaurService/GetDinosaur"
+	DinosaurService_AddDinosaur_FullMethodName        = "/jurassicpark.v1.DinosaurService/AddDinosaur"
+	DinosaurService_AddDinosaurs_FullMethodName       = "/jurassicpark.v1.DinosaurService/AddDinosaurs"
+	DinosaurService_UpdateDinosaur_FullMethodName     = "/jurassicpark.v1.DinosaurService/UpdateDinosaur"
+	DinosaurService_RemoveDinosaur_FullMethodName     = "/jurassicpark.v1.DinosaurService/RemoveDinosaur"
+	DinosaurService_RestoreDinosaur_FullMethodName    = "/jurassicpark.v1.DinosaurService/RestoreDinosaur"
+	DinosaurService_GetDinosaurHistory_FullMethodName = "/jurassicpark.v1.DinosaurService/GetDinosaurHistory"
+	DinosaurService_GetCompatibility_FullMethodName   = "/jurassicpark.v1.DinosaurService/GetCompatibility"
+	DinosaurService_MoveDinosaurs_FullMethodName      = "/jurassicpark.v1.DinosaurService/MoveDinosaurs"
+)
+
+// DinosaurServiceClient is the client API for DinosaurService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DinosaurServiceClient interface {
+	// Lists the dinosaurs matching all given filters, a page at a time. Mirrors GET /dinosaurs.
+	ListDinosaurs(ctx context.Context, in *ListDinosaursRequest, opts ...grpc.CallOption) (*ListDinosaursResponse, error)
+	// Mirrors GET /dinosaurs/:id.
+	GetDinosaur(ctx context.Context, in *GetDinosaurRequest, opts ...grpc.CallOption) (*GetDinosaurResponse, error)
+	// Mirrors POST /dinosaurs.
+	AddDinosaur(ctx context.Context, in *AddDinosaurRequest, opts ...grpc.CallOption) (*AddDinosaurResponse, error)
+	// Mirrors POST /dinosaurs/batch. A rejected all-or-nothing batch succeeds with every result reported.
+	AddDinosaurs(ctx context.Context, in *AddDinosaursRequest, opts ...grpc.CallOption) (*AddDinosaursResponse, error)
+	// Changes the fields set in the request and keeps all others. Mirrors PATCH /dinosaurs/:id.
+	UpdateDinosaur(ctx context.Context, in *UpdateDinosaurRequest, opts ...grpc.CallOption) (*UpdateDinosaurResponse, error)
+	// Mirrors DELETE /dinosaurs/:id.
+	RemoveDinosaur(ctx context.Context, in *RemoveDinosaurRequest, opts ...grpc.CallOption) (*RemoveDinosaurResponse, error)
+	// Mirrors POST /dinosaurs/:id/restore.
+	RestoreDinosaur(ctx context.Context, in *RestoreDinosaurRequest, opts ...grpc.CallOption) (*RestoreDinosaurResponse, error)
+	// Mirrors GET /dinosaurs/:id/history.
+	GetDinosaurHistory(ctx context.Context, in *GetDinosaurHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
+	// Mirrors GET /cages/:id/compatibility.
+	GetCompatibility(ctx context.Context, in *GetCompatibilityRequest, opts ...grpc.CallOption) (*GetCompatibilityResponse, error)
+	// Mirrors POST /moves.
+	MoveDinosaurs(ctx context.Context, in *MoveDinosaursRequest, opts ...grpc.CallOption) (*MoveDinosaursResponse, error)
+}
+
+type dinosaurServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDinosaurServiceClient(cc grpc.ClientConnInterface) DinosaurServiceClient {
+	return &dinosaurServiceClient{cc}
+}
+
+func (c *dinosaurServiceClient) ListDinosaurs(ctx context.Context, in *ListDinosaursRequest, opts ...grpc.CallOption) (*ListDinosaursResponse, error) {
+	out := new(ListDinosaursResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_ListDinosaurs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaurServiceClient) GetDinosaur(ctx context.Context, in *GetDinosaurRequest, opts ...grpc.CallOption) (*GetDinosaurResponse, error) {
+	out := new(GetDinosaurResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_GetDinosaur_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaurServiceClient) AddDinosaur(ctx context.Context, in *AddDinosaurRequest, opts ...grpc.CallOption) (*AddDinosaurResponse, error) {
+	out := new(AddDinosaurResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_AddDinosaur_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaurServiceClient) AddDinosaurs(ctx context.Context, in *AddDinosaursRequest, opts ...grpc.CallOption) (*AddDinosaursResponse, error) {
+	out := new(AddDinosaursResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_AddDinosaurs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaurServiceClient) UpdateDinosaur(ctx context.Context, in *UpdateDinosaurRequest, opts ...grpc.CallOption) (*UpdateDinosaurResponse, error) {
+	out := new(UpdateDinosaurResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_UpdateDinosaur_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaurServiceClient) RemoveDinosaur(ctx context.Context, in *RemoveDinosaurRequest, opts ...grpc.CallOption) (*RemoveDinosaurResponse, error) {
+	out := new(RemoveDinosaurResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_RemoveDinosaur_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaurServiceClient) RestoreDinosaur(ctx context.Context, in *RestoreDinosaurRequest, opts ...grpc.CallOption) (*RestoreDinosaurResponse, error) {
+	out := new(RestoreDinosaurResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_RestoreDinosaur_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaurServiceClient) GetDinosaurHistory(ctx context.Context, in *GetDinosaurHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error) {
+	out := new(GetHistoryResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_GetDinosaurHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaurServiceClient) GetCompatibility(ctx context.Context, in *GetCompatibilityRequest, opts ...grpc.CallOption) (*GetCompatibilityResponse, error) {
+	out := new(GetCompatibilityResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_GetCompatibility_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dinosaurServiceClient) MoveDinosaurs(ctx context.Context, in *MoveDinosaursRequest, opts ...grpc.CallOption) (*MoveDinosaursResponse, error) {
+	out := new(MoveDinosaursResponse)
+	err := c.cc.Invoke(ctx, DinosaurService_MoveDinosaurs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DinosaurServiceServer is the server API for DinosaurService service.
+// All implementations must embed UnimplementedDinosaurServiceServer
+// for forward compatibility
+type DinosaurServiceServer interface {
+	// Lists the dinosaurs matching all given filters, a page at a time. Mirrors GET /dinosaurs.
+	ListDinosaurs(context.Context, *ListDinosaursRequest) (*ListDinosaursResponse, error)
+	// Mirrors GET /dinosaurs/:id.
+	GetDinosaur(context.Context, *GetDinosaurRequest) (*GetDinosaurResponse, error)
+	// Mirrors POST /dinosaurs.
+	AddDinosaur(context.Context, *AddDinosaurRequest) (*AddDinosaurResponse, error)
+	// Mirrors POST /dinosaurs/batch. A rejected all-or-nothing batch succeeds with every result reported.
+	AddDinosaurs(context.Context, *AddDinosaursRequest) (*AddDinosaursResponse, error)
+	// Changes the fields set in the request and keeps all others. Mirrors PATCH /dinosaurs/:id.
+	UpdateDinosaur(context.Context, *UpdateDinosaurRequest) (*UpdateDinosaurResponse, error)
+	// Mirrors DELETE /dinosaurs/:id.
+	RemoveDinosaur(context.Context, *RemoveDinosaurRequest) (*RemoveDinosaurResponse, error)
+	// Mirrors POST /dinosaurs/:id/restore.
+	RestoreDinosaur(context.Context, *RestoreDinosaurRequest) (*RestoreDinosaurResponse, error)
+	// Mirrors GET /dinosaurs/:id/history.
+	GetDinosaurHistory(context.Context, *GetDinosaurHistoryRequest) (*GetHistoryResponse, error)
+	// Mirrors GET /cages/:id/compatibility.
+	GetCompatibility(context.Context, *GetCompatibilityRequest) (*GetCompatibilityResponse, error)
+	// Mirrors POST /moves.
+	MoveDinosaurs(context.Context, *MoveDinosaursRequest) (*MoveDinosaursResponse, error)
+	mustEmbedUnimplementedDinosaurServiceServer()
+}
+
+// UnimplementedDinosaurServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedDinosaurServiceServer struct {
+}
+
+func (UnimplementedDinosaurServiceServer) ListDinosaurs(context.Context, *ListDinosaursRequest) (*ListDinosaursResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDinosaurs not implemented")
+}
+func (UnimplementedDinosaurServiceServer) GetDinosaur(context.Context, *GetDinosaurRequest) (*GetDinosaurResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDinosaur not implemented")
+}
+func (UnimplementedDinosaurServiceServer) AddDinosaur(context.Context, *AddDinosaurRequest) (*AddDinosaurResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDinosaur not implemented")
+}
+func (UnimplementedDinosaurServiceServer) AddDinosaurs(context.Context, *AddDinosaursRequest) (*AddDinosaursResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDinosaurs not implemented")
+}
+func (UnimplementedDinosaurServiceServer) UpdateDinosaur(context.Context, *UpdateDinosaurRequest) (*UpdateDinosaurResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDinosaur not implemented")
+}
+func (UnimplementedDinosaurServiceServer) RemoveDinosaur(context.Context, *RemoveDinosaurRequest) (*RemoveDinosaurResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDinosaur not implemented")
+}
+func (UnimplementedDinosaurServiceServer) RestoreDinosaur(context.Context, *RestoreDinosaurRequest) (*RestoreDinosaurResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreDinosaur not implemented")
+}
+func (UnimplementedDinosaurServiceServer) GetDinosaurHistory(context.Context, *GetDinosaurHistoryRequest) (*GetHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDinosaurHistory not implemented")
+}
+func (UnimplementedDinosaurServiceServer) GetCompatibility(context.Context, *GetCompatibilityRequest) (*GetCompatibilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompatibility not implemented")
+}
+func (UnimplementedDinosaurServiceServer) MoveDinosaurs(context.Context, *MoveDinosaursRequest) (*MoveDinosaursResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveDinosaurs not implemented")
+}
+func (UnimplementedDinosaurServiceServer) mustEmbedUnimplementedDinosaurServiceServer() {}
+
+// UnsafeDinosaurServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DinosaurServiceServer will
+// result in compilation errors.
+type UnsafeDinosaurServiceServer interface {
+	mustEmbedUnimplementedDinosaurServiceServer()
+}
+
+func RegisterDinosaurServiceServer(s grpc.ServiceRegistrar, srv DinosaurServiceServer) {
+	s.RegisterService(&DinosaurService_ServiceDesc, srv)
+}
+
+func _DinosaurService_ListDinosaurs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDinosaursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).ListDinosaurs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_ListDinosaurs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).ListDinosaurs(ctx, req.(*ListDinosaursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DinosaurService_GetDinosaur_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDinosaurRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).GetDinosaur(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_GetDinosaur_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).GetDinosaur(ctx, req.(*GetDinosaurRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DinosaurService_AddDinosaur_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDinosaurRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).AddDinosaur(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_AddDinosaur_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).AddDinosaur(ctx, req.(*AddDinosaurRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DinosaurService_AddDinosaurs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDinosaursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).AddDinosaurs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_AddDinosaurs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).AddDinosaurs(ctx, req.(*AddDinosaursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DinosaurService_UpdateDinosaur_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDinosaurRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).UpdateDinosaur(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_UpdateDinosaur_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).UpdateDinosaur(ctx, req.(*UpdateDinosaurRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DinosaurService_RemoveDinosaur_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDinosaurRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).RemoveDinosaur(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_RemoveDinosaur_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).RemoveDinosaur(ctx, req.(*RemoveDinosaurRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DinosaurService_RestoreDinosaur_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreDinosaurRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).RestoreDinosaur(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_RestoreDinosaur_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).RestoreDinosaur(ctx, req.(*RestoreDinosaurRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DinosaurService_GetDinosaurHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDinosaurHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).GetDinosaurHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_GetDinosaurHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).GetDinosaurHistory(ctx, req.(*GetDinosaurHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DinosaurService_GetCompatibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCompatibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).GetCompatibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_GetCompatibility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).GetCompatibility(ctx, req.(*GetCompatibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DinosaurService_MoveDinosaurs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveDinosaursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DinosaurServiceServer).MoveDinosaurs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DinosaurService_MoveDinosaurs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DinosaurServiceServer).MoveDinosaurs(ctx, req.(*MoveDinosaursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// DinosaurService_ServiceDesc is the grpc.ServiceDesc for DinosaurService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var DinosaurService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jurassicpark.v1.DinosaurService",
+	HandlerType: (*DinosaurServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDinosaurs",
+			Handler:    _DinosaurService_ListDinosaurs_Handler,
+		},
+		{
+			MethodName: "GetDinosaur",
+			Handler:    _DinosaurService_GetDinosaur_Handler,
+		},
+		{
+			MethodName: "AddDinosaur",
+			Handler:    _DinosaurService_AddDinosaur_Handler,
+		},
+		{
+			MethodName: "AddDinosaurs",
+			Handler:    _DinosaurService_AddDinosaurs_Handler,
+		},
+		{
+			MethodName: "UpdateDinosaur",
+			Handler:    _DinosaurService_UpdateDinosaur_Handler,
+		},
+		{
+			MethodName: "RemoveDinosaur",
+			Handler:    _DinosaurService_RemoveDinosaur_Handler,
+		},
+		{
+			MethodName: "RestoreDinosaur",
+			Handler:    _DinosaurService_RestoreDinosaur_Handler,
+		},
+		{
+			MethodName: "GetDinosaurHistory",
+			Handler:    _DinosaurService_GetDinosaurHistory_Handler,
+		},
+		{
+			MethodName: "GetCompatibility",
+			Handler:    _DinosaurService_GetCompatibility_Handler,
+		},
+		{
+			MethodName: "MoveDinosaurs",
+			Handler:    _DinosaurService_MoveDinosaurs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "jurassicpark/v1/park.proto",
+}
@@ -0,0 +1,136 @@
+package transform
+
+import (
+	"strings"
+	"time"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/api/parkpb"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func CagesToProto(dbCages []dbmodels.Cage) []*parkpb.Cage {
+	pbCages := []*parkpb.Cage{}
+	for _, dbCage := range dbCages {
+		pbCages = append(pbCages, CageToProto(dbCage))
+	}
+	return pbCages
+}
+
+// CageToProto returns the cage in the shape of the gRPC API. Its dinosaurs are only set if they have been loaded.
+func CageToProto(dbCage dbmodels.Cage) *parkpb.Cage {
+	pbCage := &parkpb.Cage{
+		Id:           uint64(dbCage.ID),
+		Capacity:     int32(dbCage.Capacity),
+		CurrentCount: int32(dbCage.Occupancy),
+		PowerStatus:  PowerStatusToProto(apimodels.PowerStatus(dbCage.PowerStatus)),
+		Version:      uint64(dbCage.Version),
+		CreatedAt:    timeToProto(dbCage.CreatedAt),
+		UpdatedAt:    timeToProto(dbCage.UpdatedAt),
+		DeletedAt:    deletedAtToProto(dbCage.DeletedAt),
+	}
+	if dbCage.Dinosaurs != nil {
+		pbCage.Dinosaurs = DinosaursToProto(dbCage.Dinosaurs)
+	}
+	return pbCage
+}
+
+func DinosaursToProto(dbDinosaurs []dbmodels.Dinosaur) []*parkpb.Dinosaur {
+	pbDinosaurs := []*parkpb.Dinosaur{}
+	for _, dbDinosaur := range dbDinosaurs {
+		pbDinosaurs = append(pbDinosaurs, DinosaurToProto(dbDinosaur))
+	}
+	return pbDinosaurs
+}
+
+// DinosaurToProto returns the dinosaur in the shape of the gRPC API. Its cage is only set if it has been loaded.
+func DinosaurToProto(dbDinosaur dbmodels.Dinosaur) *parkpb.Dinosaur {
+	pbDinosaur := &parkpb.Dinosaur{
+		Id:        uint64(dbDinosaur.ID),
+		Name:      dbDinosaur.Name,
+		Species:   SpeciesToProto(apimodels.Species(dbDinosaur.Species)),
+		Type:      DinosaurTypeToProto(apimodels.DinosaurType(dbDinosaur.Type)),
+		CageId:    uint64(dbDinosaur.CageID),
+		Version:   uint64(dbDinosaur.Version),
+		CreatedAt: timeToProto(dbDinosaur.CreatedAt),
+		UpdatedAt: timeToProto(dbDinosaur.UpdatedAt),
+		DeletedAt: deletedAtToProto(dbDinosaur.DeletedAt),
+	}
+	if dbDinosaur.Cage != nil {
+		pbDinosaur.Cage = CageToProto(*dbDinosaur.Cage)
+	}
+	return pbDinosaur
+}
+
+func HistoryToProto(dbEntries []dbmodels.HistoryEntry) []*parkpb.HistoryEntry {
+	pbEntries := []*parkpb.HistoryEntry{}
+	for _, dbEntry := range dbEntries {
+		pbEntries = append(pbEntries, &parkpb.HistoryEntry{
+			Id:         uint64(dbEntry.ID),
+			Action:     dbEntry.Action,
+			Actor:      dbEntry.Actor,
+			RecordedAt: timeToProto(dbEntry.RecordedAt),
+			Before:     dbEntry.Before,
+			After:      dbEntry.After,
+		})
+	}
+	return pbEntries
+}
+
+func PowerStatusToProto(powerStatus apimodels.PowerStatus) parkpb.PowerStatus {
+	return parkpb.PowerStatus(parkpb.PowerStatus_value["POWER_STATUS_"+string(powerStatus)])
+}
+
+// PowerStatusFromProto returns false for POWER_STATUS_UNSPECIFIED and unknown values.
+func PowerStatusFromProto(powerStatus parkpb.PowerStatus) (apimodels.PowerStatus, bool) {
+	value, known := strings.CutPrefix(powerStatus.String(), "POWER_STATUS_")
+	if !known || powerStatus == parkpb.PowerStatus_POWER_STATUS_UNSPECIFIED {
+		return "", false
+	}
+	return apimodels.PowerStatus(value), true
+}
+
+func DinosaurTypeToProto(dinosaurType apimodels.DinosaurType) parkpb.DinosaurType {
+	return parkpb.DinosaurType(parkpb.DinosaurType_value["DINOSAUR_TYPE_"+string(dinosaurType)])
+}
+
+// DinosaurTypeFromProto returns false for DINOSAUR_TYPE_UNSPECIFIED and unknown values.
+func DinosaurTypeFromProto(dinosaurType parkpb.DinosaurType) (apimodels.DinosaurType, bool) {
+	value, known := strings.CutPrefix(dinosaurType.String(), "DINOSAUR_TYPE_")
+	if !known || dinosaurType == parkpb.DinosaurType_DINOSAUR_TYPE_UNSPECIFIED {
+		return "", false
+	}
+	return apimodels.DinosaurType(value), true
+}
+
+// SpeciesToProto returns the enum value of the species, e.g. SPECIES_TYRANNOSAURUS for Tyrannosaurus.
+func SpeciesToProto(species apimodels.Species) parkpb.Species {
+	return parkpb.Species(parkpb.Species_value["SPECIES_"+strings.ToUpper(string(species))])
+}
+
+// SpeciesFromProto returns false for SPECIES_UNSPECIFIED and unknown values.
+func SpeciesFromProto(species parkpb.Species) (apimodels.Species, bool) {
+	for _, known := range apimodels.AllSpecies {
+		if SpeciesToProto(known) == species && species != parkpb.Species_SPECIES_UNSPECIFIED {
+			return known, true
+		}
+	}
+	return "", false
+}
+
+func timeToProto(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func deletedAtToProto(deletedAt gorm.DeletedAt) *timestamppb.Timestamp {
+	if !deletedAt.Valid {
+		return nil
+	}
+	return timestamppb.New(deletedAt.Time)
+}
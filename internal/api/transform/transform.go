@@ -1,8 +1,16 @@
 package transform
 
 import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
 	apimodels "pp-jurassic-park-api/internal/api/models"
 	dbmodels "pp-jurassic-park-api/internal/db/models"
+
+	"gorm.io/gorm"
 )
 
 func CagesToApi(dbCages []dbmodels.Cage) []apimodels.Cage {
@@ -22,8 +30,9 @@ func CageToApi(dbCage dbmodels.Cage) apimodels.Cage {
 		ID:           dbCage.ID,
 		Capacity:     dbCage.Capacity,
 		PowerStatus:  apimodels.PowerStatus(dbCage.PowerStatus),
-		CurrentCount: len(apiDinosaurs),
+		CurrentCount: dbCage.Occupancy,
 		Dinosaurs:    apiDinosaurs,
+		DeletedAt:    deletedAtToApi(dbCage.DeletedAt),
 	}
 }
 
@@ -36,11 +45,145 @@ func DinosaursToApi(dbDinosaurs []dbmodels.Dinosaur) []apimodels.Dinosaur {
 }
 
 func DinosaurToApi(dbDinosaur dbmodels.Dinosaur) apimodels.Dinosaur {
-	return apimodels.Dinosaur{
+	apiDinosaur := apimodels.Dinosaur{
+		ID:        dbDinosaur.ID,
+		Name:      dbDinosaur.Name,
+		Species:   apimodels.Species(dbDinosaur.Species),
+		Type:      apimodels.DinosaurType(dbDinosaur.Type),
+		CageID:    dbDinosaur.CageID,
+		DeletedAt: deletedAtToApi(dbDinosaur.DeletedAt),
+	}
+	if dbDinosaur.Cage != nil {
+		apiCage := CageToApi(*dbDinosaur.Cage)
+		apiDinosaur.Cage = &apiCage
+	}
+	return apiDinosaur
+}
+
+func CageSummariesToApi(dbCages []dbmodels.Cage) []apimodels.CageSummary {
+	apiCages := []apimodels.CageSummary{}
+	for _, dbCage := range dbCages {
+		apiCages = append(apiCages, CageSummaryToApi(dbCage))
+	}
+	return apiCages
+}
+
+// CageSummaryToApi returns the cage in the shape of the v2 API, linking to its dinosaurs.
+// The dinosaurs are embedded as well if they have been loaded.
+func CageSummaryToApi(dbCage dbmodels.Cage) apimodels.CageSummary {
+	apiCage := apimodels.CageSummary{
+		ID:           dbCage.ID,
+		Capacity:     dbCage.Capacity,
+		CurrentCount: dbCage.Occupancy,
+		PowerStatus:  apimodels.PowerStatus(dbCage.PowerStatus),
+		DeletedAt:    deletedAtToApi(dbCage.DeletedAt),
+		Links: apimodels.CageLinks{
+			Self:      fmt.Sprintf("/v2/cages/%d", dbCage.ID),
+			Dinosaurs: fmt.Sprintf("/v2/dinosaurs?cage_id=%d", dbCage.ID),
+		},
+	}
+	if dbCage.Dinosaurs != nil {
+		apiDinosaurs := DinosaurSummariesToApi(dbCage.Dinosaurs)
+		apiCage.Dinosaurs = &apiDinosaurs
+	}
+	return apiCage
+}
+
+func DinosaurSummariesToApi(dbDinosaurs []dbmodels.Dinosaur) []apimodels.DinosaurSummary {
+	apiDinosaurs := []apimodels.DinosaurSummary{}
+	for _, dbDinosaur := range dbDinosaurs {
+		apiDinosaurs = append(apiDinosaurs, DinosaurSummaryToApi(dbDinosaur))
+	}
+	return apiDinosaurs
+}
+
+// DinosaurSummaryToApi returns the dinosaur in the shape of the v2 API, linking to its cage.
+// The cage is embedded as well if it has been loaded.
+func DinosaurSummaryToApi(dbDinosaur dbmodels.Dinosaur) apimodels.DinosaurSummary {
+	apiDinosaur := apimodels.DinosaurSummary{
+		ID:        dbDinosaur.ID,
+		Name:      dbDinosaur.Name,
+		Species:   apimodels.Species(dbDinosaur.Species),
+		Type:      apimodels.DinosaurType(dbDinosaur.Type),
+		CageID:    dbDinosaur.CageID,
+		DeletedAt: deletedAtToApi(dbDinosaur.DeletedAt),
+		Links: apimodels.DinosaurLinks{
+			Self: fmt.Sprintf("/v2/dinosaurs/%d", dbDinosaur.ID),
+			Cage: fmt.Sprintf("/v2/cages/%d", dbDinosaur.CageID),
+		},
+	}
+	if dbDinosaur.Cage != nil {
+		apiCage := CageSummaryToApi(*dbDinosaur.Cage)
+		apiDinosaur.Cage = &apiCage
+	}
+	return apiDinosaur
+}
+
+// CageToInventory returns the cage as a record of the park inventory.
+func CageToInventory(dbCage dbmodels.Cage) apimodels.InventoryRecord {
+	return apimodels.InventoryRecord{
+		Kind:        apimodels.InventoryCage,
+		ID:          dbCage.ID,
+		Capacity:    dbCage.Capacity,
+		PowerStatus: apimodels.PowerStatus(dbCage.PowerStatus),
+		CageRef:     strconv.FormatUint(uint64(dbCage.ID), 10),
+	}
+}
+
+// DinosaurToInventory returns the dinosaur as a record of the park inventory.
+func DinosaurToInventory(dbDinosaur dbmodels.Dinosaur) apimodels.InventoryRecord {
+	return apimodels.InventoryRecord{
+		Kind:    apimodels.InventoryDinosaur,
 		ID:      dbDinosaur.ID,
 		Name:    dbDinosaur.Name,
-		Species: apimodels.Species(dbDinosaur.Species),
+		Species: dbDinosaur.Species,
 		Type:    apimodels.DinosaurType(dbDinosaur.Type),
 		CageID:  dbDinosaur.CageID,
+		CageRef: strconv.FormatUint(uint64(dbDinosaur.CageID), 10),
+	}
+}
+
+func deletedAtToApi(deletedAt gorm.DeletedAt) *time.Time {
+	if !deletedAt.Valid {
+		return nil
+	}
+	return &deletedAt.Time
+}
+
+func HistoryToApi(dbEntries []dbmodels.HistoryEntry) []apimodels.HistoryEntry {
+	apiEntries := []apimodels.HistoryEntry{}
+	for _, dbEntry := range dbEntries {
+		apiEntries = append(apiEntries, HistoryEntryToApi(dbEntry))
+	}
+	return apiEntries
+}
+
+func HistoryEntryToApi(dbEntry dbmodels.HistoryEntry) apimodels.HistoryEntry {
+	apiEntry := apimodels.HistoryEntry{
+		ID:         dbEntry.ID,
+		Action:     apimodels.HistoryAction(dbEntry.Action),
+		Actor:      dbEntry.Actor,
+		RecordedAt: dbEntry.RecordedAt,
+	}
+	if dbEntry.Before != "" {
+		apiEntry.Before = json.RawMessage(dbEntry.Before)
+	}
+	if dbEntry.After != "" {
+		apiEntry.After = json.RawMessage(dbEntry.After)
+	}
+	return apiEntry
+}
+
+func DBStatsToApi(stats sql.DBStats) apimodels.DBStats {
+	return apimodels.DBStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
 	}
 }
@@ -0,0 +1,347 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds all settings of the API server.
+type Config struct {
+	Server   Server   `json:"server"`
+	GRPC     GRPC     `json:"grpc"`
+	Database Database `json:"database"`
+	Log      Log      `json:"log"`
+	Features Features `json:"features"`
+
+	// unknownKeys are the keys of the config file which are not settings, reported by Validate.
+	unknownKeys []string
+}
+
+type Server struct {
+	Addr            string   `json:"addr"`
+	ReadTimeout     Duration `json:"read_timeout"`
+	WriteTimeout    Duration `json:"write_timeout"`
+	IdleTimeout     Duration `json:"idle_timeout"`
+	ShutdownTimeout Duration `json:"shutdown_timeout"`
+}
+
+// GRPC holds the settings of the gRPC server, which runs beside the HTTP server.
+type GRPC struct {
+	Addr string `json:"addr"`
+	// WatchInterval is how often cages watched through WatchCages are checked for changes.
+	WatchInterval Duration `json:"watch_interval"`
+}
+
+type Database struct {
+	Driver          string   `json:"driver"`
+	Path            string   `json:"path"`
+	Host            string   `json:"host"`
+	Port            int      `json:"port"`
+	User            string   `json:"user"`
+	Password        string   `json:"password"`
+	Name            string   `json:"name"`
+	SSLMode         string   `json:"sslmode"`
+	TimeZone        string   `json:"timezone"`
+	MaxOpenConns    int      `json:"max_open_conns"`
+	MaxIdleConns    int      `json:"max_idle_conns"`
+	ConnMaxLifetime Duration `json:"conn_max_lifetime"`
+	ConnMaxIdleTime Duration `json:"conn_max_idle_time"`
+}
+
+type Log struct {
+	Level string `json:"level"`
+}
+
+// Features toggles optional parts of the API.
+type Features struct {
+	DebugEndpoints bool `json:"debug_endpoints"`
+}
+
+// Duration is a time.Duration read from strings such as "30s" or "5m".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30s\"")
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Supported database drivers. Host, port and credentials apply to Postgres, Path to SQLite.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+var logLevels = []string{"debug", "info", "warn", "error"}
+var drivers = []string{DriverPostgres, DriverSQLite}
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+func Default() Config {
+	return Config{
+		Server: Server{
+			Addr:            ":8080",
+			ReadTimeout:     Duration(10 * time.Second),
+			WriteTimeout:    Duration(10 * time.Second),
+			IdleTimeout:     Duration(60 * time.Second),
+			ShutdownTimeout: Duration(15 * time.Second),
+		},
+		GRPC: GRPC{
+			Addr:          ":9090",
+			WatchInterval: Duration(time.Second),
+		},
+		Database: Database{
+			Driver:          DriverPostgres,
+			Host:            "localhost",
+			Port:            5432,
+			SSLMode:         "disable",
+			TimeZone:        "UTC",
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: Duration(30 * time.Minute),
+			ConnMaxIdleTime: Duration(5 * time.Minute),
+		},
+		Log: Log{
+			Level: "info",
+		},
+	}
+}
+
+// Load builds the configuration from defaults, the optional config file, environment variables
+// and command-line flags, in increasing order of precedence. The resulting configuration is validated.
+// Arguments left after the flags, such as subcommands, are returned as they are.
+func Load(args []string) (Config, []string, error) {
+	cfg := Default()
+
+	flagSet := flag.NewFlagSet("api", flag.ContinueOnError)
+	configFile := flagSet.String("config", os.Getenv("CONFIG_FILE"), "path to a JSON config file (env CONFIG_FILE)")
+	flagValues := map[string]string{}
+	for _, s := range settings {
+		name := s.flag
+		usage := fmt.Sprintf("%s (env %s)", s.usage, s.env)
+		if s.isBool {
+			flagSet.BoolFunc(name, usage, func(value string) error { flagValues[name] = value; return nil })
+		} else {
+			flagSet.Func(name, usage, func(value string) error { flagValues[name] = value; return nil })
+		}
+	}
+	if err := flagSet.Parse(args); err != nil {
+		return Config{}, nil, err
+	}
+
+	if *configFile != "" {
+		if err := loadFile(*configFile, &cfg); err != nil {
+			return Config{}, nil, err
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.env); ok {
+			if err := s.set(&cfg, value); err != nil {
+				return Config{}, nil, fmt.Errorf("invalid value %q for %s: %w", value, s.env, err)
+			}
+		}
+	}
+
+	for _, s := range settings {
+		if value, ok := flagValues[s.flag]; ok {
+			if err := s.set(&cfg, value); err != nil {
+				return Config{}, nil, fmt.Errorf("invalid value %q for -%s: %w", value, s.flag, err)
+			}
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, nil, err
+	}
+	return cfg, flagSet.Args(), nil
+}
+
+// loadFile reads the config file into the configuration. Keys which are not settings, such as misspelled ones,
+// are left for Validate to report along with the invalid settings; the known settings of the file are read anyway.
+func loadFile(path string, cfg *Config) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(cfg)
+	if err != nil && strings.HasPrefix(err.Error(), "json: unknown field ") {
+		cfg.unknownKeys = unknownKeys(data, reflect.TypeOf(*cfg), "")
+		err = json.Unmarshal(data, cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// unknownKeys returns the keys of the JSON object which are not fields of the struct type, recursing into
+// the fields holding structs, as paths such as "server.read_timout". Keys match fields regardless of case,
+// as they do when decoding.
+func unknownKeys(data []byte, t reflect.Type, prefix string) []string {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil
+	}
+
+	var unknown []string
+	for key, value := range object {
+		field, found := jsonField(t, key)
+		switch {
+		case !found:
+			unknown = append(unknown, prefix+key)
+		case field.Type.Kind() == reflect.Struct:
+			unknown = append(unknown, unknownKeys(value, field.Type, prefix+key+".")...)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
+
+// jsonField returns the exported field of the struct type decoded from the JSON key.
+func jsonField(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if field.IsExported() && strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// Validate reports every invalid setting at once.
+func (cfg Config) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	for _, key := range cfg.unknownKeys {
+		invalid("%s is not a known setting", key)
+	}
+
+	if _, _, err := net.SplitHostPort(cfg.Server.Addr); err != nil {
+		invalid("server.addr must be host:port, got %q", cfg.Server.Addr)
+	}
+	if cfg.Server.ReadTimeout <= 0 {
+		invalid("server.read_timeout must be greater than 0")
+	}
+	if cfg.Server.WriteTimeout <= 0 {
+		invalid("server.write_timeout must be greater than 0")
+	}
+	if cfg.Server.IdleTimeout < 0 {
+		invalid("server.idle_timeout cannot be negative")
+	}
+	if cfg.Server.ShutdownTimeout < 0 {
+		invalid("server.shutdown_timeout cannot be negative")
+	}
+
+	if _, _, err := net.SplitHostPort(cfg.GRPC.Addr); err != nil {
+		invalid("grpc.addr must be host:port, got %q", cfg.GRPC.Addr)
+	}
+	if cfg.GRPC.WatchInterval <= 0 {
+		invalid("grpc.watch_interval must be greater than 0")
+	}
+
+	switch cfg.Database.Driver {
+	case DriverPostgres:
+		if cfg.Database.Host == "" {
+			invalid("database.host must be set")
+		}
+		if cfg.Database.Port <= 0 || cfg.Database.Port > 65535 {
+			invalid("database.port must be between 1 and 65535, got %d", cfg.Database.Port)
+		}
+		if cfg.Database.User == "" {
+			invalid("database.user must be set")
+		}
+		if cfg.Database.Name == "" {
+			invalid("database.name must be set")
+		}
+		if !slices.Contains(sslModes, cfg.Database.SSLMode) {
+			invalid("database.sslmode must be one of %v, got %q", sslModes, cfg.Database.SSLMode)
+		}
+		if _, err := time.LoadLocation(cfg.Database.TimeZone); err != nil {
+			invalid("database.timezone is not a known time zone: %q", cfg.Database.TimeZone)
+		}
+	case DriverSQLite:
+		if cfg.Database.Path == "" {
+			invalid("database.path must be set for the sqlite driver")
+		}
+	default:
+		invalid("database.driver must be one of %v, got %q", drivers, cfg.Database.Driver)
+	}
+	if cfg.Database.MaxOpenConns < 0 {
+		invalid("database.max_open_conns cannot be negative")
+	}
+	if cfg.Database.MaxIdleConns < 0 {
+		invalid("database.max_idle_conns cannot be negative")
+	}
+	if cfg.Database.MaxOpenConns > 0 && cfg.Database.MaxIdleConns > cfg.Database.MaxOpenConns {
+		invalid("database.max_idle_conns cannot exceed database.max_open_conns")
+	}
+	if cfg.Database.ConnMaxLifetime < 0 {
+		invalid("database.conn_max_lifetime cannot be negative")
+	}
+	if cfg.Database.ConnMaxIdleTime < 0 {
+		invalid("database.conn_max_idle_time cannot be negative")
+	}
+
+	if !slices.Contains(logLevels, cfg.Log.Level) {
+		invalid("log.level must be one of %v, got %q", logLevels, cfg.Log.Level)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid configuration: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// DSN returns the connection string of the database for the configured driver.
+func (d Database) DSN() string {
+	if d.Driver == DriverSQLite {
+		// Immediate transactions take the write lock upfront, which serializes placements
+		// the same way row locks do on Postgres.
+		query := url.Values{}
+		query.Set("_foreign_keys", "on")
+		query.Set("_busy_timeout", "5000")
+		query.Set("_journal_mode", "WAL")
+		query.Set("_txlock", "immediate")
+		return "file:" + d.Path + "?" + query.Encode()
+	}
+
+	dsn := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(d.User, d.Password),
+		Host:   net.JoinHostPort(d.Host, strconv.Itoa(d.Port)),
+		Path:   "/" + d.Name,
+	}
+	query := url.Values{}
+	query.Set("sslmode", d.SSLMode)
+	query.Set("TimeZone", d.TimeZone)
+	dsn.RawQuery = query.Encode()
+	return dsn.String()
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"strconv"
+	"time"
+)
+
+// setting describes a single value that can be overridden through the environment or a command-line flag.
+type setting struct {
+	env    string
+	flag   string
+	usage  string
+	isBool bool
+	set    func(cfg *Config, value string) error
+}
+
+var settings = []setting{
+	{env: "HTTP_ADDR", flag: "http-addr", usage: "address the HTTP server listens on", set: setString(func(cfg *Config) *string { return &cfg.Server.Addr })},
+	{env: "HTTP_READ_TIMEOUT", flag: "http-read-timeout", usage: "maximum duration for reading a request", set: setDuration(func(cfg *Config) *Duration { return &cfg.Server.ReadTimeout })},
+	{env: "HTTP_WRITE_TIMEOUT", flag: "http-write-timeout", usage: "maximum duration for writing a response", set: setDuration(func(cfg *Config) *Duration { return &cfg.Server.WriteTimeout })},
+	{env: "HTTP_IDLE_TIMEOUT", flag: "http-idle-timeout", usage: "maximum duration to keep idle connections open", set: setDuration(func(cfg *Config) *Duration { return &cfg.Server.IdleTimeout })},
+	{env: "HTTP_SHUTDOWN_TIMEOUT", flag: "http-shutdown-timeout", usage: "maximum duration to wait for in-flight requests on shutdown", set: setDuration(func(cfg *Config) *Duration { return &cfg.Server.ShutdownTimeout })},
+
+	{env: "GRPC_ADDR", flag: "grpc-addr", usage: "address the gRPC server listens on", set: setString(func(cfg *Config) *string { return &cfg.GRPC.Addr })},
+	{env: "GRPC_WATCH_INTERVAL", flag: "grpc-watch-interval", usage: "how often watched cages are checked for changes", set: setDuration(func(cfg *Config) *Duration { return &cfg.GRPC.WatchInterval })},
+
+	{env: "DB_DRIVER", flag: "db-driver", usage: "database driver: postgres or sqlite", set: setString(func(cfg *Config) *string { return &cfg.Database.Driver })},
+	{env: "DB_PATH", flag: "db-path", usage: "database file of the sqlite driver", set: setString(func(cfg *Config) *string { return &cfg.Database.Path })},
+	{env: "DB_HOST", flag: "db-host", usage: "database host", set: setString(func(cfg *Config) *string { return &cfg.Database.Host })},
+	{env: "DB_PORT", flag: "db-port", usage: "database port", set: setInt(func(cfg *Config) *int { return &cfg.Database.Port })},
+	{env: "DB_USER", flag: "db-user", usage: "database user", set: setString(func(cfg *Config) *string { return &cfg.Database.User })},
+	{env: "DB_PASSWORD", flag: "db-password", usage: "database password", set: setString(func(cfg *Config) *string { return &cfg.Database.Password })},
+	{env: "DB_NAME", flag: "db-name", usage: "database name", set: setString(func(cfg *Config) *string { return &cfg.Database.Name })},
+	{env: "DB_SSLMODE", flag: "db-sslmode", usage: "database SSL mode", set: setString(func(cfg *Config) *string { return &cfg.Database.SSLMode })},
+	{env: "DB_TIMEZONE", flag: "db-timezone", usage: "database session time zone", set: setString(func(cfg *Config) *string { return &cfg.Database.TimeZone })},
+	{env: "DB_MAX_OPEN_CONNS", flag: "db-max-open-conns", usage: "maximum number of open database connections", set: setInt(func(cfg *Config) *int { return &cfg.Database.MaxOpenConns })},
+	{env: "DB_MAX_IDLE_CONNS", flag: "db-max-idle-conns", usage: "maximum number of idle database connections", set: setInt(func(cfg *Config) *int { return &cfg.Database.MaxIdleConns })},
+	{env: "DB_CONN_MAX_LIFETIME", flag: "db-conn-max-lifetime", usage: "maximum lifetime of a database connection", set: setDuration(func(cfg *Config) *Duration { return &cfg.Database.ConnMaxLifetime })},
+	{env: "DB_CONN_MAX_IDLE_TIME", flag: "db-conn-max-idle-time", usage: "maximum idle time of a database connection", set: setDuration(func(cfg *Config) *Duration { return &cfg.Database.ConnMaxIdleTime })},
+
+	{env: "LOG_LEVEL", flag: "log-level", usage: "log level: debug, info, warn or error", set: setString(func(cfg *Config) *string { return &cfg.Log.Level })},
+
+	{env: "FEATURE_DEBUG_ENDPOINTS", flag: "feature-debug-endpoints", usage: "expose /debug endpoints", isBool: true, set: setBool(func(cfg *Config) *bool { return &cfg.Features.DebugEndpoints })},
+}
+
+func setString(field func(cfg *Config) *string) func(cfg *Config, value string) error {
+	return func(cfg *Config, value string) error {
+		*field(cfg) = value
+		return nil
+	}
+}
+
+func setInt(field func(cfg *Config) *int) func(cfg *Config, value string) error {
+	return func(cfg *Config, value string) error {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field(cfg) = parsed
+		return nil
+	}
+}
+
+func setBool(field func(cfg *Config) *bool) func(cfg *Config, value string) error {
+	return func(cfg *Config, value string) error {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		*field(cfg) = parsed
+		return nil
+	}
+}
+
+func setDuration(field func(cfg *Config) *Duration) func(cfg *Config, value string) error {
+	return func(cfg *Config, value string) error {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*field(cfg) = Duration(parsed)
+		return nil
+	}
+}
@@ -1,28 +1,59 @@
 package db
 
 import (
-	"os"
+	"database/sql"
+	"time"
 
-	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/config"
 
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
-const dsnMain = "host=postgres user=gorm password=gorm dbname=gorm sslmode=disable TimeZone=UTC"
-const dsnTest = "host=postgrestest user=gormtest password=gormtest dbname=gormtest sslmode=disable TimeZone=UTC"
+// Connect opens the database of the configured driver and configures its connection pool.
+// The returned handle is safe for concurrent use and should be shared for the lifetime of the process.
+func Connect(cfg config.Database, logLevel string) (*gorm.DB, error) {
+	var dialector gorm.Dialector
+	switch cfg.Driver {
+	case config.DriverSQLite:
+		dialector = sqlite.Open(cfg.DSN())
+	default:
+		dialector = postgres.Open(cfg.DSN())
+	}
 
-func Connect() (*gorm.DB, error) {
-	if os.Getenv("GO_ENV") == "test" {
-		return gorm.Open(postgres.Open(dsnTest), &gorm.Config{})
+	dbConn, err := gorm.Open(dialector, &gorm.Config{
+		Logger: logger.Default.LogMode(gormLogLevel(logLevel)),
+		// Timestamps are written in UTC, which keeps them in order when SQLite compares them as text.
+		NowFunc: func() time.Time { return time.Now().UTC() },
+	})
+	if err != nil {
+		return nil, err
 	}
-	return gorm.Open(postgres.Open(dsnMain), &gorm.Config{})
-}
 
-func Migrate() error {
-	dbConn, err := Connect()
+	sqlDB, err := dbConn.DB()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	ConfigurePool(sqlDB, cfg)
+	return dbConn, nil
+}
+
+func ConfigurePool(sqlDB *sql.DB, cfg config.Database) {
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime))
+	sqlDB.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleTime))
+}
+
+func gormLogLevel(level string) logger.LogLevel {
+	switch level {
+	case "debug":
+		return logger.Info
+	case "error":
+		return logger.Error
+	default:
+		return logger.Warn
 	}
-	return dbConn.AutoMigrate(&dbmodels.Cage{}, &dbmodels.Dinosaur{})
 }
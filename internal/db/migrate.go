@@ -0,0 +1,249 @@
+package db
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Every dialect keeps its own migration history in migrations/<dialect>, named after the GORM dialector.
+//
+//go:embed migrations/postgres/*.sql migrations/sqlite/*.sql
+var migrationFiles embed.FS
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// migrationLockID identifies the advisory lock held while migrations are applied.
+const migrationLockID = 7_246_830_951
+
+// ErrSchemaBehind is returned when migrations are pending.
+var ErrSchemaBehind = errors.New("database schema is behind")
+
+// Migration is a numbered pair of SQL scripts embedded into the binary.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus describes whether a migration has been applied.
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type schemaMigration struct {
+	Version   int    `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"not null"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// LoadMigrations returns all embedded migrations of the given dialect ordered by version.
+// Every version must have both an up and a down script and versions must not have gaps.
+func LoadMigrations(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for dialect %s: %w", dialect, err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file name %s", entry.Name())
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		content, err := fs.ReadFile(migrationFiles, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			return nil, fmt.Errorf("migration %d is missing", i+1)
+		}
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d must have both up and down scripts", migration.Version)
+		}
+	}
+	return migrations, nil
+}
+
+// MigrateUp applies all pending migrations, each in its own transaction.
+func MigrateUp(dbConn *gorm.DB) ([]Migration, error) {
+	migrations, err := LoadMigrations(dbConn.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureMigrationsTable(dbConn); err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, migration := range migrations {
+		ran := false
+		err := dbConn.Transaction(func(tx *gorm.DB) error {
+			if err := lockMigrations(tx); err != nil {
+				return err
+			}
+			var count int64
+			if err := tx.Model(&schemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			ran = true
+			return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC()}).Error
+		})
+		if err != nil {
+			return applied, fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		if ran {
+			applied = append(applied, migration)
+		}
+	}
+	return applied, nil
+}
+
+// MigrateDown rolls back the given number of most recently applied migrations.
+func MigrateDown(dbConn *gorm.DB, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("number of migrations to roll back must be greater than 0")
+	}
+
+	statuses, err := MigrationStatuses(dbConn)
+	if err != nil {
+		return nil, err
+	}
+
+	var rolledBack []Migration
+	for i := len(statuses) - 1; i >= 0 && len(rolledBack) < steps; i-- {
+		migration := statuses[i].Migration
+		if !statuses[i].Applied {
+			continue
+		}
+		err := dbConn.Transaction(func(tx *gorm.DB) error {
+			if err := lockMigrations(tx); err != nil {
+				return err
+			}
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return rolledBack, fmt.Errorf("rollback of migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		rolledBack = append(rolledBack, migration)
+	}
+	return rolledBack, nil
+}
+
+// MigrationStatuses lists all embedded migrations along with whether they have been applied.
+func MigrationStatuses(dbConn *gorm.DB) ([]MigrationStatus, error) {
+	migrations, err := LoadMigrations(dbConn.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureMigrationsTable(dbConn); err != nil {
+		return nil, err
+	}
+
+	var appliedMigrations []schemaMigration
+	if err := dbConn.Find(&appliedMigrations).Error; err != nil {
+		return nil, err
+	}
+	appliedAt := map[int]time.Time{}
+	for _, applied := range appliedMigrations {
+		appliedAt[applied.Version] = applied.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		at, applied := appliedAt[migration.Version]
+		statuses = append(statuses, MigrationStatus{Migration: migration, Applied: applied, AppliedAt: at})
+	}
+	return statuses, nil
+}
+
+// CheckSchema returns ErrSchemaBehind when any embedded migration has not been applied yet.
+func CheckSchema(dbConn *gorm.DB) error {
+	statuses, err := MigrationStatuses(dbConn)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, status := range statuses {
+		if !status.Applied {
+			pending = append(pending, fmt.Sprintf("%d_%s", status.Version, status.Name))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w, pending migrations: %v", ErrSchemaBehind, pending)
+	}
+	return nil
+}
+
+func ensureMigrationsTable(dbConn *gorm.DB) error {
+	// The SQLite driver only parses columns declared as timestamp back into time.Time.
+	timestampType := "timestamptz"
+	if dbConn.Dialector.Name() == "sqlite" {
+		timestampType = "timestamp"
+	}
+	return dbConn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    bigint PRIMARY KEY,
+		name       text   NOT NULL,
+		applied_at ` + timestampType + ` NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`).Error
+}
+
+// lockMigrations serializes migration runs from several processes for the rest of the transaction.
+// SQLite needs no extra lock because its transactions are started with an exclusive write lock.
+func lockMigrations(tx *gorm.DB) error {
+	if tx.Dialector.Name() != "postgres" {
+		return nil
+	}
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockID).Error
+}
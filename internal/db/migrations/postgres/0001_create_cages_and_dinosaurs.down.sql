@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS dinosaurs;
+DROP TABLE IF EXISTS cages;
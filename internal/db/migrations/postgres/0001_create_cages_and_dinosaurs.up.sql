@@ -0,0 +1,23 @@
+-- Baseline schema, identical to what GORM AutoMigrate used to create.
+-- IF NOT EXISTS lets databases created before versioned migrations adopt this history.
+CREATE TABLE IF NOT EXISTS cages (
+    id           bigserial PRIMARY KEY,
+    capacity     bigint    NOT NULL,
+    power_status text      NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS dinosaurs (
+    id      bigserial PRIMARY KEY,
+    name    text      NOT NULL,
+    species text      NOT NULL,
+    type    text      NOT NULL,
+    cage_id bigint    NOT NULL
+);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_cages_dinosaurs') THEN
+        ALTER TABLE dinosaurs ADD CONSTRAINT fk_cages_dinosaurs FOREIGN KEY (cage_id) REFERENCES cages (id);
+    END IF;
+END
+$$;
@@ -0,0 +1,2 @@
+ALTER TABLE dinosaurs DROP COLUMN version;
+ALTER TABLE cages DROP COLUMN version;
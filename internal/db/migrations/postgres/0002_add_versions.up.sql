@@ -0,0 +1,2 @@
+ALTER TABLE cages ADD COLUMN version bigint NOT NULL DEFAULT 1;
+ALTER TABLE dinosaurs ADD COLUMN version bigint NOT NULL DEFAULT 1;
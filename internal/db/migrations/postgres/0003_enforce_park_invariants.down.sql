@@ -0,0 +1,17 @@
+DROP TRIGGER IF EXISTS cages_enforce_capacity ON cages;
+DROP FUNCTION IF EXISTS enforce_cage_capacity();
+DROP TRIGGER IF EXISTS dinosaurs_enforce_placement ON dinosaurs;
+DROP FUNCTION IF EXISTS enforce_dinosaur_placement();
+
+DROP INDEX IF EXISTS dinosaurs_cage_id_idx;
+ALTER TABLE dinosaurs DROP CONSTRAINT IF EXISTS dinosaurs_cage_id_fkey;
+ALTER TABLE dinosaurs
+    ADD CONSTRAINT fk_cages_dinosaurs FOREIGN KEY (cage_id) REFERENCES cages (id);
+
+ALTER TABLE dinosaurs
+    DROP CONSTRAINT IF EXISTS dinosaurs_species_type_valid,
+    DROP CONSTRAINT IF EXISTS dinosaurs_name_not_blank;
+
+ALTER TABLE cages
+    DROP CONSTRAINT IF EXISTS cages_power_status_valid,
+    DROP CONSTRAINT IF EXISTS cages_capacity_positive;
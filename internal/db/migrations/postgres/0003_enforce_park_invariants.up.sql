@@ -0,0 +1,84 @@
+-- Park safety rules enforced by the database itself, mirroring the placement rules of the service layer.
+-- Constraint names are reported back to the API, which translates them into its usual responses.
+
+ALTER TABLE cages
+    ADD CONSTRAINT cages_capacity_positive CHECK (capacity > 0),
+    ADD CONSTRAINT cages_power_status_valid CHECK (power_status IN ('ACTIVE', 'DOWN'));
+
+ALTER TABLE dinosaurs
+    ADD CONSTRAINT dinosaurs_name_not_blank CHECK (btrim(name) <> ''),
+    ADD CONSTRAINT dinosaurs_species_type_valid CHECK (
+        (species IN ('Tyrannosaurus', 'Velociraptor', 'Spinosaurus', 'Megalosaurus') AND type = 'CARNIVORE')
+        OR (species IN ('Brachiosaurus', 'Stegosaurus', 'Ankylosaurus', 'Triceratops') AND type = 'HERBIVORE')
+    );
+
+-- Replace the foreign key created by GORM with one that explicitly forbids removing occupied cages.
+ALTER TABLE dinosaurs DROP CONSTRAINT IF EXISTS fk_cages_dinosaurs;
+ALTER TABLE dinosaurs
+    ADD CONSTRAINT dinosaurs_cage_id_fkey FOREIGN KEY (cage_id) REFERENCES cages (id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS dinosaurs_cage_id_idx ON dinosaurs (cage_id);
+
+CREATE FUNCTION enforce_dinosaur_placement() RETURNS trigger AS $$
+DECLARE
+    target_cage cages%ROWTYPE;
+    occupants   bigint;
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.cage_id = OLD.cage_id AND NEW.species = OLD.species AND NEW.type = OLD.type THEN
+        RETURN NEW;
+    END IF;
+
+    -- Locking the cage makes concurrent placements into it queue up behind each other.
+    SELECT * INTO target_cage FROM cages WHERE id = NEW.cage_id FOR UPDATE;
+    IF NOT FOUND THEN
+        -- Reported by dinosaurs_cage_id_fkey.
+        RETURN NEW;
+    END IF;
+
+    IF TG_OP = 'INSERT' OR NEW.cage_id <> OLD.cage_id THEN
+        IF target_cage.power_status <> 'ACTIVE' THEN
+            RAISE EXCEPTION 'cage % has no power', NEW.cage_id
+                USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_cage_powered', TABLE = 'dinosaurs';
+        END IF;
+
+        SELECT count(*) INTO occupants FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id;
+        IF occupants >= target_cage.capacity THEN
+            RAISE EXCEPTION 'cage % is already full', NEW.cage_id
+                USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_cage_capacity', TABLE = 'dinosaurs';
+        END IF;
+    END IF;
+
+    IF NEW.type = 'HERBIVORE' AND EXISTS (
+        SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND type = 'CARNIVORE'
+    ) THEN
+        RAISE EXCEPTION 'herbivore cannot share cage % with carnivores', NEW.cage_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_no_herbivores_with_carnivores', TABLE = 'dinosaurs';
+    END IF;
+
+    IF NEW.type = 'CARNIVORE' AND EXISTS (
+        SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND species <> NEW.species
+    ) THEN
+        RAISE EXCEPTION 'carnivore cannot share cage % with other species', NEW.cage_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_carnivores_single_species', TABLE = 'dinosaurs';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER dinosaurs_enforce_placement
+    BEFORE INSERT OR UPDATE ON dinosaurs
+    FOR EACH ROW EXECUTE FUNCTION enforce_dinosaur_placement();
+
+CREATE FUNCTION enforce_cage_capacity() RETURNS trigger AS $$
+BEGIN
+    IF NEW.capacity < OLD.capacity AND (SELECT count(*) FROM dinosaurs WHERE cage_id = NEW.id) > NEW.capacity THEN
+        RAISE EXCEPTION 'cage % holds more dinosaurs than capacity %', NEW.id, NEW.capacity
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'cages_capacity_not_below_occupancy', TABLE = 'cages';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER cages_enforce_capacity
+    BEFORE UPDATE ON cages
+    FOR EACH ROW EXECUTE FUNCTION enforce_cage_capacity();
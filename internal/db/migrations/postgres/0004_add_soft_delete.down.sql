@@ -0,0 +1,65 @@
+CREATE OR REPLACE FUNCTION enforce_cage_capacity() RETURNS trigger AS $$
+BEGIN
+    IF NEW.capacity < OLD.capacity AND (SELECT count(*) FROM dinosaurs WHERE cage_id = NEW.id) > NEW.capacity THEN
+        RAISE EXCEPTION 'cage % holds more dinosaurs than capacity %', NEW.id, NEW.capacity
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'cages_capacity_not_below_occupancy', TABLE = 'cages';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION enforce_dinosaur_placement() RETURNS trigger AS $$
+DECLARE
+    target_cage cages%ROWTYPE;
+    occupants   bigint;
+BEGIN
+    IF TG_OP = 'UPDATE' AND NEW.cage_id = OLD.cage_id AND NEW.species = OLD.species AND NEW.type = OLD.type THEN
+        RETURN NEW;
+    END IF;
+
+    -- Locking the cage makes concurrent placements into it queue up behind each other.
+    SELECT * INTO target_cage FROM cages WHERE id = NEW.cage_id FOR UPDATE;
+    IF NOT FOUND THEN
+        -- Reported by dinosaurs_cage_id_fkey.
+        RETURN NEW;
+    END IF;
+
+    IF TG_OP = 'INSERT' OR NEW.cage_id <> OLD.cage_id THEN
+        IF target_cage.power_status <> 'ACTIVE' THEN
+            RAISE EXCEPTION 'cage % has no power', NEW.cage_id
+                USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_cage_powered', TABLE = 'dinosaurs';
+        END IF;
+
+        SELECT count(*) INTO occupants FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id;
+        IF occupants >= target_cage.capacity THEN
+            RAISE EXCEPTION 'cage % is already full', NEW.cage_id
+                USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_cage_capacity', TABLE = 'dinosaurs';
+        END IF;
+    END IF;
+
+    IF NEW.type = 'HERBIVORE' AND EXISTS (
+        SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND type = 'CARNIVORE'
+    ) THEN
+        RAISE EXCEPTION 'herbivore cannot share cage % with carnivores', NEW.cage_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_no_herbivores_with_carnivores', TABLE = 'dinosaurs';
+    END IF;
+
+    IF NEW.type = 'CARNIVORE' AND EXISTS (
+        SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND species <> NEW.species
+    ) THEN
+        RAISE EXCEPTION 'carnivore cannot share cage % with other species', NEW.cage_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_carnivores_single_species', TABLE = 'dinosaurs';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Soft-deleted records cannot be represented anymore.
+DELETE FROM dinosaurs WHERE deleted_at IS NOT NULL;
+DELETE FROM cages WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS dinosaurs_deleted_at_idx;
+DROP INDEX IF EXISTS cages_deleted_at_idx;
+ALTER TABLE dinosaurs DROP COLUMN deleted_at;
+ALTER TABLE cages DROP COLUMN deleted_at;
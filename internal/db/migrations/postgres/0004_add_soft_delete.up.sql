@@ -0,0 +1,85 @@
+-- Deleted cages and dinosaurs are kept with a deletion timestamp so they can be restored.
+-- Soft-deleted dinosaurs keep their cage_id but no longer count as its occupants.
+
+ALTER TABLE cages ADD COLUMN deleted_at timestamptz;
+ALTER TABLE dinosaurs ADD COLUMN deleted_at timestamptz;
+CREATE INDEX cages_deleted_at_idx ON cages (deleted_at);
+CREATE INDEX dinosaurs_deleted_at_idx ON dinosaurs (deleted_at);
+
+CREATE OR REPLACE FUNCTION enforce_dinosaur_placement() RETURNS trigger AS $$
+DECLARE
+    target_cage cages%ROWTYPE;
+    occupants   bigint;
+    placed      boolean;
+BEGIN
+    IF NEW.deleted_at IS NOT NULL THEN
+        RETURN NEW;
+    END IF;
+
+    -- A restored dinosaur is placed into its cage again, just like a new one.
+    placed := TG_OP = 'INSERT' OR OLD.deleted_at IS NOT NULL OR NEW.cage_id <> OLD.cage_id;
+    IF NOT placed AND NEW.species = OLD.species AND NEW.type = OLD.type THEN
+        RETURN NEW;
+    END IF;
+
+    -- Locking the cage makes concurrent placements into it queue up behind each other.
+    SELECT * INTO target_cage FROM cages WHERE id = NEW.cage_id FOR UPDATE;
+    IF NOT FOUND THEN
+        -- Reported by dinosaurs_cage_id_fkey.
+        RETURN NEW;
+    END IF;
+
+    IF target_cage.deleted_at IS NOT NULL THEN
+        RAISE EXCEPTION 'cage % has been deleted', NEW.cage_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_cage_not_deleted', TABLE = 'dinosaurs';
+    END IF;
+
+    IF placed THEN
+        IF target_cage.power_status <> 'ACTIVE' THEN
+            RAISE EXCEPTION 'cage % has no power', NEW.cage_id
+                USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_cage_powered', TABLE = 'dinosaurs';
+        END IF;
+
+        SELECT count(*) INTO occupants FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND deleted_at IS NULL;
+        IF occupants >= target_cage.capacity THEN
+            RAISE EXCEPTION 'cage % is already full', NEW.cage_id
+                USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_cage_capacity', TABLE = 'dinosaurs';
+        END IF;
+    END IF;
+
+    IF NEW.type = 'HERBIVORE' AND EXISTS (
+        SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND deleted_at IS NULL AND type = 'CARNIVORE'
+    ) THEN
+        RAISE EXCEPTION 'herbivore cannot share cage % with carnivores', NEW.cage_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_no_herbivores_with_carnivores', TABLE = 'dinosaurs';
+    END IF;
+
+    IF NEW.type = 'CARNIVORE' AND EXISTS (
+        SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND deleted_at IS NULL AND species <> NEW.species
+    ) THEN
+        RAISE EXCEPTION 'carnivore cannot share cage % with other species', NEW.cage_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'dinosaurs_carnivores_single_species', TABLE = 'dinosaurs';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION enforce_cage_capacity() RETURNS trigger AS $$
+DECLARE
+    occupants bigint;
+BEGIN
+    SELECT count(*) INTO occupants FROM dinosaurs WHERE cage_id = NEW.id AND deleted_at IS NULL;
+
+    IF NEW.capacity < OLD.capacity AND occupants > NEW.capacity THEN
+        RAISE EXCEPTION 'cage % holds more dinosaurs than capacity %', NEW.id, NEW.capacity
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'cages_capacity_not_below_occupancy', TABLE = 'cages';
+    END IF;
+
+    IF NEW.deleted_at IS NOT NULL AND OLD.deleted_at IS NULL AND occupants > 0 THEN
+        RAISE EXCEPTION 'cage % still holds dinosaurs', NEW.id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'cages_empty_on_delete', TABLE = 'cages';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,2 @@
+DROP TABLE history;
+DROP FUNCTION IF EXISTS forbid_history_changes();
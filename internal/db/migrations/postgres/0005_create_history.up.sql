@@ -0,0 +1,23 @@
+-- Append-only log of all changes made to cages and dinosaurs.
+CREATE TABLE history (
+    id          bigserial   PRIMARY KEY,
+    entity_type text        NOT NULL,
+    entity_id   bigint      NOT NULL,
+    action      text        NOT NULL,
+    actor       text        NOT NULL,
+    recorded_at timestamptz NOT NULL,
+    before      text        NOT NULL DEFAULT '',
+    after       text        NOT NULL DEFAULT ''
+);
+CREATE INDEX history_entity_idx ON history (entity_type, entity_id, id);
+
+CREATE FUNCTION forbid_history_changes() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'history entries cannot be changed'
+        USING ERRCODE = 'check_violation', CONSTRAINT = 'history_immutable', TABLE = 'history';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER history_immutable
+    BEFORE UPDATE OR DELETE ON history
+    FOR EACH ROW EXECUTE FUNCTION forbid_history_changes();
@@ -0,0 +1,12 @@
+-- Requests made with an Idempotency-Key header, along with their responses, which are replayed for retries.
+CREATE TABLE idempotency_keys (
+    scope        text        NOT NULL,
+    key          text        NOT NULL,
+    fingerprint  text        NOT NULL,
+    status_code  integer     NOT NULL DEFAULT 0,
+    content_type text        NOT NULL DEFAULT '',
+    etag         text        NOT NULL DEFAULT '',
+    body         text        NOT NULL DEFAULT '',
+    created_at   timestamptz NOT NULL,
+    PRIMARY KEY (scope, key)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE dinosaurs DROP COLUMN updated_at;
+ALTER TABLE dinosaurs DROP COLUMN created_at;
+ALTER TABLE cages DROP COLUMN updated_at;
+ALTER TABLE cages DROP COLUMN created_at;
@@ -0,0 +1,7 @@
+-- Creation and modification times of cages and dinosaurs. Existing records are taken to have been created now.
+ALTER TABLE cages
+    ADD COLUMN created_at timestamptz NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at timestamptz NOT NULL DEFAULT now();
+ALTER TABLE dinosaurs
+    ADD COLUMN created_at timestamptz NOT NULL DEFAULT now(),
+    ADD COLUMN updated_at timestamptz NOT NULL DEFAULT now();
@@ -0,0 +1,9 @@
+DROP TRIGGER cages_enforce_capacity ON cages;
+CREATE TRIGGER cages_enforce_capacity
+    BEFORE UPDATE ON cages
+    FOR EACH ROW EXECUTE FUNCTION enforce_cage_capacity();
+
+DROP TRIGGER dinosaurs_enforce_placement ON dinosaurs;
+CREATE TRIGGER dinosaurs_enforce_placement
+    BEFORE INSERT OR UPDATE ON dinosaurs
+    FOR EACH ROW EXECUTE FUNCTION enforce_dinosaur_placement();
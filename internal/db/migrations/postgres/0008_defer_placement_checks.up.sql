@@ -0,0 +1,15 @@
+-- The placement and capacity checks become constraint triggers, so that a transaction changing several dinosaurs
+-- at once, such as a swap of cages, can defer them with SET CONSTRAINTS until all of its changes have been made.
+-- Unless deferred, they check every row right after the statement writing it, as before.
+
+DROP TRIGGER dinosaurs_enforce_placement ON dinosaurs;
+CREATE CONSTRAINT TRIGGER dinosaurs_enforce_placement
+    AFTER INSERT OR UPDATE ON dinosaurs
+    DEFERRABLE INITIALLY IMMEDIATE
+    FOR EACH ROW EXECUTE FUNCTION enforce_dinosaur_placement();
+
+DROP TRIGGER cages_enforce_capacity ON cages;
+CREATE CONSTRAINT TRIGGER cages_enforce_capacity
+    AFTER UPDATE ON cages
+    DEFERRABLE INITIALLY IMMEDIATE
+    FOR EACH ROW EXECUTE FUNCTION enforce_cage_capacity();
@@ -0,0 +1,2 @@
+DROP TABLE dinosaurs;
+DROP TABLE cages;
@@ -0,0 +1,23 @@
+-- SQLite cannot add constraints to existing tables, so the CHECK constraints and the foreign key
+-- that Postgres gains in migration 0003 are part of the initial tables here.
+CREATE TABLE cages (
+    id           integer PRIMARY KEY AUTOINCREMENT,
+    capacity     integer NOT NULL,
+    power_status text    NOT NULL,
+    CONSTRAINT cages_capacity_positive CHECK (capacity > 0),
+    CONSTRAINT cages_power_status_valid CHECK (power_status IN ('ACTIVE', 'DOWN'))
+);
+
+CREATE TABLE dinosaurs (
+    id      integer PRIMARY KEY AUTOINCREMENT,
+    name    text    NOT NULL,
+    species text    NOT NULL,
+    type    text    NOT NULL,
+    cage_id integer NOT NULL,
+    CONSTRAINT dinosaurs_name_not_blank CHECK (trim(name) <> ''),
+    CONSTRAINT dinosaurs_species_type_valid CHECK (
+        (species IN ('Tyrannosaurus', 'Velociraptor', 'Spinosaurus', 'Megalosaurus') AND type = 'CARNIVORE')
+        OR (species IN ('Brachiosaurus', 'Stegosaurus', 'Ankylosaurus', 'Triceratops') AND type = 'HERBIVORE')
+    ),
+    CONSTRAINT dinosaurs_cage_id_fkey FOREIGN KEY (cage_id) REFERENCES cages (id) ON DELETE RESTRICT
+);
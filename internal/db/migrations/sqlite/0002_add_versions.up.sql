@@ -0,0 +1,2 @@
+ALTER TABLE cages ADD COLUMN version integer NOT NULL DEFAULT 1;
+ALTER TABLE dinosaurs ADD COLUMN version integer NOT NULL DEFAULT 1;
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS cages_enforce_capacity;
+DROP TRIGGER IF EXISTS dinosaurs_enforce_placement_update;
+DROP TRIGGER IF EXISTS dinosaurs_enforce_placement_insert;
+DROP INDEX IF EXISTS dinosaurs_cage_id_idx;
@@ -0,0 +1,54 @@
+-- Park safety rules enforced by the database itself, mirroring the placement rules of the service layer.
+-- RAISE reports the constraint name as the error message, which the API translates into its usual responses.
+-- Writers are serialized by immediate transactions, so the triggers need no row locks.
+
+CREATE INDEX dinosaurs_cage_id_idx ON dinosaurs (cage_id);
+
+CREATE TRIGGER dinosaurs_enforce_placement_insert
+    BEFORE INSERT ON dinosaurs
+BEGIN
+    SELECT RAISE(ABORT, 'dinosaurs_cage_powered')
+    WHERE (SELECT power_status FROM cages WHERE id = NEW.cage_id) <> 'ACTIVE';
+
+    SELECT RAISE(ABORT, 'dinosaurs_cage_capacity')
+    WHERE (SELECT count(*) FROM dinosaurs WHERE cage_id = NEW.cage_id)
+        >= (SELECT capacity FROM cages WHERE id = NEW.cage_id);
+
+    SELECT RAISE(ABORT, 'dinosaurs_no_herbivores_with_carnivores')
+    WHERE NEW.type = 'HERBIVORE'
+        AND EXISTS (SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND type = 'CARNIVORE');
+
+    SELECT RAISE(ABORT, 'dinosaurs_carnivores_single_species')
+    WHERE NEW.type = 'CARNIVORE'
+        AND EXISTS (SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND species <> NEW.species);
+END;
+
+CREATE TRIGGER dinosaurs_enforce_placement_update
+    BEFORE UPDATE OF cage_id, species, type ON dinosaurs
+    WHEN NEW.cage_id <> OLD.cage_id OR NEW.species <> OLD.species OR NEW.type <> OLD.type
+BEGIN
+    SELECT RAISE(ABORT, 'dinosaurs_cage_powered')
+    WHERE NEW.cage_id <> OLD.cage_id
+        AND (SELECT power_status FROM cages WHERE id = NEW.cage_id) <> 'ACTIVE';
+
+    SELECT RAISE(ABORT, 'dinosaurs_cage_capacity')
+    WHERE NEW.cage_id <> OLD.cage_id
+        AND (SELECT count(*) FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id)
+            >= (SELECT capacity FROM cages WHERE id = NEW.cage_id);
+
+    SELECT RAISE(ABORT, 'dinosaurs_no_herbivores_with_carnivores')
+    WHERE NEW.type = 'HERBIVORE'
+        AND EXISTS (SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND type = 'CARNIVORE');
+
+    SELECT RAISE(ABORT, 'dinosaurs_carnivores_single_species')
+    WHERE NEW.type = 'CARNIVORE'
+        AND EXISTS (SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND species <> NEW.species);
+END;
+
+CREATE TRIGGER cages_enforce_capacity
+    BEFORE UPDATE OF capacity ON cages
+    WHEN NEW.capacity < OLD.capacity
+BEGIN
+    SELECT RAISE(ABORT, 'cages_capacity_not_below_occupancy')
+    WHERE (SELECT count(*) FROM dinosaurs WHERE cage_id = NEW.id) > NEW.capacity;
+END;
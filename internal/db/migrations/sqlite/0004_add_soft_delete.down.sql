@@ -0,0 +1,62 @@
+DROP TRIGGER cages_enforce_empty_on_delete;
+DROP TRIGGER cages_enforce_capacity;
+DROP TRIGGER dinosaurs_enforce_placement_update;
+DROP TRIGGER dinosaurs_enforce_placement_insert;
+
+CREATE TRIGGER dinosaurs_enforce_placement_insert
+    BEFORE INSERT ON dinosaurs
+BEGIN
+    SELECT RAISE(ABORT, 'dinosaurs_cage_powered')
+    WHERE (SELECT power_status FROM cages WHERE id = NEW.cage_id) <> 'ACTIVE';
+
+    SELECT RAISE(ABORT, 'dinosaurs_cage_capacity')
+    WHERE (SELECT count(*) FROM dinosaurs WHERE cage_id = NEW.cage_id)
+        >= (SELECT capacity FROM cages WHERE id = NEW.cage_id);
+
+    SELECT RAISE(ABORT, 'dinosaurs_no_herbivores_with_carnivores')
+    WHERE NEW.type = 'HERBIVORE'
+        AND EXISTS (SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND type = 'CARNIVORE');
+
+    SELECT RAISE(ABORT, 'dinosaurs_carnivores_single_species')
+    WHERE NEW.type = 'CARNIVORE'
+        AND EXISTS (SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND species <> NEW.species);
+END;
+
+CREATE TRIGGER dinosaurs_enforce_placement_update
+    BEFORE UPDATE OF cage_id, species, type ON dinosaurs
+    WHEN NEW.cage_id <> OLD.cage_id OR NEW.species <> OLD.species OR NEW.type <> OLD.type
+BEGIN
+    SELECT RAISE(ABORT, 'dinosaurs_cage_powered')
+    WHERE NEW.cage_id <> OLD.cage_id
+        AND (SELECT power_status FROM cages WHERE id = NEW.cage_id) <> 'ACTIVE';
+
+    SELECT RAISE(ABORT, 'dinosaurs_cage_capacity')
+    WHERE NEW.cage_id <> OLD.cage_id
+        AND (SELECT count(*) FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id)
+            >= (SELECT capacity FROM cages WHERE id = NEW.cage_id);
+
+    SELECT RAISE(ABORT, 'dinosaurs_no_herbivores_with_carnivores')
+    WHERE NEW.type = 'HERBIVORE'
+        AND EXISTS (SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND type = 'CARNIVORE');
+
+    SELECT RAISE(ABORT, 'dinosaurs_carnivores_single_species')
+    WHERE NEW.type = 'CARNIVORE'
+        AND EXISTS (SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND species <> NEW.species);
+END;
+
+CREATE TRIGGER cages_enforce_capacity
+    BEFORE UPDATE OF capacity ON cages
+    WHEN NEW.capacity < OLD.capacity
+BEGIN
+    SELECT RAISE(ABORT, 'cages_capacity_not_below_occupancy')
+    WHERE (SELECT count(*) FROM dinosaurs WHERE cage_id = NEW.id) > NEW.capacity;
+END;
+
+-- Soft-deleted records cannot be represented anymore.
+DELETE FROM dinosaurs WHERE deleted_at IS NOT NULL;
+DELETE FROM cages WHERE deleted_at IS NOT NULL;
+
+DROP INDEX dinosaurs_deleted_at_idx;
+DROP INDEX cages_deleted_at_idx;
+ALTER TABLE dinosaurs DROP COLUMN deleted_at;
+ALTER TABLE cages DROP COLUMN deleted_at;
@@ -0,0 +1,82 @@
+-- Deleted cages and dinosaurs are kept with a deletion timestamp so they can be restored.
+-- Soft-deleted dinosaurs keep their cage_id but no longer count as its occupants.
+
+ALTER TABLE cages ADD COLUMN deleted_at timestamp;
+ALTER TABLE dinosaurs ADD COLUMN deleted_at timestamp;
+CREATE INDEX cages_deleted_at_idx ON cages (deleted_at);
+CREATE INDEX dinosaurs_deleted_at_idx ON dinosaurs (deleted_at);
+
+DROP TRIGGER dinosaurs_enforce_placement_insert;
+DROP TRIGGER dinosaurs_enforce_placement_update;
+DROP TRIGGER cages_enforce_capacity;
+
+CREATE TRIGGER dinosaurs_enforce_placement_insert
+    BEFORE INSERT ON dinosaurs
+    WHEN NEW.deleted_at IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'dinosaurs_cage_not_deleted')
+    WHERE (SELECT deleted_at FROM cages WHERE id = NEW.cage_id) IS NOT NULL;
+
+    SELECT RAISE(ABORT, 'dinosaurs_cage_powered')
+    WHERE (SELECT power_status FROM cages WHERE id = NEW.cage_id) <> 'ACTIVE';
+
+    SELECT RAISE(ABORT, 'dinosaurs_cage_capacity')
+    WHERE (SELECT count(*) FROM dinosaurs WHERE cage_id = NEW.cage_id AND deleted_at IS NULL)
+        >= (SELECT capacity FROM cages WHERE id = NEW.cage_id);
+
+    SELECT RAISE(ABORT, 'dinosaurs_no_herbivores_with_carnivores')
+    WHERE NEW.type = 'HERBIVORE'
+        AND EXISTS (SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND deleted_at IS NULL AND type = 'CARNIVORE');
+
+    SELECT RAISE(ABORT, 'dinosaurs_carnivores_single_species')
+    WHERE NEW.type = 'CARNIVORE'
+        AND EXISTS (SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND deleted_at IS NULL AND species <> NEW.species);
+END;
+
+-- A restored dinosaur is placed into its cage again, just like a new one.
+CREATE TRIGGER dinosaurs_enforce_placement_update
+    BEFORE UPDATE OF cage_id, species, type, deleted_at ON dinosaurs
+    WHEN NEW.deleted_at IS NULL AND (
+        OLD.deleted_at IS NOT NULL OR NEW.cage_id <> OLD.cage_id OR NEW.species <> OLD.species OR NEW.type <> OLD.type
+    )
+BEGIN
+    SELECT RAISE(ABORT, 'dinosaurs_cage_not_deleted')
+    WHERE (SELECT deleted_at FROM cages WHERE id = NEW.cage_id) IS NOT NULL;
+
+    SELECT RAISE(ABORT, 'dinosaurs_cage_powered')
+    WHERE (OLD.deleted_at IS NOT NULL OR NEW.cage_id <> OLD.cage_id)
+        AND (SELECT power_status FROM cages WHERE id = NEW.cage_id) <> 'ACTIVE';
+
+    SELECT RAISE(ABORT, 'dinosaurs_cage_capacity')
+    WHERE (OLD.deleted_at IS NOT NULL OR NEW.cage_id <> OLD.cage_id)
+        AND (SELECT count(*) FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND deleted_at IS NULL)
+            >= (SELECT capacity FROM cages WHERE id = NEW.cage_id);
+
+    SELECT RAISE(ABORT, 'dinosaurs_no_herbivores_with_carnivores')
+    WHERE NEW.type = 'HERBIVORE'
+        AND EXISTS (
+            SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND deleted_at IS NULL AND type = 'CARNIVORE'
+        );
+
+    SELECT RAISE(ABORT, 'dinosaurs_carnivores_single_species')
+    WHERE NEW.type = 'CARNIVORE'
+        AND EXISTS (
+            SELECT 1 FROM dinosaurs WHERE cage_id = NEW.cage_id AND id <> NEW.id AND deleted_at IS NULL AND species <> NEW.species
+        );
+END;
+
+CREATE TRIGGER cages_enforce_capacity
+    BEFORE UPDATE OF capacity ON cages
+    WHEN NEW.capacity < OLD.capacity
+BEGIN
+    SELECT RAISE(ABORT, 'cages_capacity_not_below_occupancy')
+    WHERE (SELECT count(*) FROM dinosaurs WHERE cage_id = NEW.id AND deleted_at IS NULL) > NEW.capacity;
+END;
+
+CREATE TRIGGER cages_enforce_empty_on_delete
+    BEFORE UPDATE OF deleted_at ON cages
+    WHEN NEW.deleted_at IS NOT NULL AND OLD.deleted_at IS NULL
+BEGIN
+    SELECT RAISE(ABORT, 'cages_empty_on_delete')
+    WHERE EXISTS (SELECT 1 FROM dinosaurs WHERE cage_id = NEW.id AND deleted_at IS NULL);
+END;
@@ -0,0 +1,24 @@
+-- Append-only log of all changes made to cages and dinosaurs.
+CREATE TABLE history (
+    id          integer   PRIMARY KEY AUTOINCREMENT,
+    entity_type text      NOT NULL,
+    entity_id   integer   NOT NULL,
+    action      text      NOT NULL,
+    actor       text      NOT NULL,
+    recorded_at timestamp NOT NULL,
+    before      text      NOT NULL DEFAULT '',
+    after       text      NOT NULL DEFAULT ''
+);
+CREATE INDEX history_entity_idx ON history (entity_type, entity_id, id);
+
+CREATE TRIGGER history_immutable_update
+    BEFORE UPDATE ON history
+BEGIN
+    SELECT RAISE(ABORT, 'history_immutable');
+END;
+
+CREATE TRIGGER history_immutable_delete
+    BEFORE DELETE ON history
+BEGIN
+    SELECT RAISE(ABORT, 'history_immutable');
+END;
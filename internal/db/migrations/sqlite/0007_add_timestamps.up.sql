@@ -0,0 +1,9 @@
+-- Creation and modification times of cages and dinosaurs. Existing records are taken to have been created now.
+-- SQLite only adds columns with constant defaults, so the times are filled in afterwards.
+ALTER TABLE cages ADD COLUMN created_at timestamp NOT NULL DEFAULT '1970-01-01 00:00:00';
+ALTER TABLE cages ADD COLUMN updated_at timestamp NOT NULL DEFAULT '1970-01-01 00:00:00';
+ALTER TABLE dinosaurs ADD COLUMN created_at timestamp NOT NULL DEFAULT '1970-01-01 00:00:00';
+ALTER TABLE dinosaurs ADD COLUMN updated_at timestamp NOT NULL DEFAULT '1970-01-01 00:00:00';
+
+UPDATE cages SET created_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP;
+UPDATE dinosaurs SET created_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP;
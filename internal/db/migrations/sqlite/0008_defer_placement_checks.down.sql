@@ -0,0 +1 @@
+DROP VIEW dinosaur_placement_violations;
@@ -0,0 +1,32 @@
+-- SQLite has no deferrable triggers. A transaction changing several dinosaurs at once, such as a swap of cages,
+-- drops dinosaurs_enforce_placement_update while it makes its changes and creates it again before it commits,
+-- holding the write lock all along. This view then checks the changed dinosaurs against the cages as they end up,
+-- in the order of the trigger. Moves leave capacities alone, so the capacity check of cages is not deferred.
+
+CREATE VIEW dinosaur_placement_violations (dinosaur_id, rule, constraint_name) AS
+    SELECT d.id, 1, 'dinosaurs_cage_not_deleted'
+    FROM dinosaurs d JOIN cages c ON c.id = d.cage_id
+    WHERE d.deleted_at IS NULL AND c.deleted_at IS NOT NULL
+UNION ALL
+    SELECT d.id, 2, 'dinosaurs_cage_powered'
+    FROM dinosaurs d JOIN cages c ON c.id = d.cage_id
+    WHERE d.deleted_at IS NULL AND c.power_status <> 'ACTIVE'
+UNION ALL
+    SELECT d.id, 3, 'dinosaurs_cage_capacity'
+    FROM dinosaurs d JOIN cages c ON c.id = d.cage_id
+    WHERE d.deleted_at IS NULL
+        AND (SELECT count(*) FROM dinosaurs WHERE cage_id = d.cage_id AND deleted_at IS NULL) > c.capacity
+UNION ALL
+    SELECT d.id, 4, 'dinosaurs_no_herbivores_with_carnivores'
+    FROM dinosaurs d
+    WHERE d.deleted_at IS NULL AND d.type = 'HERBIVORE'
+        AND EXISTS (
+            SELECT 1 FROM dinosaurs WHERE cage_id = d.cage_id AND id <> d.id AND deleted_at IS NULL AND type = 'CARNIVORE'
+        )
+UNION ALL
+    SELECT d.id, 5, 'dinosaurs_carnivores_single_species'
+    FROM dinosaurs d
+    WHERE d.deleted_at IS NULL AND d.type = 'CARNIVORE'
+        AND EXISTS (
+            SELECT 1 FROM dinosaurs WHERE cage_id = d.cage_id AND id <> d.id AND deleted_at IS NULL AND species <> d.species
+        );
@@ -0,0 +1 @@
+ALTER TABLE cages DROP COLUMN occupancy_version;
@@ -0,0 +1,3 @@
+-- A cage's version only counts changes of its own fields, so that placing dinosaurs into it does not fail
+-- a concurrent change of its power or capacity. Changes of its dinosaurs are counted separately.
+ALTER TABLE cages ADD COLUMN occupancy_version integer NOT NULL DEFAULT 1;
@@ -1,8 +1,33 @@
 package dbmodels
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 type Cage struct {
-	ID          uint       `gorm:"primaryKey;autoIncrement"`
-	Capacity    int        `gorm:"not null"`
-	PowerStatus string     `gorm:"not null"`
-	Dinosaurs   []Dinosaur `gorm:"foreignKey:CageID"`
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	Capacity    int    `gorm:"not null"`
+	PowerStatus string `gorm:"not null"`
+	// Version is incremented on every change of the cage's own fields.
+	Version uint `gorm:"not null;default:1"`
+	// OccupancyVersion is incremented whenever the dinosaurs of the cage change.
+	OccupancyVersion uint      `gorm:"not null;default:1"`
+	CreatedAt        time.Time `gorm:"not null"`
+	// UpdatedAt is set on every change of the cage or its dinosaurs.
+	UpdatedAt time.Time `gorm:"not null"`
+	// DeletedAt marks soft-deleted cages, which are hidden from queries unless explicitly requested.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	Dinosaurs []Dinosaur     `gorm:"foreignKey:CageID"`
+	// Occupancy is the number of dinosaurs in the cage, which is read along with the cage even without its dinosaurs.
+	Occupancy int `gorm:"->;-:migration"`
+}
+
+// LastModified returns when the cage has last been changed, its deletion included.
+func (c Cage) LastModified() time.Time {
+	if c.DeletedAt.Valid && c.DeletedAt.Time.After(c.UpdatedAt) {
+		return c.DeletedAt.Time
+	}
+	return c.UpdatedAt
 }
@@ -1,9 +1,32 @@
 package dbmodels
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 type Dinosaur struct {
 	ID      uint   `gorm:"primaryKey;autoIncrement"`
 	Name    string `gorm:"not null"`
 	Species string `gorm:"not null"`
 	Type    string `gorm:"not null"`
 	CageID  uint   `gorm:"not null"`
+	// Version is incremented on every change of the dinosaur.
+	Version   uint      `gorm:"not null;default:1"`
+	CreatedAt time.Time `gorm:"not null"`
+	// UpdatedAt is set along with the version on every change of the dinosaur.
+	UpdatedAt time.Time `gorm:"not null"`
+	// DeletedAt marks soft-deleted dinosaurs. They keep their cage, but no longer occupy it.
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	// Cage is only set when it has been requested along with the dinosaur.
+	Cage *Cage `gorm:"foreignKey:CageID"`
+}
+
+// LastModified returns when the dinosaur has last been changed, its deletion included.
+func (d Dinosaur) LastModified() time.Time {
+	if d.DeletedAt.Valid && d.DeletedAt.Time.After(d.UpdatedAt) {
+		return d.DeletedAt.Time
+	}
+	return d.UpdatedAt
 }
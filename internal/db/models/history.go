@@ -0,0 +1,20 @@
+package dbmodels
+
+import "time"
+
+// HistoryEntry records a single change of a cage or a dinosaur. Entries are never changed once written.
+type HistoryEntry struct {
+	ID         uint      `gorm:"primaryKey;autoIncrement"`
+	EntityType string    `gorm:"not null"`
+	EntityID   uint      `gorm:"not null"`
+	Action     string    `gorm:"not null"`
+	Actor      string    `gorm:"not null"`
+	RecordedAt time.Time `gorm:"not null"`
+	// Before and After hold JSON snapshots of the entity. Before is empty for creations.
+	Before string
+	After  string
+}
+
+func (HistoryEntry) TableName() string {
+	return "history"
+}
@@ -0,0 +1,17 @@
+package dbmodels
+
+import "time"
+
+// IdempotencyKey records a request made with an Idempotency-Key header, and its response once it has been handled.
+type IdempotencyKey struct {
+	// Scope is the route the key has been used for. The same key can be used once for every route.
+	Scope       string `gorm:"primaryKey"`
+	Key         string `gorm:"primaryKey"`
+	Fingerprint string `gorm:"not null"`
+	// StatusCode is 0 while the request is being handled.
+	StatusCode  int       `gorm:"not null"`
+	ContentType string    `gorm:"not null"`
+	ETag        string    `gorm:"column:etag;not null"`
+	Body        string    `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
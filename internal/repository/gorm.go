@@ -0,0 +1,644 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormStore is a Store backed by a relational database through GORM.
+type GormStore struct {
+	db *gorm.DB
+}
+
+func NewGormStore(db *gorm.DB) *GormStore {
+	return &GormStore{db: db}
+}
+
+func (s *GormStore) Cages() CageRepository {
+	return &gormCageRepository{db: s.db, relations: CageRelations{Dinosaurs: true}}
+}
+
+func (s *GormStore) Dinosaurs() DinosaurRepository {
+	return &gormDinosaurRepository{db: s.db}
+}
+
+func (s *GormStore) History() HistoryRepository {
+	return &gormHistoryRepository{db: s.db}
+}
+
+func (s *GormStore) IdempotencyKeys() IdempotencyRepository {
+	return &gormIdempotencyRepository{db: s.db}
+}
+
+func (s *GormStore) Transaction(ctx context.Context, fn func(tx Store) error) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&GormStore{db: tx})
+	})
+}
+
+// occupants restricts preloaded dinosaurs to those still in their cage, also for unscoped queries.
+const occupants = "deleted_at IS NULL"
+
+// occupancy counts the dinosaurs still in the cage of the surrounding query.
+const occupancy = "(SELECT COUNT(*) FROM dinosaurs WHERE dinosaurs.cage_id = cages.id AND dinosaurs.deleted_at IS NULL)"
+
+// selectCages selects the cages along with their occupancy, and preloads the requested relations.
+func selectCages(query *gorm.DB, relations CageRelations) *gorm.DB {
+	query = query.Select("cages.*, " + occupancy + " AS occupancy")
+	if relations.Dinosaurs {
+		query = query.Preload("Dinosaurs", occupants)
+	}
+	return query
+}
+
+// scopedQuery starts a query that includes soft-deleted records if the repository is unscoped.
+func scopedQuery(ctx context.Context, db *gorm.DB, unscoped bool) *gorm.DB {
+	query := db.WithContext(ctx)
+	if unscoped {
+		query = query.Unscoped()
+	}
+	return query
+}
+
+// paginate orders the query and restricts it to the page. Records following page.After are selected
+// by comparing the sort columns one by one: (a > x) OR (a = x AND b > y) OR ..., with < for descending columns.
+// String columns are compared byte-wise, as compareSortValues does, whatever the collation of the database.
+func paginate[T any](query *gorm.DB, page Page, columns map[string]SortColumn[T]) *gorm.DB {
+	sort := pageSort(page)
+	expressions := make([]string, len(sort))
+	for i, key := range sort {
+		expressions[i] = key.Column
+		if !columns[key.Column].Numeric {
+			expressions[i] += " COLLATE " + byteWiseCollation(query)
+		}
+	}
+
+	if len(page.After) > 0 {
+		conditions := make([]string, len(sort))
+		var args []any
+		for i, key := range sort {
+			terms := make([]string, 0, i+1)
+			for j := 0; j < i; j++ {
+				terms = append(terms, expressions[j]+" = ?")
+				args = append(args, page.After[j])
+			}
+			operator := " > ?"
+			if key.Desc {
+				operator = " < ?"
+			}
+			terms = append(terms, expressions[i]+operator)
+			args = append(args, page.After[i])
+			conditions[i] = "(" + strings.Join(terms, " AND ") + ")"
+		}
+		query = query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+
+	for i, key := range sort {
+		query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: expressions[i], Raw: true}, Desc: key.Desc})
+	}
+	if page.Limit > 0 {
+		query = query.Limit(page.Limit)
+	}
+	return query
+}
+
+// byteWiseCollation returns the collation of the database comparing strings byte by byte, which orders them
+// by code point.
+func byteWiseCollation(query *gorm.DB) string {
+	if query.Dialector.Name() == "sqlite" {
+		return "BINARY"
+	}
+	return `"C"`
+}
+
+type gormCageRepository struct {
+	db        *gorm.DB
+	unscoped  bool
+	relations CageRelations
+}
+
+func (r *gormCageRepository) Unscoped() CageRepository {
+	return &gormCageRepository{db: r.db, unscoped: true, relations: r.relations}
+}
+
+func (r *gormCageRepository) Preload(relations CageRelations) CageRepository {
+	return &gormCageRepository{db: r.db, unscoped: r.unscoped, relations: relations}
+}
+
+func (r *gormCageRepository) List(ctx context.Context, filter CageFilter, page Page) ([]dbmodels.Cage, error) {
+	query := selectCages(paginate(r.filtered(ctx, filter), page, CageSortColumns), r.relations)
+
+	var cages []dbmodels.Cage
+	if err := query.Find(&cages).Error; err != nil {
+		return nil, err
+	}
+	return cages, nil
+}
+
+func (r *gormCageRepository) Count(ctx context.Context, filter CageFilter) (int, error) {
+	var count int64
+	if err := r.filtered(ctx, filter).Model(&dbmodels.Cage{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// filtered starts a query of the cages matching the filter.
+func (r *gormCageRepository) filtered(ctx context.Context, filter CageFilter) *gorm.DB {
+	query := scopedQuery(ctx, r.db, r.unscoped)
+	if len(filter.IDs) > 0 {
+		query = query.Where("id IN ?", filter.IDs)
+	}
+	if len(filter.PowerStatuses) > 0 {
+		query = query.Where("power_status IN ?", filter.PowerStatuses)
+	}
+	if filter.HasFreeCapacity != nil {
+		if *filter.HasFreeCapacity {
+			query = query.Where(occupancy + " < capacity")
+		} else {
+			query = query.Where(occupancy + " >= capacity")
+		}
+	}
+	if filter.CapacityGTE != nil {
+		query = query.Where("capacity >= ?", *filter.CapacityGTE)
+	}
+	if filter.CapacityLTE != nil {
+		query = query.Where("capacity <= ?", *filter.CapacityLTE)
+	}
+	if filter.ChangedSince != nil {
+		query = query.Where("(updated_at >= @since OR deleted_at >= @since)", sql.Named("since", filter.ChangedSince.UTC()))
+	}
+	return query
+}
+
+func (r *gormCageRepository) Get(ctx context.Context, id uint) (dbmodels.Cage, error) {
+	var cage dbmodels.Cage
+	if err := selectCages(scopedQuery(ctx, r.db, r.unscoped), r.relations).First(&cage, id).Error; err != nil {
+		return dbmodels.Cage{}, translateError(err)
+	}
+	return cage, nil
+}
+
+// GetForUpdate locks the cage row with SELECT ... FOR UPDATE. SQLite drops the locking clause,
+// there every transaction already holds the database write lock from the start.
+func (r *gormCageRepository) GetForUpdate(ctx context.Context, id uint) (dbmodels.Cage, error) {
+	var cage dbmodels.Cage
+	if err := scopedQuery(ctx, r.db, r.unscoped).Clauses(clause.Locking{Strength: "UPDATE"}).First(&cage, id).Error; err != nil {
+		return dbmodels.Cage{}, translateError(err)
+	}
+	// Dinosaurs are loaded only once the lock is held, so placements committed meanwhile are visible.
+	if err := r.db.WithContext(ctx).Where("cage_id = ?", id).Order("id").Find(&cage.Dinosaurs).Error; err != nil {
+		return dbmodels.Cage{}, err
+	}
+	cage.Occupancy = len(cage.Dinosaurs)
+	return cage, nil
+}
+
+func (r *gormCageRepository) Create(ctx context.Context, cage *dbmodels.Cage) error {
+	cage.Version, cage.OccupancyVersion = 1, 1
+	return translateError(r.db.WithContext(ctx).Omit("Dinosaurs").Create(cage).Error)
+}
+
+func (r *gormCageRepository) Update(ctx context.Context, cage *dbmodels.Cage) error {
+	updated := *cage
+	updated.Version++
+	updated.UpdatedAt = r.db.NowFunc()
+	// UpdateColumns stores UpdatedAt as set here, rather than taking the time again.
+	result := scopedQuery(ctx, r.db, r.unscoped).Model(&dbmodels.Cage{ID: cage.ID}).Where("version = ?", cage.Version).
+		Select("*").Omit("ID", "OccupancyVersion", "CreatedAt", "Dinosaurs").UpdateColumns(&updated)
+	if err := versionedUpdateResult(scopedQuery(ctx, r.db, r.unscoped), result, &dbmodels.Cage{}, cage.ID); err != nil {
+		return err
+	}
+	cage.Version = updated.Version
+	cage.UpdatedAt = updated.UpdatedAt
+	return nil
+}
+
+func (r *gormCageRepository) Touch(ctx context.Context, cage *dbmodels.Cage) error {
+	updatedAt := r.db.NowFunc()
+	result := scopedQuery(ctx, r.db, r.unscoped).Model(&dbmodels.Cage{ID: cage.ID}).
+		UpdateColumns(map[string]any{"occupancy_version": gorm.Expr("occupancy_version + 1"), "updated_at": updatedAt})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	var touched dbmodels.Cage
+	if err := scopedQuery(ctx, r.db, r.unscoped).Select("occupancy_version").First(&touched, cage.ID).Error; err != nil {
+		return translateError(err)
+	}
+	cage.OccupancyVersion = touched.OccupancyVersion
+	cage.UpdatedAt = updatedAt
+	return nil
+}
+
+func (r *gormCageRepository) Delete(ctx context.Context, id uint) error {
+	result := scopedQuery(ctx, r.db, r.unscoped).Delete(&dbmodels.Cage{}, id)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormCageRepository) LastModified(ctx context.Context, filter CageFilter) (time.Time, error) {
+	return latestTime(r.allFiltered(ctx, filter), &dbmodels.Cage{}, "updated_at", "deleted_at")
+}
+
+func (r *gormCageRepository) LastDeleted(ctx context.Context, filter CageFilter) (time.Time, error) {
+	return latestTime(r.allFiltered(ctx, filter), &dbmodels.Cage{}, "deleted_at")
+}
+
+// allFiltered starts a query of the cages matching the filter, deleted ones included.
+func (r *gormCageRepository) allFiltered(ctx context.Context, filter CageFilter) *gorm.DB {
+	return (&gormCageRepository{db: r.db, unscoped: true}).filtered(ctx, filter)
+}
+
+type gormDinosaurRepository struct {
+	db        *gorm.DB
+	unscoped  bool
+	relations DinosaurRelations
+}
+
+func (r *gormDinosaurRepository) Unscoped() DinosaurRepository {
+	return &gormDinosaurRepository{db: r.db, unscoped: true, relations: r.relations}
+}
+
+func (r *gormDinosaurRepository) Preload(relations DinosaurRelations) DinosaurRepository {
+	return &gormDinosaurRepository{db: r.db, unscoped: r.unscoped, relations: relations}
+}
+
+// preloaded preloads the requested relations of the dinosaurs. All cages of the page are loaded by a single query.
+func (r *gormDinosaurRepository) preloaded(query *gorm.DB) *gorm.DB {
+	if r.relations.Cage == nil {
+		return query
+	}
+	return query.Preload("Cage", func(cages *gorm.DB) *gorm.DB {
+		return selectCages(cages.Unscoped(), *r.relations.Cage)
+	})
+}
+
+func (r *gormDinosaurRepository) List(ctx context.Context, filter DinosaurFilter, page Page) ([]dbmodels.Dinosaur, error) {
+	var dinosaurs []dbmodels.Dinosaur
+	if err := r.preloaded(paginate(r.filtered(ctx, filter), page, DinosaurSortColumns)).Find(&dinosaurs).Error; err != nil {
+		return nil, err
+	}
+	return dinosaurs, nil
+}
+
+func (r *gormDinosaurRepository) Count(ctx context.Context, filter DinosaurFilter) (int, error) {
+	var count int64
+	if err := r.filtered(ctx, filter).Model(&dbmodels.Dinosaur{}).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// filtered starts a query of the dinosaurs matching the filter.
+func (r *gormDinosaurRepository) filtered(ctx context.Context, filter DinosaurFilter) *gorm.DB {
+	query := scopedQuery(ctx, r.db, r.unscoped)
+	if len(filter.Species) > 0 {
+		query = query.Where("species IN ?", filter.Species)
+	}
+	if len(filter.Types) > 0 {
+		query = query.Where("type IN ?", filter.Types)
+	}
+	if len(filter.CageIDs) > 0 {
+		query = query.Where("cage_id IN ?", filter.CageIDs)
+	}
+	if len(filter.NamePrefixes) > 0 {
+		// Compared with substr rather than LIKE, which is case-insensitive on SQLite and would need escaping.
+		conditions := make([]string, len(filter.NamePrefixes))
+		args := make([]any, 0, 2*len(filter.NamePrefixes))
+		for i, prefix := range filter.NamePrefixes {
+			conditions[i] = "substr(name, 1, ?) = ?"
+			args = append(args, utf8.RuneCountInString(prefix), prefix)
+		}
+		query = query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+	}
+	return query
+}
+
+func (r *gormDinosaurRepository) Get(ctx context.Context, id uint) (dbmodels.Dinosaur, error) {
+	var dinosaur dbmodels.Dinosaur
+	if err := r.preloaded(scopedQuery(ctx, r.db, r.unscoped)).First(&dinosaur, id).Error; err != nil {
+		return dbmodels.Dinosaur{}, translateError(err)
+	}
+	return dinosaur, nil
+}
+
+func (r *gormDinosaurRepository) GetForUpdate(ctx context.Context, id uint) (dbmodels.Dinosaur, error) {
+	var dinosaur dbmodels.Dinosaur
+	if err := scopedQuery(ctx, r.db, r.unscoped).Clauses(clause.Locking{Strength: "UPDATE"}).First(&dinosaur, id).Error; err != nil {
+		return dbmodels.Dinosaur{}, translateError(err)
+	}
+	return dinosaur, nil
+}
+
+func (r *gormDinosaurRepository) Create(ctx context.Context, dinosaur *dbmodels.Dinosaur) error {
+	dinosaur.Version = 1
+	return translateError(r.db.WithContext(ctx).Omit("Cage").Create(dinosaur).Error)
+}
+
+func (r *gormDinosaurRepository) Update(ctx context.Context, dinosaur *dbmodels.Dinosaur) error {
+	updated := *dinosaur
+	updated.Version++
+	updated.UpdatedAt = r.db.NowFunc()
+	result := scopedQuery(ctx, r.db, r.unscoped).Model(&dbmodels.Dinosaur{ID: dinosaur.ID}).Where("version = ?", dinosaur.Version).
+		Select("*").Omit("ID", "CreatedAt", "Cage").UpdateColumns(&updated)
+	if err := versionedUpdateResult(scopedQuery(ctx, r.db, r.unscoped), result, &dbmodels.Dinosaur{}, dinosaur.ID); err != nil {
+		return err
+	}
+	dinosaur.Version = updated.Version
+	dinosaur.UpdatedAt = updated.UpdatedAt
+	return nil
+}
+
+// Move defers the placement checks until all dinosaurs have been updated. Postgres defers its constraint triggers,
+// while SQLite stages the dinosaurs, whose placement is checked as they leave the stage.
+func (r *gormDinosaurRepository) Move(ctx context.Context, dinosaurs []*dbmodels.Dinosaur) error {
+	ids := make([]uint, len(dinosaurs))
+	for i, dinosaur := range dinosaurs {
+		ids[i] = dinosaur.ID
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if tx.Dialector.Name() == "sqlite" {
+			return moveWithoutTrigger(ctx, tx, r.unscoped, dinosaurs, ids)
+		}
+
+		if err := tx.Exec("SET CONSTRAINTS " + deferredPlacementChecks + " DEFERRED").Error; err != nil {
+			return translateError(err)
+		}
+		moves := &gormDinosaurRepository{db: tx, unscoped: r.unscoped}
+		for _, dinosaur := range dinosaurs {
+			if err := moves.Update(ctx, dinosaur); err != nil {
+				return err
+			}
+		}
+		return translateError(tx.Exec("SET CONSTRAINTS " + deferredPlacementChecks + " IMMEDIATE").Error)
+	})
+}
+
+// deferredPlacementChecks are the Postgres constraint triggers deferred by Move.
+const deferredPlacementChecks = "dinosaurs_enforce_placement, cages_enforce_capacity"
+
+// sqlitePlacementTrigger is the SQLite trigger checking each dinosaur as it is placed, dropped by Move
+// until all of its dinosaurs have been stored.
+const sqlitePlacementTrigger = "dinosaurs_enforce_placement_update"
+
+// moveWithoutTrigger stores the dinosaurs with the placement trigger dropped and created again from its stored
+// definition, and then checks them against dinosaur_placement_violations, within the transaction holding the
+// SQLite write lock. Power and capacity are only checked for dinosaurs placed into another cage.
+func moveWithoutTrigger(ctx context.Context, tx *gorm.DB, unscoped bool, dinosaurs []*dbmodels.Dinosaur, ids []uint) error {
+	var trigger string
+	if err := tx.Raw("SELECT sql FROM sqlite_master WHERE type = 'trigger' AND name = ?", sqlitePlacementTrigger).Scan(&trigger).Error; err != nil {
+		return err
+	}
+	var stored []dbmodels.Dinosaur
+	if err := tx.Unscoped().Select("id", "cage_id").Where("id IN ?", ids).Find(&stored).Error; err != nil {
+		return err
+	}
+	placed := []uint{}
+	for _, dinosaur := range dinosaurs {
+		for _, previous := range stored {
+			if previous.ID == dinosaur.ID && previous.CageID != dinosaur.CageID {
+				placed = append(placed, dinosaur.ID)
+			}
+		}
+	}
+
+	if err := tx.Exec("DROP TRIGGER " + sqlitePlacementTrigger).Error; err != nil {
+		return err
+	}
+	moves := &gormDinosaurRepository{db: tx, unscoped: unscoped}
+	for _, dinosaur := range dinosaurs {
+		if err := moves.Update(ctx, dinosaur); err != nil {
+			return err
+		}
+	}
+	if err := tx.Exec(trigger).Error; err != nil {
+		return err
+	}
+
+	var violation struct {
+		DinosaurID     uint
+		ConstraintName string
+	}
+	err := tx.Raw(`SELECT dinosaur_id, constraint_name FROM dinosaur_placement_violations
+		WHERE dinosaur_id IN @ids AND (dinosaur_id IN @placed OR constraint_name NOT IN @placedOnly)
+		ORDER BY rule LIMIT 1`,
+		sql.Named("ids", ids), sql.Named("placed", placed),
+		sql.Named("placedOnly", []string{ConstraintCagePowered, ConstraintCageCapacity})).Scan(&violation).Error
+	if err != nil {
+		return err
+	}
+	if violation.ConstraintName != "" {
+		return &ConstraintError{
+			Constraint: violation.ConstraintName,
+			Err:        fmt.Errorf("dinosaur %d violates %s once moved", violation.DinosaurID, violation.ConstraintName),
+		}
+	}
+	return nil
+}
+
+func (r *gormDinosaurRepository) Delete(ctx context.Context, id uint) error {
+	result := scopedQuery(ctx, r.db, r.unscoped).Delete(&dbmodels.Dinosaur{}, id)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormDinosaurRepository) LastModified(ctx context.Context, filter DinosaurFilter) (time.Time, error) {
+	return latestTime(r.allFiltered(ctx, filter), &dbmodels.Dinosaur{}, "updated_at", "deleted_at")
+}
+
+func (r *gormDinosaurRepository) LastDeleted(ctx context.Context, filter DinosaurFilter) (time.Time, error) {
+	return latestTime(r.allFiltered(ctx, filter), &dbmodels.Dinosaur{}, "deleted_at")
+}
+
+// allFiltered starts a query of the dinosaurs matching the filter, deleted ones included.
+func (r *gormDinosaurRepository) allFiltered(ctx context.Context, filter DinosaurFilter) *gorm.DB {
+	return (&gormDinosaurRepository{db: r.db, unscoped: true}).filtered(ctx, filter)
+}
+
+// latestTime returns the latest time held by any of the timestamp columns of the records selected by the query.
+// The columns are read as they are, since SQLite returns aggregates of timestamps as plain text.
+func latestTime(query *gorm.DB, model any, columns ...string) (time.Time, error) {
+	var latest time.Time
+	for _, column := range columns {
+		var times []time.Time
+		err := query.Session(&gorm.Session{}).Model(model).Where(column+" IS NOT NULL").
+			Order(column+" DESC").Limit(1).Pluck(column, &times).Error
+		if err != nil {
+			return time.Time{}, err
+		}
+		if len(times) > 0 && times[0].After(latest) {
+			latest = times[0]
+		}
+	}
+	return latest, nil
+}
+
+type gormHistoryRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormHistoryRepository) Append(ctx context.Context, entry *dbmodels.HistoryEntry) error {
+	return translateError(r.db.WithContext(ctx).Create(entry).Error)
+}
+
+func (r *gormHistoryRepository) List(ctx context.Context, query HistoryQuery) ([]dbmodels.HistoryEntry, error) {
+	var entries []dbmodels.HistoryEntry
+	err := r.db.WithContext(ctx).
+		Where("entity_type = ? AND entity_id = ? AND id > ?", query.EntityType, query.EntityID, query.AfterID).
+		Order("id").Limit(query.Limit).Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+type gormIdempotencyRepository struct {
+	db *gorm.DB
+}
+
+func (r *gormIdempotencyRepository) Get(ctx context.Context, scope string, key string) (dbmodels.IdempotencyKey, error) {
+	var stored dbmodels.IdempotencyKey
+	if err := r.db.WithContext(ctx).Where(&dbmodels.IdempotencyKey{Scope: scope, Key: key}).First(&stored).Error; err != nil {
+		return dbmodels.IdempotencyKey{}, translateError(err)
+	}
+	return stored, nil
+}
+
+// Create skips keys stored before rather than failing on the primary key, which would abort
+// the surrounding transaction on Postgres.
+func (r *gormIdempotencyRepository) Create(ctx context.Context, key *dbmodels.IdempotencyKey) error {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlreadyExists
+	}
+	return nil
+}
+
+func (r *gormIdempotencyRepository) Update(ctx context.Context, key *dbmodels.IdempotencyKey) error {
+	result := r.db.WithContext(ctx).Model(&dbmodels.IdempotencyKey{}).Where(&dbmodels.IdempotencyKey{Scope: key.Scope, Key: key.Key}).
+		Select("*").Omit("Scope", "Key", "CreatedAt").Updates(key)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Replace tells the stored key apart from those stored later by its creation time.
+func (r *gormIdempotencyRepository) Replace(ctx context.Context, stored dbmodels.IdempotencyKey, key *dbmodels.IdempotencyKey) error {
+	result := r.db.WithContext(ctx).Model(&dbmodels.IdempotencyKey{}).
+		Where(&dbmodels.IdempotencyKey{Scope: stored.Scope, Key: stored.Key}).Where("created_at = ?", stored.CreatedAt).
+		Select("*").Omit("Scope", "Key").Updates(key)
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *gormIdempotencyRepository) Delete(ctx context.Context, scope string, key string) error {
+	result := r.db.WithContext(ctx).Where(&dbmodels.IdempotencyKey{Scope: scope, Key: key}).Delete(&dbmodels.IdempotencyKey{})
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// versionedUpdateResult tells apart updates that matched no row because the record is gone
+// from those that lost a race against a concurrent change.
+func versionedUpdateResult(db *gorm.DB, result *gorm.DB, model any, id uint) error {
+	if result.Error != nil {
+		return translateError(result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	var count int64
+	if err := db.Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
+	}
+	return ErrVersionConflict
+}
+
+// integrityConstraintViolation is the Postgres error class of all constraint violations.
+const integrityConstraintViolation = "23"
+
+// SQLite reports constraint names only inside the error message.
+const (
+	sqliteCheckPrefix        = "CHECK constraint failed: "
+	sqliteForeignKeyViolated = "FOREIGN KEY constraint failed"
+)
+
+func translateError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && strings.HasPrefix(pgErr.Code, integrityConstraintViolation) && pgErr.ConstraintName != "" {
+		return &ConstraintError{Constraint: pgErr.ConstraintName, Err: err}
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrConstraint {
+		if constraint := sqliteConstraintName(sqliteErr); constraint != "" {
+			return &ConstraintError{Constraint: constraint, Err: err}
+		}
+	}
+	return err
+}
+
+// sqliteConstraintName recovers the constraint name from a SQLite constraint violation.
+// Triggers raise the bare constraint name, while foreign key violations carry no name at all
+// and are reported as trigger errors when caused by ON DELETE RESTRICT.
+func sqliteConstraintName(err sqlite3.Error) string {
+	switch {
+	case err.Error() == sqliteForeignKeyViolated:
+		return ConstraintDinosaurCage
+	case err.ExtendedCode == sqlite3.ErrConstraintCheck:
+		return strings.TrimPrefix(err.Error(), sqliteCheckPrefix)
+	case err.ExtendedCode == sqlite3.ErrConstraintTrigger:
+		return err.Error()
+	default:
+		return ""
+	}
+}
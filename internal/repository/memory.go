@@ -0,0 +1,633 @@
+package repository
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+
+	"gorm.io/gorm"
+)
+
+// MemoryStore is a thread-safe Store keeping all records in memory.
+// Used by tests and local experiments where no database is available.
+// Transactions hold the store lock exclusively, which serializes them with every other access.
+type MemoryStore struct {
+	mu   *sync.RWMutex
+	data *memoryData
+	// inTransaction is set on stores handed to Transaction callbacks, which already hold the lock.
+	inTransaction bool
+}
+
+type memoryData struct {
+	cages          map[uint]dbmodels.Cage
+	dinosaurs      map[uint]dbmodels.Dinosaur
+	history        []dbmodels.HistoryEntry
+	idempotency    map[idempotencyID]dbmodels.IdempotencyKey
+	lastCageID     uint
+	lastDinosaurID uint
+}
+
+func (d *memoryData) clone() *memoryData {
+	return &memoryData{
+		cages:          maps.Clone(d.cages),
+		dinosaurs:      maps.Clone(d.dinosaurs),
+		history:        slices.Clone(d.history),
+		idempotency:    maps.Clone(d.idempotency),
+		lastCageID:     d.lastCageID,
+		lastDinosaurID: d.lastDinosaurID,
+	}
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		mu: &sync.RWMutex{},
+		data: &memoryData{
+			cages:       map[uint]dbmodels.Cage{},
+			dinosaurs:   map[uint]dbmodels.Dinosaur{},
+			idempotency: map[idempotencyID]dbmodels.IdempotencyKey{},
+		},
+	}
+}
+
+func (s *MemoryStore) Cages() CageRepository {
+	return &memoryCageRepository{store: s, relations: CageRelations{Dinosaurs: true}}
+}
+
+func (s *MemoryStore) Dinosaurs() DinosaurRepository {
+	return &memoryDinosaurRepository{store: s}
+}
+
+func (s *MemoryStore) History() HistoryRepository {
+	return &memoryHistoryRepository{store: s}
+}
+
+func (s *MemoryStore) IdempotencyKeys() IdempotencyRepository {
+	return &memoryIdempotencyRepository{store: s}
+}
+
+func (s *MemoryStore) Transaction(_ context.Context, fn func(tx Store) error) error {
+	if s.inTransaction {
+		return fn(s)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot := s.data.clone()
+	if err := fn(&MemoryStore{mu: s.mu, data: s.data, inTransaction: true}); err != nil {
+		*s.data = *snapshot
+		return err
+	}
+	return nil
+}
+
+func (s *MemoryStore) readLock() func() {
+	if s.inTransaction {
+		return func() {}
+	}
+	s.mu.RLock()
+	return s.mu.RUnlock
+}
+
+func (s *MemoryStore) writeLock() func() {
+	if s.inTransaction {
+		return func() {}
+	}
+	s.mu.Lock()
+	return s.mu.Unlock
+}
+
+// cageWithRelations returns a copy of the cage with its occupancy and the requested relations attached.
+// Its dinosaurs leave out deleted ones. Callers must hold the store lock.
+func (s *MemoryStore) cageWithRelations(cage dbmodels.Cage, relations CageRelations) dbmodels.Cage {
+	dinosaurs := []dbmodels.Dinosaur{}
+	for _, dinosaur := range s.sortedDinosaurs() {
+		if dinosaur.CageID == cage.ID && !dinosaur.DeletedAt.Valid {
+			dinosaurs = append(dinosaurs, dinosaur)
+		}
+	}
+	cage.Occupancy = len(dinosaurs)
+	cage.Dinosaurs = nil
+	if relations.Dinosaurs {
+		cage.Dinosaurs = dinosaurs
+	}
+	return cage
+}
+
+// dinosaurWithRelations returns a copy of the dinosaur with the requested relations attached.
+// Callers must hold the store lock.
+func (s *MemoryStore) dinosaurWithRelations(dinosaur dbmodels.Dinosaur, relations DinosaurRelations) dbmodels.Dinosaur {
+	dinosaur.Cage = nil
+	if cage, ok := s.data.cages[dinosaur.CageID]; ok && relations.Cage != nil {
+		cage = s.cageWithRelations(cage, *relations.Cage)
+		dinosaur.Cage = &cage
+	}
+	return dinosaur
+}
+
+func (s *MemoryStore) sortedCages() []dbmodels.Cage {
+	cages := make([]dbmodels.Cage, 0, len(s.data.cages))
+	for _, cage := range s.data.cages {
+		cages = append(cages, cage)
+	}
+	sort.Slice(cages, func(i, j int) bool { return cages[i].ID < cages[j].ID })
+	return cages
+}
+
+func (s *MemoryStore) sortedDinosaurs() []dbmodels.Dinosaur {
+	dinosaurs := make([]dbmodels.Dinosaur, 0, len(s.data.dinosaurs))
+	for _, dinosaur := range s.data.dinosaurs {
+		dinosaurs = append(dinosaurs, dinosaur)
+	}
+	sort.Slice(dinosaurs, func(i, j int) bool { return dinosaurs[i].ID < dinosaurs[j].ID })
+	return dinosaurs
+}
+
+// softDelete returns the deletion mark set by Delete on scoped repositories.
+func softDelete() gorm.DeletedAt {
+	return gorm.DeletedAt{Time: time.Now().UTC(), Valid: true}
+}
+
+// createdNow fills in the creation and modification times of a new record, unless they have been set already.
+func createdNow(createdAt time.Time, updatedAt time.Time) (time.Time, time.Time) {
+	now := time.Now().UTC()
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	if updatedAt.IsZero() {
+		updatedAt = now
+	}
+	return createdAt, updatedAt
+}
+
+type memoryCageRepository struct {
+	store     *MemoryStore
+	unscoped  bool
+	relations CageRelations
+}
+
+func (r *memoryCageRepository) Unscoped() CageRepository {
+	return &memoryCageRepository{store: r.store, unscoped: true, relations: r.relations}
+}
+
+func (r *memoryCageRepository) Preload(relations CageRelations) CageRepository {
+	return &memoryCageRepository{store: r.store, unscoped: r.unscoped, relations: relations}
+}
+
+// get returns the stored cage, if it is visible to the repository. Callers must hold the store lock.
+func (r *memoryCageRepository) get(id uint) (dbmodels.Cage, bool) {
+	cage, ok := r.store.data.cages[id]
+	return cage, ok && (r.unscoped || !cage.DeletedAt.Valid)
+}
+
+func (r *memoryCageRepository) List(_ context.Context, filter CageFilter, page Page) ([]dbmodels.Cage, error) {
+	defer r.store.readLock()()
+
+	return paginateRecords(r.filtered(filter), page, CageSortColumns), nil
+}
+
+func (r *memoryCageRepository) Count(_ context.Context, filter CageFilter) (int, error) {
+	defer r.store.readLock()()
+
+	return len(r.filtered(filter)), nil
+}
+
+// filtered returns the cages matching the filter. Callers must hold the store lock.
+func (r *memoryCageRepository) filtered(filter CageFilter) []dbmodels.Cage {
+	cages := []dbmodels.Cage{}
+	for _, cage := range r.store.sortedCages() {
+		if cage.DeletedAt.Valid && !r.unscoped {
+			continue
+		}
+		cage = r.store.cageWithRelations(cage, r.relations)
+		if !matchesCageFilter(cage, filter) {
+			continue
+		}
+		cages = append(cages, cage)
+	}
+	return cages
+}
+
+// paginateRecords sorts the records and returns those on the page.
+func paginateRecords[T any](records []T, page Page, columns map[string]SortColumn[T]) []T {
+	sort := pageSort(page)
+	slices.SortStableFunc(records, func(a, b T) int {
+		return compareSortValues(SortValues(columns, a, sort), SortValues(columns, b, sort), sort)
+	})
+
+	if len(page.After) > 0 {
+		start := len(records)
+		for i, record := range records {
+			if compareSortValues(SortValues(columns, record, sort), page.After, sort) > 0 {
+				start = i
+				break
+			}
+		}
+		records = records[start:]
+	}
+	if page.Limit > 0 && len(records) > page.Limit {
+		records = records[:page.Limit]
+	}
+	return records
+}
+
+// matchesCageFilter expects the cage with its occupancy attached.
+func matchesCageFilter(cage dbmodels.Cage, filter CageFilter) bool {
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, cage.ID) {
+		return false
+	}
+	if len(filter.PowerStatuses) > 0 && !slices.Contains(filter.PowerStatuses, cage.PowerStatus) {
+		return false
+	}
+	if filter.HasFreeCapacity != nil && (cage.Occupancy < cage.Capacity) != *filter.HasFreeCapacity {
+		return false
+	}
+	if filter.CapacityGTE != nil && cage.Capacity < *filter.CapacityGTE {
+		return false
+	}
+	if filter.CapacityLTE != nil && cage.Capacity > *filter.CapacityLTE {
+		return false
+	}
+	if filter.ChangedSince != nil && cage.UpdatedAt.Before(*filter.ChangedSince) &&
+		!(cage.DeletedAt.Valid && !cage.DeletedAt.Time.Before(*filter.ChangedSince)) {
+		return false
+	}
+	return true
+}
+
+func (r *memoryCageRepository) Get(_ context.Context, id uint) (dbmodels.Cage, error) {
+	defer r.store.readLock()()
+
+	cage, ok := r.get(id)
+	if !ok {
+		return dbmodels.Cage{}, ErrNotFound
+	}
+	return r.store.cageWithRelations(cage, r.relations), nil
+}
+
+// GetForUpdate relies on the exclusive lock held by the surrounding transaction.
+func (r *memoryCageRepository) GetForUpdate(ctx context.Context, id uint) (dbmodels.Cage, error) {
+	return r.Preload(CageRelations{Dinosaurs: true}).Get(ctx, id)
+}
+
+func (r *memoryCageRepository) Create(_ context.Context, cage *dbmodels.Cage) error {
+	defer r.store.writeLock()()
+
+	r.store.data.lastCageID++
+	cage.ID = r.store.data.lastCageID
+	cage.Version, cage.OccupancyVersion = 1, 1
+	cage.CreatedAt, cage.UpdatedAt = createdNow(cage.CreatedAt, cage.UpdatedAt)
+	stored := *cage
+	stored.Dinosaurs = nil
+	stored.Occupancy = 0
+	r.store.data.cages[cage.ID] = stored
+	return nil
+}
+
+func (r *memoryCageRepository) Update(_ context.Context, cage *dbmodels.Cage) error {
+	defer r.store.writeLock()()
+
+	current, ok := r.get(cage.ID)
+	if !ok {
+		return ErrNotFound
+	}
+	if current.Version != cage.Version {
+		return ErrVersionConflict
+	}
+	cage.Version++
+	cage.OccupancyVersion = current.OccupancyVersion
+	cage.CreatedAt = current.CreatedAt
+	cage.UpdatedAt = time.Now().UTC()
+	stored := *cage
+	stored.Dinosaurs = nil
+	stored.Occupancy = 0
+	r.store.data.cages[cage.ID] = stored
+	return nil
+}
+
+func (r *memoryCageRepository) Touch(_ context.Context, cage *dbmodels.Cage) error {
+	defer r.store.writeLock()()
+
+	current, ok := r.get(cage.ID)
+	if !ok {
+		return ErrNotFound
+	}
+	current.OccupancyVersion++
+	current.UpdatedAt = time.Now().UTC()
+	r.store.data.cages[cage.ID] = current
+	cage.OccupancyVersion, cage.UpdatedAt = current.OccupancyVersion, current.UpdatedAt
+	return nil
+}
+
+func (r *memoryCageRepository) Delete(_ context.Context, id uint) error {
+	defer r.store.writeLock()()
+
+	cage, ok := r.get(id)
+	if !ok {
+		return ErrNotFound
+	}
+	if r.unscoped {
+		delete(r.store.data.cages, id)
+		return nil
+	}
+	cage.DeletedAt = softDelete()
+	r.store.data.cages[id] = cage
+	return nil
+}
+
+func (r *memoryCageRepository) LastModified(_ context.Context, filter CageFilter) (time.Time, error) {
+	defer r.store.readLock()()
+
+	var latest time.Time
+	for _, cage := range r.allFiltered(filter) {
+		if cage.LastModified().After(latest) {
+			latest = cage.LastModified()
+		}
+	}
+	return latest, nil
+}
+
+func (r *memoryCageRepository) LastDeleted(_ context.Context, filter CageFilter) (time.Time, error) {
+	defer r.store.readLock()()
+
+	var latest time.Time
+	for _, cage := range r.allFiltered(filter) {
+		if cage.DeletedAt.Valid && cage.DeletedAt.Time.After(latest) {
+			latest = cage.DeletedAt.Time
+		}
+	}
+	return latest, nil
+}
+
+// allFiltered returns the cages matching the filter, deleted ones included. Callers must hold the store lock.
+func (r *memoryCageRepository) allFiltered(filter CageFilter) []dbmodels.Cage {
+	return (&memoryCageRepository{store: r.store, unscoped: true}).filtered(filter)
+}
+
+type memoryDinosaurRepository struct {
+	store     *MemoryStore
+	unscoped  bool
+	relations DinosaurRelations
+}
+
+func (r *memoryDinosaurRepository) Unscoped() DinosaurRepository {
+	return &memoryDinosaurRepository{store: r.store, unscoped: true, relations: r.relations}
+}
+
+func (r *memoryDinosaurRepository) Preload(relations DinosaurRelations) DinosaurRepository {
+	return &memoryDinosaurRepository{store: r.store, unscoped: r.unscoped, relations: relations}
+}
+
+// get returns the stored dinosaur, if it is visible to the repository. Callers must hold the store lock.
+func (r *memoryDinosaurRepository) get(id uint) (dbmodels.Dinosaur, bool) {
+	dinosaur, ok := r.store.data.dinosaurs[id]
+	return dinosaur, ok && (r.unscoped || !dinosaur.DeletedAt.Valid)
+}
+
+func (r *memoryDinosaurRepository) List(_ context.Context, filter DinosaurFilter, page Page) ([]dbmodels.Dinosaur, error) {
+	defer r.store.readLock()()
+
+	return paginateRecords(r.filtered(filter), page, DinosaurSortColumns), nil
+}
+
+func (r *memoryDinosaurRepository) Count(_ context.Context, filter DinosaurFilter) (int, error) {
+	defer r.store.readLock()()
+
+	return len(r.filtered(filter)), nil
+}
+
+// filtered returns the dinosaurs matching the filter. Callers must hold the store lock.
+func (r *memoryDinosaurRepository) filtered(filter DinosaurFilter) []dbmodels.Dinosaur {
+	dinosaurs := []dbmodels.Dinosaur{}
+	for _, dinosaur := range r.store.sortedDinosaurs() {
+		if dinosaur.DeletedAt.Valid && !r.unscoped {
+			continue
+		}
+		if !matchesDinosaurFilter(dinosaur, filter) {
+			continue
+		}
+		dinosaurs = append(dinosaurs, r.store.dinosaurWithRelations(dinosaur, r.relations))
+	}
+	return dinosaurs
+}
+
+func matchesDinosaurFilter(dinosaur dbmodels.Dinosaur, filter DinosaurFilter) bool {
+	if len(filter.Species) > 0 && !slices.Contains(filter.Species, dinosaur.Species) {
+		return false
+	}
+	if len(filter.Types) > 0 && !slices.Contains(filter.Types, dinosaur.Type) {
+		return false
+	}
+	if len(filter.CageIDs) > 0 && !slices.Contains(filter.CageIDs, dinosaur.CageID) {
+		return false
+	}
+	if len(filter.NamePrefixes) > 0 && !slices.ContainsFunc(filter.NamePrefixes, func(prefix string) bool {
+		return strings.HasPrefix(dinosaur.Name, prefix)
+	}) {
+		return false
+	}
+	return true
+}
+
+func (r *memoryDinosaurRepository) Get(_ context.Context, id uint) (dbmodels.Dinosaur, error) {
+	defer r.store.readLock()()
+
+	dinosaur, ok := r.get(id)
+	if !ok {
+		return dbmodels.Dinosaur{}, ErrNotFound
+	}
+	return r.store.dinosaurWithRelations(dinosaur, r.relations), nil
+}
+
+// GetForUpdate relies on the exclusive lock held by the surrounding transaction.
+func (r *memoryDinosaurRepository) GetForUpdate(ctx context.Context, id uint) (dbmodels.Dinosaur, error) {
+	return r.Get(ctx, id)
+}
+
+func (r *memoryDinosaurRepository) Create(_ context.Context, dinosaur *dbmodels.Dinosaur) error {
+	defer r.store.writeLock()()
+
+	r.store.data.lastDinosaurID++
+	dinosaur.ID = r.store.data.lastDinosaurID
+	dinosaur.Version = 1
+	dinosaur.CreatedAt, dinosaur.UpdatedAt = createdNow(dinosaur.CreatedAt, dinosaur.UpdatedAt)
+	stored := *dinosaur
+	stored.Cage = nil
+	r.store.data.dinosaurs[dinosaur.ID] = stored
+	return nil
+}
+
+func (r *memoryDinosaurRepository) Update(_ context.Context, dinosaur *dbmodels.Dinosaur) error {
+	defer r.store.writeLock()()
+
+	current, ok := r.get(dinosaur.ID)
+	if !ok {
+		return ErrNotFound
+	}
+	if current.Version != dinosaur.Version {
+		return ErrVersionConflict
+	}
+	dinosaur.Version++
+	dinosaur.CreatedAt = current.CreatedAt
+	dinosaur.UpdatedAt = time.Now().UTC()
+	stored := *dinosaur
+	stored.Cage = nil
+	r.store.data.dinosaurs[dinosaur.ID] = stored
+	return nil
+}
+
+// Move updates the dinosaurs one by one, the memory store checks no placement rules of its own.
+func (r *memoryDinosaurRepository) Move(ctx context.Context, dinosaurs []*dbmodels.Dinosaur) error {
+	for _, dinosaur := range dinosaurs {
+		if err := r.Update(ctx, dinosaur); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *memoryDinosaurRepository) Delete(_ context.Context, id uint) error {
+	defer r.store.writeLock()()
+
+	dinosaur, ok := r.get(id)
+	if !ok {
+		return ErrNotFound
+	}
+	if r.unscoped {
+		delete(r.store.data.dinosaurs, id)
+		return nil
+	}
+	dinosaur.DeletedAt = softDelete()
+	r.store.data.dinosaurs[id] = dinosaur
+	return nil
+}
+
+func (r *memoryDinosaurRepository) LastModified(_ context.Context, filter DinosaurFilter) (time.Time, error) {
+	defer r.store.readLock()()
+
+	var latest time.Time
+	for _, dinosaur := range r.allFiltered(filter) {
+		if dinosaur.LastModified().After(latest) {
+			latest = dinosaur.LastModified()
+		}
+	}
+	return latest, nil
+}
+
+func (r *memoryDinosaurRepository) LastDeleted(_ context.Context, filter DinosaurFilter) (time.Time, error) {
+	defer r.store.readLock()()
+
+	var latest time.Time
+	for _, dinosaur := range r.allFiltered(filter) {
+		if dinosaur.DeletedAt.Valid && dinosaur.DeletedAt.Time.After(latest) {
+			latest = dinosaur.DeletedAt.Time
+		}
+	}
+	return latest, nil
+}
+
+// allFiltered returns the dinosaurs matching the filter, deleted ones included. Callers must hold the store lock.
+func (r *memoryDinosaurRepository) allFiltered(filter DinosaurFilter) []dbmodels.Dinosaur {
+	return (&memoryDinosaurRepository{store: r.store, unscoped: true}).filtered(filter)
+}
+
+type memoryHistoryRepository struct {
+	store *MemoryStore
+}
+
+func (r *memoryHistoryRepository) Append(_ context.Context, entry *dbmodels.HistoryEntry) error {
+	defer r.store.writeLock()()
+
+	entry.ID = uint(len(r.store.data.history)) + 1
+	r.store.data.history = append(r.store.data.history, *entry)
+	return nil
+}
+
+func (r *memoryHistoryRepository) List(_ context.Context, query HistoryQuery) ([]dbmodels.HistoryEntry, error) {
+	defer r.store.readLock()()
+
+	entries := []dbmodels.HistoryEntry{}
+	for _, entry := range r.store.data.history {
+		if len(entries) == query.Limit {
+			break
+		}
+		if entry.EntityType == query.EntityType && entry.EntityID == query.EntityID && entry.ID > query.AfterID {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// idempotencyID is the primary key of idempotency keys.
+type idempotencyID struct {
+	scope string
+	key   string
+}
+
+type memoryIdempotencyRepository struct {
+	store *MemoryStore
+}
+
+func (r *memoryIdempotencyRepository) Get(_ context.Context, scope string, key string) (dbmodels.IdempotencyKey, error) {
+	defer r.store.readLock()()
+
+	stored, ok := r.store.data.idempotency[idempotencyID{scope: scope, key: key}]
+	if !ok {
+		return dbmodels.IdempotencyKey{}, ErrNotFound
+	}
+	return stored, nil
+}
+
+func (r *memoryIdempotencyRepository) Create(_ context.Context, key *dbmodels.IdempotencyKey) error {
+	defer r.store.writeLock()()
+
+	id := idempotencyID{scope: key.Scope, key: key.Key}
+	if _, ok := r.store.data.idempotency[id]; ok {
+		return ErrAlreadyExists
+	}
+	r.store.data.idempotency[id] = *key
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Update(_ context.Context, key *dbmodels.IdempotencyKey) error {
+	defer r.store.writeLock()()
+
+	id := idempotencyID{scope: key.Scope, key: key.Key}
+	stored, ok := r.store.data.idempotency[id]
+	if !ok {
+		return ErrNotFound
+	}
+	updated := *key
+	updated.CreatedAt = stored.CreatedAt
+	r.store.data.idempotency[id] = updated
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Replace(_ context.Context, stored dbmodels.IdempotencyKey, key *dbmodels.IdempotencyKey) error {
+	defer r.store.writeLock()()
+
+	id := idempotencyID{scope: stored.Scope, key: stored.Key}
+	current, ok := r.store.data.idempotency[id]
+	if !ok || !current.CreatedAt.Equal(stored.CreatedAt) {
+		return ErrNotFound
+	}
+	r.store.data.idempotency[id] = *key
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Delete(_ context.Context, scope string, key string) error {
+	defer r.store.writeLock()()
+
+	id := idempotencyID{scope: scope, key: key}
+	if _, ok := r.store.data.idempotency[id]; !ok {
+		return ErrNotFound
+	}
+	delete(r.store.data.idempotency, id)
+	return nil
+}
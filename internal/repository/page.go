@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"strings"
+
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+)
+
+// SortKey orders a list by a single column.
+type SortKey struct {
+	Column string
+	Desc   bool
+}
+
+// Page selects a part of a sorted list. The zero Page selects the whole list ordered by ID.
+type Page struct {
+	// Sort must end with the id column, which makes the order total.
+	Sort []SortKey
+	// After holds the values of the Sort columns of the last record of the previous page,
+	// as returned by SortValues. The page starts with the first record following them.
+	After []any
+	// Limit is the maximum number of records returned, 0 for no limit.
+	Limit int
+}
+
+// SortColumn is a column lists can be sorted by.
+type SortColumn[T any] struct {
+	// Numeric columns hold int64 values, all others hold strings.
+	Numeric bool
+	Value   func(T) any
+}
+
+// CageSortColumns are the columns cage lists can be sorted by.
+var CageSortColumns = map[string]SortColumn[dbmodels.Cage]{
+	"id":           {Numeric: true, Value: func(cage dbmodels.Cage) any { return int64(cage.ID) }},
+	"capacity":     {Numeric: true, Value: func(cage dbmodels.Cage) any { return int64(cage.Capacity) }},
+	"power_status": {Value: func(cage dbmodels.Cage) any { return cage.PowerStatus }},
+}
+
+// DinosaurSortColumns are the columns dinosaur lists can be sorted by.
+var DinosaurSortColumns = map[string]SortColumn[dbmodels.Dinosaur]{
+	"id":      {Numeric: true, Value: func(dinosaur dbmodels.Dinosaur) any { return int64(dinosaur.ID) }},
+	"name":    {Value: func(dinosaur dbmodels.Dinosaur) any { return dinosaur.Name }},
+	"species": {Value: func(dinosaur dbmodels.Dinosaur) any { return dinosaur.Species }},
+	"type":    {Value: func(dinosaur dbmodels.Dinosaur) any { return dinosaur.Type }},
+	"cage_id": {Numeric: true, Value: func(dinosaur dbmodels.Dinosaur) any { return int64(dinosaur.CageID) }},
+}
+
+// SortValues returns the values of the sort columns of the record, in the order of sort.
+func SortValues[T any](columns map[string]SortColumn[T], record T, sort []SortKey) []any {
+	values := make([]any, len(sort))
+	for i, key := range sort {
+		values[i] = columns[key.Column].Value(record)
+	}
+	return values
+}
+
+// pageSort returns the sort order of the page, defaulting to ascending IDs.
+func pageSort(page Page) []SortKey {
+	if len(page.Sort) == 0 {
+		return []SortKey{{Column: "id"}}
+	}
+	return page.Sort
+}
+
+// compareSortValues compares two lists of sort values in the given order. Strings are compared byte-wise,
+// as the database stores compare them.
+func compareSortValues(a []any, b []any, sort []SortKey) int {
+	for i, key := range sort {
+		var result int
+		switch value := a[i].(type) {
+		case int64:
+			other := b[i].(int64)
+			if value < other {
+				result = -1
+			} else if value > other {
+				result = 1
+			}
+		case string:
+			result = strings.Compare(value, b[i].(string))
+		}
+		if key.Desc {
+			result = -result
+		}
+		if result != 0 {
+			return result
+		}
+	}
+	return 0
+}
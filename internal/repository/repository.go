@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+)
+
+var (
+	// ErrNotFound is returned when the requested record does not exist.
+	ErrNotFound = errors.New("record not found")
+	// ErrVersionConflict is returned when a record has been changed since it was read.
+	ErrVersionConflict = errors.New("record version conflict")
+	// ErrAlreadyExists is returned when a record with the same primary key has already been stored.
+	ErrAlreadyExists = errors.New("record already exists")
+)
+
+// Names of database constraints and triggers guarding the park rules.
+const (
+	ConstraintDinosaurCage           = "dinosaurs_cage_id_fkey"
+	ConstraintCagePowered            = "dinosaurs_cage_powered"
+	ConstraintCageCapacity           = "dinosaurs_cage_capacity"
+	ConstraintNoHerbivoresCarnivores = "dinosaurs_no_herbivores_with_carnivores"
+	ConstraintSingleCarnivoreSpecies = "dinosaurs_carnivores_single_species"
+	ConstraintCapacityAboveOccupancy = "cages_capacity_not_below_occupancy"
+	ConstraintCageNotDeleted         = "dinosaurs_cage_not_deleted"
+	ConstraintCageEmptyOnDelete      = "cages_empty_on_delete"
+)
+
+// ConstraintError reports a write rejected by a database constraint or trigger.
+type ConstraintError struct {
+	Constraint string
+	Err        error
+}
+
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("constraint %s violated: %v", e.Constraint, e.Err)
+}
+
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+// CageFilter narrows down the cages returned by CageRepository.List.
+// A cage has to match every set field, and any of the values of a list field.
+type CageFilter struct {
+	IDs           []uint
+	PowerStatuses []string
+	// HasFreeCapacity selects cages with room for at least one more dinosaur, or full cages if false.
+	HasFreeCapacity *bool
+	CapacityGTE     *int
+	CapacityLTE     *int
+	// ChangedSince selects cages created, changed, deleted or restored at or after the time,
+	// including changes of their dinosaurs.
+	ChangedSince *time.Time
+}
+
+// DinosaurFilter narrows down the dinosaurs returned by DinosaurRepository.List.
+// A dinosaur has to match every set field, and any of the values of a list field.
+type DinosaurFilter struct {
+	Species []string
+	Types   []string
+	CageIDs []uint
+	// NamePrefixes are matched case-sensitively against the start of the name.
+	NamePrefixes []string
+}
+
+// CageRelations selects the records loaded along with cages.
+type CageRelations struct {
+	// Dinosaurs loads the dinosaurs in the cage. Dinosaurs is left nil on cages read without them.
+	Dinosaurs bool
+}
+
+// DinosaurRelations selects the records loaded along with dinosaurs.
+type DinosaurRelations struct {
+	// Cage loads the cage of the dinosaur, along with its own relations, even if the cage has been deleted.
+	// Cage is left nil on dinosaurs read without it.
+	Cage *CageRelations
+}
+
+// CageRepository persists cages. Cages are returned with their dinosaurs loaded, which only ever includes
+// dinosaurs that have not been deleted, unless the repository is narrowed down by Preload.
+// The Occupancy of cages is loaded in any case.
+//
+// Delete only marks a cage as deleted and reads skip deleted cages. A repository returned by Unscoped
+// also reads and updates deleted cages, and its Delete removes the cage permanently.
+type CageRepository interface {
+	Unscoped() CageRepository
+	// Preload returns a repository loading only the given relations of the cages it reads.
+	// GetForUpdate always loads the dinosaurs, which the placement rules are checked against.
+	Preload(relations CageRelations) CageRepository
+	List(ctx context.Context, filter CageFilter, page Page) ([]dbmodels.Cage, error)
+	// Count returns the number of cages matching the filter.
+	Count(ctx context.Context, filter CageFilter) (int, error)
+	Get(ctx context.Context, id uint) (dbmodels.Cage, error)
+	// GetForUpdate returns the cage and locks it until the end of the surrounding transaction,
+	// so that its dinosaurs cannot change while placement rules are being checked.
+	GetForUpdate(ctx context.Context, id uint) (dbmodels.Cage, error)
+	Create(ctx context.Context, cage *dbmodels.Cage) error
+	// Update stores the cage only if its version still matches the stored one, increments the version
+	// and sets UpdatedAt. The occupancy version is left as stored.
+	Update(ctx context.Context, cage *dbmodels.Cage) error
+	// Touch increments the occupancy version of the cage and sets UpdatedAt after its dinosaurs have changed,
+	// leaving its version alone.
+	Touch(ctx context.Context, cage *dbmodels.Cage) error
+	Delete(ctx context.Context, id uint) error
+	// LastModified returns when a cage matching the filter has last been created, updated or deleted,
+	// or the zero time if there is none. Deleted cages are taken into account even if the repository is not Unscoped.
+	LastModified(ctx context.Context, filter CageFilter) (time.Time, error)
+	// LastDeleted returns when a cage matching the filter has last been deleted, or the zero time if there is none.
+	LastDeleted(ctx context.Context, filter CageFilter) (time.Time, error)
+}
+
+// DinosaurRepository persists dinosaurs. Dinosaurs are returned without their cage, unless
+// the repository is extended by Preload. Deletion works the same way as for cages.
+type DinosaurRepository interface {
+	Unscoped() DinosaurRepository
+	// Preload returns a repository loading the given relations of the dinosaurs it reads.
+	Preload(relations DinosaurRelations) DinosaurRepository
+	List(ctx context.Context, filter DinosaurFilter, page Page) ([]dbmodels.Dinosaur, error)
+	// Count returns the number of dinosaurs matching the filter.
+	Count(ctx context.Context, filter DinosaurFilter) (int, error)
+	Get(ctx context.Context, id uint) (dbmodels.Dinosaur, error)
+	// GetForUpdate returns the dinosaur and locks it until the end of the surrounding transaction.
+	GetForUpdate(ctx context.Context, id uint) (dbmodels.Dinosaur, error)
+	Create(ctx context.Context, dinosaur *dbmodels.Dinosaur) error
+	// Update stores the dinosaur only if its version still matches the stored one, increments the version
+	// and sets UpdatedAt.
+	Update(ctx context.Context, dinosaur *dbmodels.Dinosaur) error
+	// Move updates the dinosaurs, each moved to another cage, at once. The database only checks their placement
+	// once all of them have been stored, against the cages as they end up, so that dinosaurs can swap cages.
+	Move(ctx context.Context, dinosaurs []*dbmodels.Dinosaur) error
+	Delete(ctx context.Context, id uint) error
+	// LastModified returns when a dinosaur matching the filter has last been created, updated or deleted,
+	// or the zero time if there is none.
+	LastModified(ctx context.Context, filter DinosaurFilter) (time.Time, error)
+	// LastDeleted returns when a dinosaur matching the filter has last been deleted, or the zero time if there is none.
+	LastDeleted(ctx context.Context, filter DinosaurFilter) (time.Time, error)
+}
+
+// HistoryQuery selects a page of the history of a single entity, ordered by entry ID.
+type HistoryQuery struct {
+	EntityType string
+	EntityID   uint
+	// AfterID skips all entries up to and including this ID.
+	AfterID uint
+	Limit   int
+}
+
+// HistoryRepository persists the change history of cages and dinosaurs. Entries can only be appended.
+type HistoryRepository interface {
+	Append(ctx context.Context, entry *dbmodels.HistoryEntry) error
+	List(ctx context.Context, query HistoryQuery) ([]dbmodels.HistoryEntry, error)
+}
+
+// IdempotencyRepository persists the requests made with an idempotency key, along with their responses.
+type IdempotencyRepository interface {
+	Get(ctx context.Context, scope string, key string) (dbmodels.IdempotencyKey, error)
+	// Create stores a new key, or fails with ErrAlreadyExists if the key has been stored for the scope before.
+	Create(ctx context.Context, key *dbmodels.IdempotencyKey) error
+	// Update stores the response of the request made with the key.
+	Update(ctx context.Context, key *dbmodels.IdempotencyKey) error
+	// Replace stores a new key in place of the stored one, or fails with ErrNotFound if the stored key
+	// has been deleted or replaced since it was read.
+	Replace(ctx context.Context, stored dbmodels.IdempotencyKey, key *dbmodels.IdempotencyKey) error
+	Delete(ctx context.Context, scope string, key string) error
+}
+
+// Store gives access to all repositories backed by the same storage.
+type Store interface {
+	Cages() CageRepository
+	Dinosaurs() DinosaurRepository
+	History() HistoryRepository
+	IdempotencyKeys() IdempotencyRepository
+	// Transaction runs fn in a single transaction. The store passed to fn is bound to the transaction
+	// and must be used for all reads and writes that belong to it. Returning an error rolls everything back.
+	Transaction(ctx context.Context, fn func(tx Store) error) error
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// CageService holds the business rules around cages.
+type CageService struct {
+	store repository.Store
+}
+
+func NewCageService(store repository.Store) *CageService {
+	return &CageService{store: store}
+}
+
+// ListCages returns the page of cages matching the filter, including the requested relations.
+// Deleted cages are only returned if includeDeleted is set.
+func (s *CageService) ListCages(ctx context.Context, filter repository.CageFilter, page repository.Page, includeDeleted bool, relations repository.CageRelations) ([]dbmodels.Cage, error) {
+	return cageRepository(s.store, includeDeleted).Preload(relations).List(ctx, filter, page)
+}
+
+// CountCages returns the number of cages matching the filter.
+func (s *CageService) CountCages(ctx context.Context, filter repository.CageFilter, includeDeleted bool) (int, error) {
+	return cageRepository(s.store, includeDeleted).Count(ctx, filter)
+}
+
+// LastModified returns when a cage matching the filter has last been created, changed or deleted.
+// Cages change along with their dinosaurs.
+func (s *CageService) LastModified(ctx context.Context, filter repository.CageFilter) (time.Time, error) {
+	return s.store.Cages().LastModified(ctx, filter)
+}
+
+// LastDeleted returns when a cage matching the filter has last been deleted.
+func (s *CageService) LastDeleted(ctx context.Context, filter repository.CageFilter) (time.Time, error) {
+	return s.store.Cages().LastDeleted(ctx, filter)
+}
+
+// GetCage returns a single cage, including the requested relations.
+// A deleted cage is only returned if includeDeleted is set.
+func (s *CageService) GetCage(ctx context.Context, id uint, includeDeleted bool, relations repository.CageRelations) (dbmodels.Cage, error) {
+	cage, err := cageRepository(s.store, includeDeleted).Preload(relations).Get(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return dbmodels.Cage{}, cageError(id, ErrCageNotFound)
+	}
+	return cage, err
+}
+
+// CreateCage registers a new cage.
+func (s *CageService) CreateCage(ctx context.Context, cage *dbmodels.Cage) error {
+	return s.store.Transaction(ctx, func(tx repository.Store) error {
+		if err := tx.Cages().Create(ctx, cage); err != nil {
+			return err
+		}
+		return recordCage(ctx, tx, apimodels.Created, nil, *cage)
+	})
+}
+
+// CageUpdate holds the changes of a cage. Fields left nil keep their current value.
+type CageUpdate struct {
+	Capacity    *int
+	PowerStatus *string
+}
+
+// UpdateCage applies the changes to the cage. The capacity cannot drop below the number of dinosaurs in the cage.
+// The cage is locked, so the change cannot interleave with a placement into the cage.
+func (s *CageService) UpdateCage(ctx context.Context, id uint, update CageUpdate, expectedVersion uint) (dbmodels.Cage, error) {
+	var cage dbmodels.Cage
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		var err error
+		cage, err = lockCage(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(cage.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		before := cage
+		if update.Capacity != nil {
+			cage.Capacity = *update.Capacity
+		}
+		if update.PowerStatus != nil {
+			cage.PowerStatus = *update.PowerStatus
+		}
+		if cage.Capacity == before.Capacity && cage.PowerStatus == before.PowerStatus {
+			return nil
+		}
+		if cage.Capacity < len(cage.Dinosaurs) {
+			return cageError(id, ErrCapacityBelowOccupancy)
+		}
+
+		if err := tx.Cages().Update(ctx, &cage); err != nil {
+			return err
+		}
+		action := apimodels.Updated
+		if cage.Capacity == before.Capacity {
+			action = apimodels.PowerChanged
+		}
+		return recordCage(ctx, tx, action, &before, cage)
+	})
+	if err != nil {
+		return dbmodels.Cage{}, fromConstraintError(err)
+	}
+	return cage, nil
+}
+
+// DeleteCage marks the cage as deleted. Only empty cages can be removed.
+// The cage is locked, so no dinosaur can be placed into it while it is being removed.
+func (s *CageService) DeleteCage(ctx context.Context, id uint, expectedVersion uint) error {
+	return s.store.Transaction(ctx, func(tx repository.Store) error {
+		cage, err := lockCage(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(cage.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		if len(cage.Dinosaurs) > 0 {
+			return cageError(id, ErrCageNotEmpty)
+		}
+
+		err = tx.Cages().Delete(ctx, id)
+		var constraintErr *repository.ConstraintError
+		if errors.As(err, &constraintErr) && (constraintErr.Constraint == repository.ConstraintDinosaurCage ||
+			constraintErr.Constraint == repository.ConstraintCageEmptyOnDelete) {
+			return cageError(id, fmt.Errorf("%w: %w", ErrCageNotEmpty, err))
+		}
+		if err != nil {
+			return err
+		}
+
+		deleted, err := tx.Cages().Unscoped().Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		return recordCage(ctx, tx, apimodels.Deleted, &cage, deleted)
+	})
+}
+
+// RestoreCage brings back a deleted cage. Restoring a cage that is not deleted changes nothing.
+func (s *CageService) RestoreCage(ctx context.Context, id uint, expectedVersion uint) (dbmodels.Cage, error) {
+	var cage dbmodels.Cage
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		var err error
+		cage, err = tx.Cages().Unscoped().GetForUpdate(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) {
+			return cageError(id, ErrCageNotFound)
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(cage.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		if !cage.DeletedAt.Valid {
+			return nil
+		}
+		before := cage
+		cage.DeletedAt = gorm.DeletedAt{}
+		if err := tx.Cages().Unscoped().Update(ctx, &cage); err != nil {
+			return err
+		}
+		return recordCage(ctx, tx, apimodels.Restored, &before, cage)
+	})
+	if err != nil {
+		return dbmodels.Cage{}, err
+	}
+	return cage, nil
+}
+
+// GetCageHistory returns up to limit changes of the cage recorded after the entry afterID.
+// The history of deleted cages stays available.
+func (s *CageService) GetCageHistory(ctx context.Context, id uint, afterID uint, limit int) (HistoryPage, error) {
+	if _, err := s.store.Cages().Unscoped().Get(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return HistoryPage{}, cageError(id, ErrCageNotFound)
+		}
+		return HistoryPage{}, err
+	}
+	return historyPage(ctx, s.store, historyCage, id, afterID, limit)
+}
+
+// cageRepository returns the cage repository of the store, which includes deleted cages if requested.
+func cageRepository(store repository.Store, includeDeleted bool) repository.CageRepository {
+	if includeDeleted {
+		return store.Cages().Unscoped()
+	}
+	return store.Cages()
+}
+
+func lockCage(ctx context.Context, tx repository.Store, id uint) (dbmodels.Cage, error) {
+	cage, err := tx.Cages().GetForUpdate(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return dbmodels.Cage{}, cageError(id, ErrCageNotFound)
+	}
+	return cage, err
+}
+
+// lockCages locks several cages in ascending ID order, so that concurrent transactions
+// locking overlapping cages cannot deadlock.
+func lockCages(ctx context.Context, tx repository.Store, ids ...uint) (map[uint]dbmodels.Cage, error) {
+	sorted := append([]uint{}, ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	cages := map[uint]dbmodels.Cage{}
+	for _, id := range sorted {
+		if _, locked := cages[id]; locked {
+			continue
+		}
+		cage, err := lockCage(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		cages[id] = cage
+	}
+	return cages, nil
+}
+
+// touchCage bumps the occupancy version of a locked cage after its dinosaurs have changed. Its own version
+// stays, so that changes of the cage expecting it are not failed by placements: the capacity and deletion checks
+// run against the dinosaurs read while the cage is locked instead.
+func touchCage(ctx context.Context, tx repository.Store, cage dbmodels.Cage) error {
+	return tx.Cages().Touch(ctx, &cage)
+}
@@ -0,0 +1,472 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// DinosaurService holds the business rules around dinosaurs and their placement in cages.
+type DinosaurService struct {
+	store repository.Store
+}
+
+func NewDinosaurService(store repository.Store) *DinosaurService {
+	return &DinosaurService{store: store}
+}
+
+// ListDinosaurs returns the page of dinosaurs matching the filter, including the requested relations.
+// Deleted dinosaurs are only returned if includeDeleted is set.
+func (s *DinosaurService) ListDinosaurs(ctx context.Context, filter repository.DinosaurFilter, page repository.Page, includeDeleted bool, relations repository.DinosaurRelations) ([]dbmodels.Dinosaur, error) {
+	return dinosaurRepository(s.store, includeDeleted).Preload(relations).List(ctx, filter, page)
+}
+
+// CountDinosaurs returns the number of dinosaurs matching the filter.
+func (s *DinosaurService) CountDinosaurs(ctx context.Context, filter repository.DinosaurFilter, includeDeleted bool) (int, error) {
+	return dinosaurRepository(s.store, includeDeleted).Count(ctx, filter)
+}
+
+// LastModified returns when a dinosaur matching the filter has last been created, changed or deleted.
+func (s *DinosaurService) LastModified(ctx context.Context, filter repository.DinosaurFilter) (time.Time, error) {
+	return s.store.Dinosaurs().LastModified(ctx, filter)
+}
+
+// LastDeleted returns when a dinosaur matching the filter has last been deleted.
+func (s *DinosaurService) LastDeleted(ctx context.Context, filter repository.DinosaurFilter) (time.Time, error) {
+	return s.store.Dinosaurs().LastDeleted(ctx, filter)
+}
+
+// GetDinosaur returns a single dinosaur, including the requested relations.
+// A deleted dinosaur is only returned if includeDeleted is set.
+func (s *DinosaurService) GetDinosaur(ctx context.Context, id uint, includeDeleted bool, relations repository.DinosaurRelations) (dbmodels.Dinosaur, error) {
+	dinosaur, err := dinosaurRepository(s.store, includeDeleted).Preload(relations).Get(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return dbmodels.Dinosaur{}, ErrDinosaurNotFound
+	}
+	return dinosaur, err
+}
+
+// AddDinosaur places a new dinosaur in its cage, if the placement rules allow it.
+// The cage stays locked from the rule check until the dinosaur is stored.
+func (s *DinosaurService) AddDinosaur(ctx context.Context, dinosaur *dbmodels.Dinosaur) error {
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		cage, err := lockCage(ctx, tx, dinosaur.CageID)
+		if err != nil {
+			return err
+		}
+		if err := checkPlacement(cage, *dinosaur); err != nil {
+			return err
+		}
+		if err := tx.Dinosaurs().Create(ctx, dinosaur); err != nil {
+			return err
+		}
+		if err := recordDinosaur(ctx, tx, apimodels.Created, nil, *dinosaur); err != nil {
+			return err
+		}
+		return touchCage(ctx, tx, cage)
+	})
+	return fromConstraintError(err)
+}
+
+// BatchItem is a dinosaur of a batch added at once, along with the reason it is rejected.
+type BatchItem struct {
+	Dinosaur dbmodels.Dinosaur
+	// Err is nil for dinosaurs added to the park. Items handed in with an error set are rejected right away.
+	Err error
+}
+
+// AddDinosaurs places a batch of dinosaurs in their cages within a single transaction.
+// Every dinosaur is checked with the placement rules of AddDinosaur against its cage as it stands
+// with the dinosaurs of the batch placed before it. Dinosaurs breaking the rules get their Err set.
+// In all-or-nothing mode a single rejected dinosaur rolls back the whole batch and ErrBatchRejected is returned,
+// otherwise the remaining dinosaurs are added. All cages of the batch stay locked until it is stored.
+func (s *DinosaurService) AddDinosaurs(ctx context.Context, items []BatchItem, allOrNothing bool) error {
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		cageIDs := []uint{}
+		for _, item := range items {
+			if item.Err == nil && !slices.Contains(cageIDs, item.Dinosaur.CageID) {
+				cageIDs = append(cageIDs, item.Dinosaur.CageID)
+			}
+		}
+		// Locked one by one in ascending order, a missing cage only rejects the dinosaurs meant for it.
+		slices.Sort(cageIDs)
+		cages := map[uint]dbmodels.Cage{}
+		for _, id := range cageIDs {
+			cage, err := lockCage(ctx, tx, id)
+			if err != nil && !errors.Is(err, ErrCageNotFound) {
+				return err
+			}
+			if err == nil {
+				cages[id] = cage
+			}
+		}
+
+		rejected := false
+		placed := map[uint]bool{}
+		for i := range items {
+			item := &items[i]
+			if item.Err == nil {
+				var err error
+				if item.Err, err = addBatchItem(ctx, tx, cages, item); err != nil {
+					return err
+				}
+			}
+			if item.Err != nil {
+				rejected = true
+				continue
+			}
+			placed[item.Dinosaur.CageID] = true
+		}
+		if rejected && allOrNothing {
+			return ErrBatchRejected
+		}
+
+		for id := range placed {
+			if err := touchCage(ctx, tx, cages[id]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return fromConstraintError(err)
+}
+
+// addBatchItem places a single dinosaur of a batch, updating the locked cages so later dinosaurs see it.
+// Returns the park rule the dinosaur breaks, or an error if storing it failed.
+func addBatchItem(ctx context.Context, tx repository.Store, cages map[uint]dbmodels.Cage, item *BatchItem) (error, error) {
+	cage, found := cages[item.Dinosaur.CageID]
+	if !found {
+		return cageError(item.Dinosaur.CageID, ErrCageNotFound), nil
+	}
+	if ruleErr := checkPlacement(cage, item.Dinosaur); ruleErr != nil {
+		return ruleErr, nil
+	}
+	if err := tx.Dinosaurs().Create(ctx, &item.Dinosaur); err != nil {
+		return nil, err
+	}
+	if err := recordDinosaur(ctx, tx, apimodels.Created, nil, item.Dinosaur); err != nil {
+		return nil, err
+	}
+	cage.Dinosaurs = append(cage.Dinosaurs, item.Dinosaur)
+	cages[cage.ID] = cage
+	return nil, nil
+}
+
+// DinosaurUpdate holds the changes of a dinosaur. Fields left nil keep their current value.
+// The type of the dinosaur follows its species.
+type DinosaurUpdate struct {
+	Name    *string
+	Species *string
+	CageID  *uint
+}
+
+// apply returns the dinosaur with the changes applied.
+func (u DinosaurUpdate) apply(dinosaur dbmodels.Dinosaur) (dbmodels.Dinosaur, error) {
+	if u.Name != nil {
+		dinosaur.Name = *u.Name
+	}
+	if u.Species != nil {
+		knownSpecies, species, dinosaurType := apimodels.LookupSpeciesType(*u.Species)
+		if !knownSpecies {
+			return dbmodels.Dinosaur{}, ErrUnknownSpecies
+		}
+		dinosaur.Species, dinosaur.Type = string(species), string(dinosaurType)
+	}
+	if u.CageID != nil {
+		dinosaur.CageID = *u.CageID
+	}
+	return dinosaur, nil
+}
+
+// UpdateDinosaur applies the changes to the dinosaur. Moving it to a different cage has to follow the placement rules,
+// and a dinosaur changing its species has to get along with its cage-mates.
+// The dinosaur and its cages stay locked from the rule check until the change is stored.
+func (s *DinosaurService) UpdateDinosaur(ctx context.Context, id uint, update DinosaurUpdate, expectedVersion uint) (dbmodels.Dinosaur, error) {
+	var dinosaur dbmodels.Dinosaur
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		var err error
+		dinosaur, err = lockDinosaur(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(dinosaur.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		before := dinosaur
+		if dinosaur, err = update.apply(before); err != nil {
+			return err
+		}
+		if dinosaur.Name == before.Name && dinosaur.Species == before.Species && dinosaur.CageID == before.CageID {
+			return nil
+		}
+
+		cages, err := lockCages(ctx, tx, before.CageID, dinosaur.CageID)
+		if err != nil {
+			return err
+		}
+		if err := firstViolation(updateViolations(cages[dinosaur.CageID], before, dinosaur)); err != nil {
+			return err
+		}
+
+		if err := tx.Dinosaurs().Update(ctx, &dinosaur); err != nil {
+			return err
+		}
+		action := apimodels.Updated
+		if dinosaur.Name == before.Name && dinosaur.Species == before.Species {
+			action = apimodels.Moved
+		}
+		if err := recordDinosaur(ctx, tx, action, &before, dinosaur); err != nil {
+			return err
+		}
+		for _, cage := range cages {
+			if err := touchCage(ctx, tx, cage); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return dbmodels.Dinosaur{}, fromConstraintError(err)
+	}
+	return dinosaur, nil
+}
+
+// PlacementViolations returns every placement rule broken by putting the dinosaur in the cage, without changing anything.
+// A dinosaur already living in the cage only has to get along with its cage-mates.
+func (s *DinosaurService) PlacementViolations(ctx context.Context, cageID uint, dinosaur dbmodels.Dinosaur) ([]error, error) {
+	cage, err := s.store.Cages().Get(ctx, cageID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, cageError(cageID, ErrCageNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if dinosaur.ID != 0 && !dinosaur.DeletedAt.Valid && dinosaur.CageID == cageID {
+		return dietViolations(cage, dinosaur), nil
+	}
+	dinosaur.CageID = cageID
+	return placementViolations(cage, dinosaur), nil
+}
+
+// UpdateViolations returns the dinosaur as UpdateDinosaur would store it, along with every rule the change breaks.
+// Nothing is changed.
+func (s *DinosaurService) UpdateViolations(ctx context.Context, id uint, update DinosaurUpdate, expectedVersion uint) (dbmodels.Dinosaur, []error, error) {
+	before, err := s.GetDinosaur(ctx, id, false, repository.DinosaurRelations{})
+	if err != nil {
+		return dbmodels.Dinosaur{}, nil, err
+	}
+	if err := checkVersion(before.Version, expectedVersion); err != nil {
+		return dbmodels.Dinosaur{}, nil, err
+	}
+
+	dinosaur, err := update.apply(before)
+	if err != nil {
+		return dbmodels.Dinosaur{}, nil, err
+	}
+	if dinosaur.CageID == before.CageID && dinosaur.Species == before.Species {
+		return dinosaur, nil, nil
+	}
+	cage, err := s.store.Cages().Get(ctx, dinosaur.CageID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return dbmodels.Dinosaur{}, nil, cageError(dinosaur.CageID, ErrCageNotFound)
+	}
+	if err != nil {
+		return dbmodels.Dinosaur{}, nil, err
+	}
+	return dinosaur, updateViolations(cage, before, dinosaur), nil
+}
+
+// Move relocates a single dinosaur as part of MoveDinosaurs.
+type Move struct {
+	DinosaurID   uint
+	TargetCageID uint
+}
+
+// MoveDinosaurs relocates several dinosaurs at once within a single transaction, returning them in the order of moves.
+// Only the park as it stands after all moves is checked against the placement rules, so dinosaurs can swap
+// or rotate through full cages. Either all moves are applied or none. Each dinosaur can be moved only once.
+// The dinosaurs and all their cages stay locked from the rule check until the moves are stored.
+func (s *DinosaurService) MoveDinosaurs(ctx context.Context, moves []Move) ([]dbmodels.Dinosaur, error) {
+	dinosaurs := make([]dbmodels.Dinosaur, len(moves))
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		// Dinosaurs are locked in ascending ID order, so that concurrent moves cannot deadlock.
+		order := make([]int, len(moves))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortFunc(order, func(a, b int) int { return cmp.Compare(moves[a].DinosaurID, moves[b].DinosaurID) })
+		for n, i := range order {
+			if n > 0 && moves[i].DinosaurID == moves[order[n-1]].DinosaurID {
+				return ErrDinosaurMovedTwice
+			}
+			var err error
+			if dinosaurs[i], err = lockDinosaur(ctx, tx, moves[i].DinosaurID); err != nil {
+				return err
+			}
+		}
+
+		cageIDs := []uint{}
+		for i, move := range moves {
+			cageIDs = append(cageIDs, dinosaurs[i].CageID, move.TargetCageID)
+		}
+		cages, err := lockCages(ctx, tx, cageIDs...)
+		if err != nil {
+			return err
+		}
+
+		before := slices.Clone(dinosaurs)
+		moved := []int{}
+		for i, move := range moves {
+			if move.TargetCageID != before[i].CageID {
+				dinosaurs[i].CageID = move.TargetCageID
+				moved = append(moved, i)
+			}
+		}
+		if err := checkMoves(cages, before, dinosaurs); err != nil {
+			return err
+		}
+
+		// The database checks the moves once all of them have been made, as a swap cannot be made one dinosaur at a time.
+		movedDinosaurs := make([]*dbmodels.Dinosaur, len(moved))
+		for n, i := range moved {
+			movedDinosaurs[n] = &dinosaurs[i]
+		}
+		if err := tx.Dinosaurs().Move(ctx, movedDinosaurs); err != nil {
+			return err
+		}
+		for _, i := range moved {
+			if err := recordDinosaur(ctx, tx, apimodels.Moved, &before[i], dinosaurs[i]); err != nil {
+				return err
+			}
+		}
+
+		touched := map[uint]bool{}
+		for _, i := range moved {
+			touched[before[i].CageID], touched[dinosaurs[i].CageID] = true, true
+		}
+		for id := range touched {
+			if err := touchCage(ctx, tx, cages[id]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fromConstraintError(err)
+	}
+	return dinosaurs, nil
+}
+
+// RemoveDinosaur removes the dinosaur from the park. Its record is kept, marked as deleted.
+func (s *DinosaurService) RemoveDinosaur(ctx context.Context, id uint, expectedVersion uint) error {
+	return s.store.Transaction(ctx, func(tx repository.Store) error {
+		dinosaur, err := lockDinosaur(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(dinosaur.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		cage, err := lockCage(ctx, tx, dinosaur.CageID)
+		if err != nil && !errors.Is(err, ErrCageNotFound) {
+			return err
+		}
+		if err := tx.Dinosaurs().Delete(ctx, id); err != nil {
+			return err
+		}
+		deleted, err := tx.Dinosaurs().Unscoped().Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := recordDinosaur(ctx, tx, apimodels.Deleted, &dinosaur, deleted); err != nil {
+			return err
+		}
+		if cage.ID == 0 {
+			return nil
+		}
+		return touchCage(ctx, tx, cage)
+	})
+}
+
+// RestoreDinosaur brings back a deleted dinosaur into the cage it was removed from,
+// if the placement rules still allow it. Restoring a dinosaur that is not deleted changes nothing.
+func (s *DinosaurService) RestoreDinosaur(ctx context.Context, id uint, expectedVersion uint) (dbmodels.Dinosaur, error) {
+	var dinosaur dbmodels.Dinosaur
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		var err error
+		dinosaur, err = tx.Dinosaurs().Unscoped().GetForUpdate(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrDinosaurNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(dinosaur.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		if !dinosaur.DeletedAt.Valid {
+			return nil
+		}
+		cage, err := lockCage(ctx, tx, dinosaur.CageID)
+		if err != nil {
+			return err
+		}
+		if err := checkPlacement(cage, dinosaur); err != nil {
+			return err
+		}
+
+		before := dinosaur
+		dinosaur.DeletedAt = gorm.DeletedAt{}
+		if err := tx.Dinosaurs().Unscoped().Update(ctx, &dinosaur); err != nil {
+			return err
+		}
+		if err := recordDinosaur(ctx, tx, apimodels.Restored, &before, dinosaur); err != nil {
+			return err
+		}
+		return touchCage(ctx, tx, cage)
+	})
+	if err != nil {
+		return dbmodels.Dinosaur{}, fromConstraintError(err)
+	}
+	return dinosaur, nil
+}
+
+// GetDinosaurHistory returns up to limit changes of the dinosaur recorded after the entry afterID.
+// The history of deleted dinosaurs stays available.
+func (s *DinosaurService) GetDinosaurHistory(ctx context.Context, id uint, afterID uint, limit int) (HistoryPage, error) {
+	if _, err := s.store.Dinosaurs().Unscoped().Get(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return HistoryPage{}, ErrDinosaurNotFound
+		}
+		return HistoryPage{}, err
+	}
+	return historyPage(ctx, s.store, historyDinosaur, id, afterID, limit)
+}
+
+// dinosaurRepository returns the dinosaur repository of the store, which includes deleted dinosaurs if requested.
+func dinosaurRepository(store repository.Store, includeDeleted bool) repository.DinosaurRepository {
+	if includeDeleted {
+		return store.Dinosaurs().Unscoped()
+	}
+	return store.Dinosaurs()
+}
+
+func lockDinosaur(ctx context.Context, tx repository.Store, id uint) (dbmodels.Dinosaur, error) {
+	dinosaur, err := tx.Dinosaurs().GetForUpdate(ctx, id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return dbmodels.Dinosaur{}, ErrDinosaurNotFound
+	}
+	return dinosaur, err
+}
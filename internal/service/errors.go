@@ -0,0 +1,77 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"pp-jurassic-park-api/internal/repository"
+)
+
+var (
+	ErrCageNotFound              = errors.New("cage not found")
+	ErrDinosaurNotFound          = errors.New("dinosaur not found")
+	ErrCageNotEmpty              = errors.New("cage has dinosaurs inside")
+	ErrCageFull                  = errors.New("cage is already full")
+	ErrCageNoPower               = errors.New("cage has no power")
+	ErrHerbivoreWithCarnivores   = errors.New("herbivore cannot be placed in cage with carnivores")
+	ErrCarnivoreWithOtherSpecies = errors.New("carnivore cannot be placed in cage with other species")
+	ErrCapacityBelowOccupancy    = errors.New("capacity is lower than the number of dinosaurs in the cage")
+	ErrUnknownSpecies            = errors.New("unknown species")
+	ErrBatchRejected             = errors.New("batch rejected as some of its items break the park rules")
+	ErrImportRejected            = errors.New("import rejected as some of its rows break the park rules")
+	ErrImportCageRejected        = errors.New("cage of the import is rejected")
+	ErrImportCageNotFound        = errors.New("no cage of the import has the reference")
+	ErrDinosaurMovedTwice        = errors.New("dinosaur is moved more than once")
+	ErrVersionMismatch           = errors.New("resource has been modified since it was read")
+)
+
+// CageError attributes an error to the cage it concerns.
+type CageError struct {
+	CageID uint
+	Err    error
+}
+
+func (e *CageError) Error() string {
+	return fmt.Sprintf("cage %d: %v", e.CageID, e.Err)
+}
+
+func (e *CageError) Unwrap() error {
+	return e.Err
+}
+
+func cageError(id uint, err error) error {
+	return &CageError{CageID: id, Err: err}
+}
+
+// AnyVersion skips the optimistic concurrency check of an update or delete.
+const AnyVersion uint = 0
+
+func checkVersion(current uint, expected uint) error {
+	if expected != AnyVersion && current != expected {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// constraintErrors maps database constraints to the park rule they enforce.
+var constraintErrors = map[string]error{
+	repository.ConstraintDinosaurCage:           ErrCageNotFound,
+	repository.ConstraintCageNotDeleted:         ErrCageNotFound,
+	repository.ConstraintCagePowered:            ErrCageNoPower,
+	repository.ConstraintCageCapacity:           ErrCageFull,
+	repository.ConstraintNoHerbivoresCarnivores: ErrHerbivoreWithCarnivores,
+	repository.ConstraintSingleCarnivoreSpecies: ErrCarnivoreWithOtherSpecies,
+	repository.ConstraintCapacityAboveOccupancy: ErrCapacityBelowOccupancy,
+}
+
+// fromConstraintError translates a write rejected by the database into the park rule it violates,
+// so callers get the same errors whether the service or the database caught the problem.
+func fromConstraintError(err error) error {
+	var constraintErr *repository.ConstraintError
+	if errors.As(err, &constraintErr) {
+		if ruleErr, ok := constraintErrors[constraintErr.Constraint]; ok {
+			return fmt.Errorf("%w: %w", ruleErr, err)
+		}
+	}
+	return err
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+)
+
+// Entity types the history is recorded for.
+const (
+	historyCage     = "cage"
+	historyDinosaur = "dinosaur"
+)
+
+// AnonymousActor is recorded for changes made without an identified actor.
+const AnonymousActor = "anonymous"
+
+type actorKey struct{}
+
+// WithActor returns a context attributing all changes made with it to the given actor.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func actorFrom(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
+
+// HistoryPage is a page of history entries. NextAfterID is 0 on the last page.
+type HistoryPage struct {
+	Entries     []dbmodels.HistoryEntry
+	NextAfterID uint
+}
+
+// cageSnapshot is the state of a cage as recorded in the history. Its dinosaurs have their own history.
+type cageSnapshot struct {
+	ID          uint       `json:"id"`
+	Capacity    int        `json:"capacity"`
+	PowerStatus string     `json:"power_status"`
+	Version     uint       `json:"version"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
+
+type dinosaurSnapshot struct {
+	ID        uint       `json:"id"`
+	Name      string     `json:"name"`
+	Species   string     `json:"species"`
+	Type      string     `json:"type"`
+	CageID    uint       `json:"cage_id"`
+	Version   uint       `json:"version"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+func snapshotCage(cage *dbmodels.Cage) any {
+	if cage == nil {
+		return nil
+	}
+	snapshot := cageSnapshot{ID: cage.ID, Capacity: cage.Capacity, PowerStatus: cage.PowerStatus, Version: cage.Version}
+	if cage.DeletedAt.Valid {
+		snapshot.DeletedAt = &cage.DeletedAt.Time
+	}
+	return snapshot
+}
+
+func snapshotDinosaur(dinosaur *dbmodels.Dinosaur) any {
+	if dinosaur == nil {
+		return nil
+	}
+	snapshot := dinosaurSnapshot{
+		ID:      dinosaur.ID,
+		Name:    dinosaur.Name,
+		Species: dinosaur.Species,
+		Type:    dinosaur.Type,
+		CageID:  dinosaur.CageID,
+		Version: dinosaur.Version,
+	}
+	if dinosaur.DeletedAt.Valid {
+		snapshot.DeletedAt = &dinosaur.DeletedAt.Time
+	}
+	return snapshot
+}
+
+// recordCage appends a change of the cage to its history. before is nil for newly created cages.
+func recordCage(ctx context.Context, tx repository.Store, action apimodels.HistoryAction, before *dbmodels.Cage, after dbmodels.Cage) error {
+	return record(ctx, tx, historyCage, after.ID, action, snapshotCage(before), snapshotCage(&after))
+}
+
+// recordDinosaur appends a change of the dinosaur to its history. before is nil for newly added dinosaurs.
+func recordDinosaur(ctx context.Context, tx repository.Store, action apimodels.HistoryAction, before *dbmodels.Dinosaur, after dbmodels.Dinosaur) error {
+	return record(ctx, tx, historyDinosaur, after.ID, action, snapshotDinosaur(before), snapshotDinosaur(&after))
+}
+
+func record(ctx context.Context, tx repository.Store, entityType string, entityID uint, action apimodels.HistoryAction, before any, after any) error {
+	entry := dbmodels.HistoryEntry{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     string(action),
+		Actor:      actorFrom(ctx),
+		RecordedAt: time.Now().UTC(),
+	}
+	if before != nil {
+		data, err := json.Marshal(before)
+		if err != nil {
+			return err
+		}
+		entry.Before = string(data)
+	}
+	data, err := json.Marshal(after)
+	if err != nil {
+		return err
+	}
+	entry.After = string(data)
+	return tx.History().Append(ctx, &entry)
+}
+
+// historyPage loads the page of history entries following afterID.
+func historyPage(ctx context.Context, store repository.Store, entityType string, entityID uint, afterID uint, limit int) (HistoryPage, error) {
+	// One extra entry tells whether another page follows.
+	entries, err := store.History().List(ctx, repository.HistoryQuery{
+		EntityType: entityType,
+		EntityID:   entityID,
+		AfterID:    afterID,
+		Limit:      limit + 1,
+	})
+	if err != nil {
+		return HistoryPage{}, err
+	}
+
+	page := HistoryPage{Entries: entries}
+	if len(entries) > limit {
+		page.Entries = entries[:limit]
+		page.NextAfterID = page.Entries[limit-1].ID
+	}
+	return page, nil
+}
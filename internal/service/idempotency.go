@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+)
+
+// IdempotencyKeyTTL is how long a key is kept. Requests made with an expired key are handled as new ones.
+const IdempotencyKeyTTL = 24 * time.Hour
+
+// IdempotencyKeyLeaseMargin is how long a key stays reserved past the time a request is handled for, leaving
+// the request time to store its response or release the key.
+const IdempotencyKeyLeaseMargin = 10 * time.Second
+
+var (
+	ErrIdempotencyKeyReused = errors.New("idempotency key has been used for a different request")
+	ErrIdempotencyKeyInUse  = errors.New("request with the same idempotency key is still being handled")
+)
+
+// IdempotencyService remembers the responses of requests made with an idempotency key,
+// so that retries of a request return its original response instead of handling it again.
+type IdempotencyService struct {
+	store   repository.Store
+	timeout time.Duration
+}
+
+// NewIdempotencyService returns the service for requests which are handled for at most the timeout,
+// usually the write timeout of the server.
+func NewIdempotencyService(store repository.Store, timeout time.Duration) *IdempotencyService {
+	return &IdempotencyService{store: store, timeout: timeout}
+}
+
+// Timeout is how long a request made with a key is handled for, after which its context is canceled.
+func (s *IdempotencyService) Timeout() time.Duration {
+	return s.timeout
+}
+
+// Lease is how long a key stays reserved for the request being handled with it, past its timeout. A key reserved
+// for longer has been left behind by a request that could not release it, and is reserved for the next request
+// made with it.
+func (s *IdempotencyService) Lease() time.Duration {
+	return s.timeout + IdempotencyKeyLeaseMargin
+}
+
+// StoredResponse is the response recorded for a request.
+type StoredResponse struct {
+	StatusCode  int
+	ContentType string
+	ETag        string
+	Body        []byte
+}
+
+// Begin reserves the key of the scope for the request with the fingerprint. The request is to be handled only
+// if no response is returned, and Complete or Release called once it has been.
+// Returns the stored response if the same request has been completed before, ErrIdempotencyKeyReused
+// if the key has been used for another request and ErrIdempotencyKeyInUse while the request is being handled.
+func (s *IdempotencyService) Begin(ctx context.Context, scope string, key string, fingerprint string) (*StoredResponse, error) {
+	reserved := dbmodels.IdempotencyKey{Scope: scope, Key: key, Fingerprint: fingerprint, CreatedAt: time.Now().UTC()}
+	err := s.store.IdempotencyKeys().Create(ctx, &reserved)
+	if !errors.Is(err, repository.ErrAlreadyExists) {
+		return nil, err
+	}
+
+	stored, err := s.store.IdempotencyKeys().Get(ctx, scope, key)
+	if errors.Is(err, repository.ErrNotFound) {
+		// Released in the meantime.
+		return nil, s.reserveAgain(ctx, &reserved)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	age := time.Since(stored.CreatedAt)
+	if age > IdempotencyKeyTTL || stored.StatusCode == 0 && age > s.Lease() {
+		err := s.store.IdempotencyKeys().Replace(ctx, stored, &reserved)
+		if errors.Is(err, repository.ErrNotFound) {
+			// Taken over or released by another request in the meantime.
+			return nil, ErrIdempotencyKeyInUse
+		}
+		return nil, err
+	}
+	if stored.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if stored.StatusCode == 0 {
+		return nil, ErrIdempotencyKeyInUse
+	}
+	return &StoredResponse{
+		StatusCode:  stored.StatusCode,
+		ContentType: stored.ContentType,
+		ETag:        stored.ETag,
+		Body:        []byte(stored.Body),
+	}, nil
+}
+
+// reserveAgain reserves a key which has been freed since it was found taken.
+// Losing the key to another request in the meantime reports it as in use.
+func (s *IdempotencyService) reserveAgain(ctx context.Context, reserved *dbmodels.IdempotencyKey) error {
+	err := s.store.IdempotencyKeys().Create(ctx, reserved)
+	if errors.Is(err, repository.ErrAlreadyExists) {
+		return ErrIdempotencyKeyInUse
+	}
+	return err
+}
+
+// Complete stores the response of the request the key has been reserved for.
+func (s *IdempotencyService) Complete(ctx context.Context, scope string, key string, fingerprint string, response StoredResponse) error {
+	return s.store.IdempotencyKeys().Update(ctx, &dbmodels.IdempotencyKey{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: fingerprint,
+		StatusCode:  response.StatusCode,
+		ContentType: response.ContentType,
+		ETag:        response.ETag,
+		Body:        string(response.Body),
+	})
+}
+
+// Release frees the key of a request which has not been handled, so that it can be retried.
+func (s *IdempotencyService) Release(ctx context.Context, scope string, key string) error {
+	err := s.store.IdempotencyKeys().Delete(ctx, scope, key)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+)
+
+// exportPageSize is the number of records read at once while exporting the park.
+const exportPageSize = 500
+
+// errImportDryRun rolls back the transaction of an import that only validates its rows.
+var errImportDryRun = errors.New("import dry run")
+
+// InventoryService exports the park inventory, and imports cages and dinosaurs in bulk.
+type InventoryService struct {
+	store repository.Store
+}
+
+func NewInventoryService(store repository.Store) *InventoryService {
+	return &InventoryService{store: store}
+}
+
+// Export passes every cage of the park to cageFn, then every dinosaur to dinosaurFn, both in ascending ID order.
+// Records are read page by page, so the export is not a snapshot: records changed while it runs
+// are exported as they are when their page is read. Stops at the first error returned by a callback.
+func (s *InventoryService) Export(ctx context.Context, cageFn func(dbmodels.Cage) error, dinosaurFn func(dbmodels.Dinosaur) error) error {
+	cages := func(page repository.Page) ([]dbmodels.Cage, error) {
+		return s.store.Cages().List(ctx, repository.CageFilter{}, page)
+	}
+	if err := exportPages(cages, repository.CageSortColumns, cageFn); err != nil {
+		return err
+	}
+	dinosaurs := func(page repository.Page) ([]dbmodels.Dinosaur, error) {
+		return s.store.Dinosaurs().List(ctx, repository.DinosaurFilter{}, page)
+	}
+	return exportPages(dinosaurs, repository.DinosaurSortColumns, dinosaurFn)
+}
+
+// exportPages passes every record of the list to fn, reading the list in pages of ascending IDs.
+func exportPages[T any](list func(repository.Page) ([]T, error), columns map[string]repository.SortColumn[T], fn func(T) error) error {
+	page := repository.Page{Sort: []repository.SortKey{{Column: "id"}}, Limit: exportPageSize}
+	for {
+		records, err := list(page)
+		if err != nil {
+			return err
+		}
+		for _, record := range records {
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+		if len(records) < page.Limit {
+			return nil
+		}
+		page.After = repository.SortValues(columns, records[len(records)-1], page.Sort)
+	}
+}
+
+// ImportCage is a cage of an import, along with the reason it is rejected.
+type ImportCage struct {
+	// Ref identifies the cage within the import, so that its dinosaurs can refer to it. Empty if they do not.
+	Ref  string
+	Cage dbmodels.Cage
+	// Err is nil for cages created in the park. Cages handed in with an error set are rejected right away.
+	Err error
+}
+
+// ImportDinosaur is a dinosaur of an import, along with the reason it is rejected.
+type ImportDinosaur struct {
+	// CageRef refers to the cage of the import with the same Ref. Without one, the CageID of the dinosaur
+	// refers to an existing cage.
+	CageRef  string
+	Dinosaur dbmodels.Dinosaur
+	// Err is nil for dinosaurs added to the park. Dinosaurs handed in with an error set are rejected right away.
+	Err error
+}
+
+// Import holds the cages and dinosaurs imported at once.
+type Import struct {
+	Cages     []ImportCage
+	Dinosaurs []ImportDinosaur
+}
+
+// Import creates the cages of the import, then places its dinosaurs in their cages, within a single transaction.
+// Every dinosaur is checked with the placement rules of AddDinosaur against its cage as it stands with the dinosaurs
+// of the import placed before it. Cages and dinosaurs breaking the rules get their Err set, and a single rejected one
+// rolls back the whole import and returns ErrImportRejected. A dry run checks every row the same way, then rolls back.
+// All existing cages receiving dinosaurs stay locked until the import is stored.
+func (s *InventoryService) Import(ctx context.Context, imp *Import, dryRun bool) error {
+	err := s.store.Transaction(ctx, func(tx repository.Store) error {
+		rejected := false
+		cages := map[uint]dbmodels.Cage{}
+		created := map[string]uint{}
+		rejectedRefs := map[string]bool{}
+		for i := range imp.Cages {
+			item := &imp.Cages[i]
+			if item.Err != nil {
+				rejected = true
+				if item.Ref != "" {
+					rejectedRefs[item.Ref] = true
+				}
+				continue
+			}
+			if err := tx.Cages().Create(ctx, &item.Cage); err != nil {
+				return err
+			}
+			if err := recordCage(ctx, tx, apimodels.Created, nil, item.Cage); err != nil {
+				return err
+			}
+			cages[item.Cage.ID] = item.Cage
+			if item.Ref != "" {
+				created[item.Ref] = item.Cage.ID
+			}
+		}
+
+		existing := []uint{}
+		for i := range imp.Dinosaurs {
+			item := &imp.Dinosaurs[i]
+			if item.Err != nil {
+				continue
+			}
+			id, found := created[item.CageRef]
+			switch {
+			case item.CageRef == "":
+				if !slices.Contains(existing, item.Dinosaur.CageID) {
+					existing = append(existing, item.Dinosaur.CageID)
+				}
+			case found:
+				item.Dinosaur.CageID = id
+			case rejectedRefs[item.CageRef]:
+				item.Err = ErrImportCageRejected
+			default:
+				item.Err = ErrImportCageNotFound
+			}
+		}
+		// Locked one by one in ascending order, a missing cage only rejects the dinosaurs meant for it.
+		slices.Sort(existing)
+		for _, id := range existing {
+			if _, found := cages[id]; found {
+				continue
+			}
+			cage, err := lockCage(ctx, tx, id)
+			if err != nil && !errors.Is(err, ErrCageNotFound) {
+				return err
+			}
+			if err == nil {
+				cages[id] = cage
+			}
+		}
+
+		placed := map[uint]bool{}
+		for i := range imp.Dinosaurs {
+			item := &imp.Dinosaurs[i]
+			if item.Err == nil {
+				batchItem := BatchItem{Dinosaur: item.Dinosaur}
+				var err error
+				if item.Err, err = addBatchItem(ctx, tx, cages, &batchItem); err != nil {
+					return err
+				}
+				item.Dinosaur = batchItem.Dinosaur
+			}
+			if item.Err != nil {
+				rejected = true
+				continue
+			}
+			placed[item.Dinosaur.CageID] = true
+		}
+		if rejected {
+			return ErrImportRejected
+		}
+
+		for id := range placed {
+			if err := touchCage(ctx, tx, cages[id]); err != nil {
+				return err
+			}
+		}
+		if dryRun {
+			return errImportDryRun
+		}
+		return nil
+	})
+	if errors.Is(err, errImportDryRun) {
+		return nil
+	}
+	return fromConstraintError(err)
+}
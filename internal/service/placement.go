@@ -0,0 +1,117 @@
+package service
+
+import (
+	"slices"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+)
+
+// checkPlacement validates that the dinosaur can be placed in the cage, returning the first rule it breaks.
+// The cage must have its current dinosaurs loaded.
+func checkPlacement(cage dbmodels.Cage, dinosaur dbmodels.Dinosaur) error {
+	return firstViolation(placementViolations(cage, dinosaur))
+}
+
+// placementViolations returns every rule broken by placing the dinosaur in the cage, nil if it can be placed.
+// The cage must have its current dinosaurs loaded.
+func placementViolations(cage dbmodels.Cage, dinosaur dbmodels.Dinosaur) []error {
+	var violations []error
+	if cage.Capacity <= len(cage.Dinosaurs) {
+		violations = append(violations, cageError(cage.ID, ErrCageFull))
+	}
+
+	if cage.PowerStatus != string(apimodels.Active) {
+		violations = append(violations, cageError(cage.ID, ErrCageNoPower))
+	}
+
+	return append(violations, dietViolations(cage, dinosaur)...)
+}
+
+// checkDiet validates that the dinosaur can live together with the dinosaurs in the cage, returning the first rule it breaks.
+// The cage must have its current dinosaurs loaded, the dinosaur itself is skipped if it is among them.
+func checkDiet(cage dbmodels.Cage, dinosaur dbmodels.Dinosaur) error {
+	return firstViolation(dietViolations(cage, dinosaur))
+}
+
+// dietViolations returns every diet rule broken by the dinosaur living together with the dinosaurs in the cage.
+// The cage must have its current dinosaurs loaded, the dinosaur itself is skipped if it is among them.
+func dietViolations(cage dbmodels.Cage, dinosaur dbmodels.Dinosaur) []error {
+	for _, dinosaurInCage := range cage.Dinosaurs {
+		if dinosaurInCage.ID == dinosaur.ID {
+			continue
+		}
+		// A dinosaur has a single type, so it can break only one of the rules.
+		if dinosaur.Type == string(apimodels.Herbivore) && dinosaurInCage.Type == string(apimodels.Carnivore) {
+			return []error{cageError(cage.ID, ErrHerbivoreWithCarnivores)}
+		}
+		if dinosaur.Type == string(apimodels.Carnivore) && dinosaur.Species != dinosaurInCage.Species {
+			return []error{cageError(cage.ID, ErrCarnivoreWithOtherSpecies)}
+		}
+	}
+	return nil
+}
+
+// updateViolations returns every rule broken by changing the dinosaur from before to after.
+// A dinosaur moved to another cage has to follow the placement rules, one changing its species
+// has to get along with its cage-mates. The cage is the one the dinosaur ends up in, with its current dinosaurs loaded.
+func updateViolations(cage dbmodels.Cage, before dbmodels.Dinosaur, after dbmodels.Dinosaur) []error {
+	if after.CageID != before.CageID {
+		return placementViolations(cage, after)
+	}
+	if after.Species != before.Species {
+		return dietViolations(cage, after)
+	}
+	return nil
+}
+
+func firstViolation(violations []error) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return violations[0]
+}
+
+// checkMoves validates the cages as they stand after the dinosaurs have been moved from before to after.
+// Every cage receiving a dinosaur must have room and power for all its final occupants,
+// and the dinosaurs moved into it must get along with them. The cages must have their current dinosaurs loaded.
+func checkMoves(cages map[uint]dbmodels.Cage, before []dbmodels.Dinosaur, after []dbmodels.Dinosaur) error {
+	movedOut := map[uint]bool{}
+	movedIn := map[uint][]dbmodels.Dinosaur{}
+	for i, dinosaur := range after {
+		if dinosaur.CageID != before[i].CageID {
+			movedOut[dinosaur.ID] = true
+			movedIn[dinosaur.CageID] = append(movedIn[dinosaur.CageID], dinosaur)
+		}
+	}
+
+	// Cages are checked in ascending ID order, so the same moves are always rejected for the same reason.
+	ids := []uint{}
+	for id := range movedIn {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	for _, id := range ids {
+		cage, arrivals := cages[id], movedIn[id]
+		occupants := []dbmodels.Dinosaur{}
+		for _, dinosaur := range cage.Dinosaurs {
+			if !movedOut[dinosaur.ID] {
+				occupants = append(occupants, dinosaur)
+			}
+		}
+		cage.Dinosaurs = append(occupants, arrivals...)
+
+		if cage.Capacity < len(cage.Dinosaurs) {
+			return cageError(id, ErrCageFull)
+		}
+		if cage.PowerStatus != string(apimodels.Active) {
+			return cageError(id, ErrCageNoPower)
+		}
+		for _, dinosaur := range arrivals {
+			if err := checkDiet(cage, dinosaur); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
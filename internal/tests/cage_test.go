@@ -10,8 +10,8 @@ import (
 	"testing"
 
 	apimodels "pp-jurassic-park-api/internal/api/models"
-	"pp-jurassic-park-api/internal/db"
 	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -157,7 +157,7 @@ func TestUpdateCagePowerStatus(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, response.Code)
 
-		var updateResponse apimodels.UpdateCagePowerStatusResponse
+		var updateResponse apimodels.UpdateCageResponse
 		json.Unmarshal(response.Body.Bytes(), &updateResponse)
 		assertCage(t, updateResponse.Cage, 3, apimodels.Down, 0)
 	})
@@ -250,20 +250,26 @@ func TestDeleteCage(t *testing.T) {
 }
 
 func CreateTestCage(capacity int, powerStatus apimodels.PowerStatus) dbmodels.Cage {
-	dbConn, _ := db.Connect()
-
 	cage := dbmodels.Cage{
 		Capacity:    capacity,
 		PowerStatus: string(powerStatus),
 	}
-	dbConn.Create(&cage)
+	store.Cages().Create(ctx, &cage)
 	cageIDsToCleanup = append(cageIDsToCleanup, cage.ID)
 	return cage
 }
 
+// DeleteTestCages permanently removes the cages, along with any deleted dinosaurs still referencing them.
 func DeleteTestCages(ids []uint) {
-	dbConn, _ := db.Connect()
-	dbConn.Where("id IN (?)", ids).Delete(&dbmodels.Cage{})
+	dinosaurs, _ := store.Dinosaurs().Unscoped().List(ctx, repository.DinosaurFilter{}, repository.Page{})
+	for _, id := range ids {
+		for _, dinosaur := range dinosaurs {
+			if dinosaur.CageID == id {
+				store.Dinosaurs().Unscoped().Delete(ctx, dinosaur.ID)
+			}
+		}
+		store.Cages().Unscoped().Delete(ctx, id)
+	}
 }
 
 func assertCage(t *testing.T, cage apimodels.Cage, capacity int, powerStatus apimodels.PowerStatus, currentCount int) {
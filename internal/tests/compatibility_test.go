@@ -0,0 +1,149 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCompatibility(t *testing.T) {
+	cage := CreateTestCage(1, apimodels.Active)
+	blue := createTemporaryDinosaur(t, "Blue", apimodels.Velociraptor, apimodels.Carnivore, cage.ID)
+	sendMergePatch(cagePath(cage.ID), `{"power_status": "DOWN"}`)
+
+	t.Run("Every broken rule is reported", func(t *testing.T) {
+		compatibility := getCompatibility(t, fmt.Sprintf("%s/compatibility?species=Stegosaurus", cagePath(cage.ID)))
+
+		assert.False(t, compatibility.Compatible)
+		assert.Equal(t, []apimodels.ErrorCode{apimodels.CageFull, apimodels.CageUnpowered, apimodels.DietConflict},
+			violatedRules(compatibility.Violations))
+		if assert.Len(t, compatibility.Violations, 3) {
+			assert.Equal(t, "Dinosaur cannot be placed in cage that is already full.", compatibility.Violations[0].Message)
+		}
+
+		compatibility = getCompatibility(t, fmt.Sprintf("%s/compatibility?species=Tyrannosaurus", cagePath(cage.ID)))
+
+		assert.Equal(t, []apimodels.ErrorCode{apimodels.CageFull, apimodels.CageUnpowered, apimodels.SpeciesConflict},
+			violatedRules(compatibility.Violations))
+	})
+
+	t.Run("Compatible cage", func(t *testing.T) {
+		emptyCage := CreateTestCage(1, apimodels.Active)
+
+		response := sendWithIfMatch(http.MethodGet, fmt.Sprintf("%s/compatibility?species=Stegosaurus", cagePath(emptyCage.ID)), "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"compatible": true, "violations": []}`, response.Body.String())
+	})
+
+	t.Run("Existing dinosaur", func(t *testing.T) {
+		// Blue already lives in the cage, so only its cage-mates matter.
+		compatibility := getCompatibility(t, fmt.Sprintf("%s/compatibility?dinosaur_id=%d", cagePath(cage.ID), blue.ID))
+		assert.True(t, compatibility.Compatible)
+
+		herbivoreCage := CreateTestCage(2, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, herbivoreCage.ID)
+
+		compatibility = getCompatibility(t, fmt.Sprintf("%s/compatibility?dinosaur_id=%d", cagePath(herbivoreCage.ID), blue.ID))
+		assert.Equal(t, []apimodels.ErrorCode{apimodels.SpeciesConflict}, violatedRules(compatibility.Violations))
+	})
+
+	t.Run("Invalid requests", func(t *testing.T) {
+		for path, status := range map[string]int{
+			cagePath(cage.ID) + "/compatibility":                                   http.StatusBadRequest,
+			cagePath(cage.ID) + "/compatibility?species=Stegosaurus&dinosaur_id=1": http.StatusBadRequest,
+			cagePath(cage.ID) + "/compatibility?species=Dodo":                      http.StatusBadRequest,
+			cagePath(cage.ID) + "/compatibility?dinosaur_id=first":                 http.StatusBadRequest,
+			cagePath(cage.ID) + "/compatibility?dinosaur_id=123456":                http.StatusNotFound,
+			cagePath(123456) + "/compatibility?species=Stegosaurus":                http.StatusNotFound,
+		} {
+			response := sendWithIfMatch(http.MethodGet, path, "", "")
+
+			assert.Equal(t, status, response.Code, path)
+		}
+	})
+}
+
+func TestDryRunDinosaur(t *testing.T) {
+	t.Run("New dinosaur is checked but not added", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Active)
+		createTemporaryDinosaur(t, "Blue", apimodels.Velociraptor, apimodels.Carnivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodPost, "/dinosaurs?dry_run=true",
+			fmt.Sprintf(`{"name": "Bo", "species": "Stegosaurus", "cage_id": %d}`, cage.ID), "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var dryRun apimodels.DryRunDinosaurResponse
+		json.Unmarshal(response.Body.Bytes(), &dryRun)
+		assert.False(t, dryRun.Compatible)
+		assert.Equal(t, []apimodels.ErrorCode{apimodels.CageFull, apimodels.DietConflict}, violatedRules(dryRun.Violations))
+		assertDinosaur(t, dryRun.Dinosaur, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		assertCurrentCount(t, cage.ID, 1)
+	})
+
+	t.Run("Update is checked but not applied", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		bo := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		createTemporaryDinosaur(t, "Mo", apimodels.Triceratops, apimodels.Herbivore, cage.ID)
+
+		request := `{"name": "Rex", "species": "Tyrannosaurus"}`
+		response := sendWithIfMatch(http.MethodPatch, dinosaurPath(bo.ID)+"?dry_run=true", request, `"1"`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var dryRun apimodels.DryRunDinosaurResponse
+		json.Unmarshal(response.Body.Bytes(), &dryRun)
+		assert.Equal(t, []apimodels.ErrorCode{apimodels.SpeciesConflict}, violatedRules(dryRun.Violations))
+		assertDinosaur(t, dryRun.Dinosaur, "Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, cage.ID)
+
+		response = sendWithIfMatch(http.MethodGet, dinosaurPath(bo.ID), "", "")
+		assert.Contains(t, response.Body.String(), `"name":"Bo"`)
+		assert.Equal(t, `"1"`, response.Header().Get("ETag"))
+	})
+
+	t.Run("Compatible update", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		otherCage := CreateTestCage(2, apimodels.Active)
+		bo := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodPatch, dinosaurPath(bo.ID)+"?dry_run=true", fmt.Sprintf(`{"cage_id": %d}`, otherCage.ID), "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `"compatible":true`)
+		assertCurrentCount(t, cage.ID, 1)
+		assertCurrentCount(t, otherCage.ID, 0)
+	})
+
+	t.Run("Invalid dry run", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		response := sendWithIfMatch(http.MethodPost, "/dinosaurs?dry_run=maybe",
+			fmt.Sprintf(`{"name": "Bo", "species": "Stegosaurus", "cage_id": %d}`, cage.ID), "")
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+
+		response = sendWithIfMatch(http.MethodPost, "/dinosaurs?dry_run=true", `{"name": "Bo", "species": "Dodo", "cage_id": 1}`, "")
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assertCurrentCount(t, cage.ID, 0)
+	})
+}
+
+func getCompatibility(t *testing.T, path string) apimodels.GetCompatibilityResponse {
+	response := sendWithIfMatch(http.MethodGet, path, "", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	var getResponse apimodels.GetCompatibilityResponse
+	json.Unmarshal(response.Body.Bytes(), &getResponse)
+	return getResponse
+}
+
+func violatedRules(violations []apimodels.Violation) []apimodels.ErrorCode {
+	rules := []apimodels.ErrorCode{}
+	for _, violation := range violations {
+		rules = append(rules, violation.Rule)
+	}
+	return rules
+}
@@ -0,0 +1,155 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const parallelRequests = 20
+
+func TestConcurrentPlacement(t *testing.T) {
+	t.Run("Parallel additions never overfill a cage", func(t *testing.T) {
+		cage := CreateTestCage(3, apimodels.Active)
+
+		codes := sendInParallel(t, parallelRequests, func(i int) *http.Request {
+			payload := fmt.Sprintf(`{"name": "Stego %d", "species": "Stegosaurus", "cage_id": %d}`, i, cage.ID)
+			request, _ := http.NewRequest(http.MethodPost, "/dinosaurs", bytes.NewBufferString(payload))
+			return request
+		})
+
+		assert.Equal(t, 3, codes[http.StatusOK])
+		assert.Equal(t, parallelRequests-3, codes[http.StatusConflict])
+		assertCageInvariants(t, cage.ID)
+	})
+
+	t.Run("Parallel additions never mix diets or carnivore species", func(t *testing.T) {
+		cage := CreateTestCage(parallelRequests, apimodels.Active)
+		species := []apimodels.Species{apimodels.Tyrannosaurus, apimodels.Velociraptor, apimodels.Brachiosaurus, apimodels.Triceratops}
+
+		codes := sendInParallel(t, parallelRequests, func(i int) *http.Request {
+			payload := fmt.Sprintf(`{"name": "Dino %d", "species": "%s", "cage_id": %d}`, i, species[i%len(species)], cage.ID)
+			request, _ := http.NewRequest(http.MethodPost, "/dinosaurs", bytes.NewBufferString(payload))
+			return request
+		})
+
+		assert.Greater(t, codes[http.StatusOK], 0)
+		assert.Equal(t, parallelRequests, codes[http.StatusOK]+codes[http.StatusConflict])
+		assertCageInvariants(t, cage.ID)
+	})
+
+	t.Run("Parallel moves never overfill a cage", func(t *testing.T) {
+		sourceCage := CreateTestCage(parallelRequests, apimodels.Active)
+		targetCage := CreateTestCage(2, apimodels.Active)
+		var dinosaurs []dbmodels.Dinosaur
+		for i := 0; i < parallelRequests; i++ {
+			dinosaurs = append(dinosaurs, createTemporaryDinosaur(t, fmt.Sprintf("Ankylo %d", i), apimodels.Ankylosaurus, apimodels.Herbivore, sourceCage.ID))
+		}
+
+		codes := sendInParallel(t, parallelRequests, func(i int) *http.Request {
+			payload := fmt.Sprintf(`{"cage_id": %d}`, targetCage.ID)
+			request, _ := http.NewRequest(http.MethodPatch, "/dinosaurs/"+strconv.FormatUint(uint64(dinosaurs[i].ID), 10), bytes.NewBufferString(payload))
+			return request
+		})
+
+		assert.Equal(t, 2, codes[http.StatusOK])
+		assertCageInvariants(t, targetCage.ID)
+	})
+
+	t.Run("Power outage and additions are serialized", func(t *testing.T) {
+		cage := CreateTestCage(parallelRequests, apimodels.Active)
+
+		sendInParallel(t, parallelRequests, func(i int) *http.Request {
+			if i == parallelRequests/2 {
+				request, _ := http.NewRequest(http.MethodPatch, "/cages/"+strconv.FormatUint(uint64(cage.ID), 10), bytes.NewBufferString(`{"power_status": "DOWN"}`))
+				return request
+			}
+			payload := fmt.Sprintf(`{"name": "Brachio %d", "species": "Brachiosaurus", "cage_id": %d}`, i, cage.ID)
+			request, _ := http.NewRequest(http.MethodPost, "/dinosaurs", bytes.NewBufferString(payload))
+			return request
+		})
+
+		reloaded, err := store.Cages().Get(ctx, cage.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, string(apimodels.Down), reloaded.PowerStatus)
+		t.Cleanup(func() { deleteDinosaursInCage(cage.ID) })
+	})
+}
+
+// sendInParallel fires the requests at once and counts responses by status code.
+// Dinosaurs created along the way are removed once the test finishes.
+func sendInParallel(t *testing.T, count int, buildRequest func(i int) *http.Request) map[int]int {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	codes := map[int]int{}
+
+	for i := 0; i < count; i++ {
+		request := buildRequest(i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, request)
+
+			var created apimodels.AddDinosaurResponse
+			json.Unmarshal(response.Body.Bytes(), &created)
+
+			mu.Lock()
+			defer mu.Unlock()
+			codes[response.Code]++
+			if request.Method == http.MethodPost && response.Code == http.StatusOK {
+				t.Cleanup(func() { store.Dinosaurs().Unscoped().Delete(ctx, created.Dinosaur.ID) })
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	return codes
+}
+
+func createTemporaryDinosaur(t *testing.T, name string, species apimodels.Species, dinosaurType apimodels.DinosaurType, cageID uint) dbmodels.Dinosaur {
+	dinosaur := dbmodels.Dinosaur{Name: name, Species: string(species), Type: string(dinosaurType), CageID: cageID}
+	store.Dinosaurs().Create(ctx, &dinosaur)
+	t.Cleanup(func() { store.Dinosaurs().Unscoped().Delete(ctx, dinosaur.ID) })
+	return dinosaur
+}
+
+func deleteDinosaursInCage(cageID uint) {
+	cage, _ := store.Cages().Get(ctx, cageID)
+	for _, dinosaur := range cage.Dinosaurs {
+		store.Dinosaurs().Unscoped().Delete(ctx, dinosaur.ID)
+	}
+}
+
+// assertCageInvariants checks the park safety rules against the stored state of the cage.
+func assertCageInvariants(t *testing.T, cageID uint) {
+	cage, err := store.Cages().Get(ctx, cageID)
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(cage.Dinosaurs), cage.Capacity)
+
+	carnivoreSpecies := map[string]bool{}
+	herbivores := 0
+	for _, dinosaur := range cage.Dinosaurs {
+		if dinosaur.Type == string(apimodels.Carnivore) {
+			carnivoreSpecies[dinosaur.Species] = true
+		} else {
+			herbivores++
+		}
+	}
+	assert.LessOrEqual(t, len(carnivoreSpecies), 1, "carnivores of different species share a cage")
+	if len(carnivoreSpecies) > 0 {
+		assert.Zero(t, herbivores, "herbivores share a cage with carnivores")
+	}
+}
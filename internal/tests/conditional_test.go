@@ -0,0 +1,252 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConditionalReads(t *testing.T) {
+	t.Run("Records are timestamped", func(t *testing.T) {
+		before := time.Now().Add(-time.Second)
+		cage := CreateTestCage(2, apimodels.Active)
+		assert.True(t, cage.CreatedAt.After(before))
+		assert.Equal(t, cage.CreatedAt, cage.UpdatedAt)
+
+		sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"power_status": "DOWN"}`, "")
+		updated, err := store.Cages().Get(ctx, cage.ID)
+		assert.NoError(t, err)
+		assert.WithinDuration(t, cage.CreatedAt, updated.CreatedAt, time.Millisecond)
+		assert.True(t, updated.UpdatedAt.After(updated.CreatedAt))
+
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, activeCage.ID)
+		assert.False(t, dinosaur.CreatedAt.IsZero())
+		assert.Equal(t, dinosaur.CreatedAt, dinosaur.UpdatedAt)
+	})
+
+	t.Run("Unchanged cage", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		waitForNextSecond()
+		response := sendWithIfMatch(http.MethodGet, cagePath(cage.ID), "", "")
+		etag := response.Header().Get("ETag")
+		lastModified := response.Header().Get("Last-Modified")
+		assert.Equal(t, `"1.1"`, etag)
+		assert.NotEmpty(t, lastModified)
+
+		response = sendConditional(cagePath(cage.ID), "If-None-Match", etag)
+		assert.Equal(t, http.StatusNotModified, response.Code)
+		assert.Empty(t, response.Body.String())
+		assert.Equal(t, etag, response.Header().Get("ETag"))
+
+		response = sendConditional("/v2"+cagePath(cage.ID), "If-None-Match", "W/"+etag)
+		assert.Equal(t, http.StatusNotModified, response.Code)
+
+		response = sendConditional(cagePath(cage.ID), "If-Modified-Since", lastModified)
+		assert.Equal(t, http.StatusNotModified, response.Code)
+		assert.Equal(t, lastModified, response.Header().Get("Last-Modified"))
+	})
+
+	t.Run("Changed cage", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		etag := sendWithIfMatch(http.MethodGet, cagePath(cage.ID), "", "").Header().Get("ETag")
+
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"capacity": 3}`, "")
+
+		response := sendConditional(cagePath(cage.ID), "If-None-Match", etag)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, `"2.1"`, response.Header().Get("ETag"))
+
+		// If-None-Match takes precedence over If-Modified-Since.
+		request, _ := http.NewRequest(http.MethodGet, cagePath(cage.ID), nil)
+		request.Header.Set("If-None-Match", etag)
+		request.Header.Set("If-Modified-Since", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		response = httptest.NewRecorder()
+		router.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		lastModified, _ := http.ParseTime(response.Header().Get("Last-Modified"))
+		response = sendConditional(cagePath(cage.ID), "If-Modified-Since", lastModified.Add(-time.Second).Format(http.TimeFormat))
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("Cage changed within the second of the copy", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		lastModified := sendWithIfMatch(http.MethodGet, cagePath(cage.ID), "", "").Header().Get("Last-Modified")
+
+		// Still in the second of the copy, or just past it: either way the change is after Last-Modified.
+		sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"capacity": 3}`, "")
+
+		response := sendConditional(cagePath(cage.ID), "If-Modified-Since", lastModified)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("Deleted cage", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		etag := sendWithIfMatch(http.MethodGet, cagePath(cage.ID), "", "").Header().Get("ETag")
+		sendWithIfMatch(http.MethodDelete, cagePath(cage.ID), "", "")
+
+		response := sendConditional(cagePath(cage.ID)+"?include_deleted=true", "If-None-Match", etag)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `"deleted_at"`)
+		assert.Empty(t, response.Header().Get("ETag"))
+	})
+
+	t.Run("Cage read with fields", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		etag := sendWithIfMatch(http.MethodGet, cagePath(cage.ID), "", "").Header().Get("ETag")
+
+		response := sendConditional(cagePath(cage.ID)+"?fields=id", "If-None-Match", etag)
+		assert.Equal(t, http.StatusOK, response.Code)
+		viewETag := response.Header().Get("ETag")
+		assert.Regexp(t, `^W/"1\.1-[0-9a-f]{8}"$`, viewETag)
+
+		response = sendConditional(cagePath(cage.ID)+"?fields=id", "If-None-Match", viewETag)
+		assert.Equal(t, http.StatusNotModified, response.Code)
+
+		response = sendConditional(cagePath(cage.ID)+"?fields=capacity", "If-None-Match", viewETag)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.NotEqual(t, viewETag, response.Header().Get("ETag"))
+	})
+
+	t.Run("Unchanged page", func(t *testing.T) {
+		cage := CreateTestCage(77, apimodels.Active)
+		path := "/cages?capacity_gte=77&capacity_lte=77"
+		waitForNextSecond()
+		response := sendWithIfMatch(http.MethodGet, path, "", "")
+		etag := response.Header().Get("ETag")
+		lastModified := response.Header().Get("Last-Modified")
+		assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+		assert.NotEmpty(t, lastModified)
+
+		response = sendConditional(path, "If-None-Match", etag)
+		assert.Equal(t, http.StatusNotModified, response.Code)
+		assert.Empty(t, response.Body.String())
+
+		response = sendConditional(path, "If-Modified-Since", lastModified)
+		assert.Equal(t, http.StatusNotModified, response.Code)
+
+		// Fields change the page, but not the cages.
+		response = sendConditional(path+"&fields=id", "If-None-Match", etag)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"cages": [{"id": %d}]}`, cage.ID), response.Body.String())
+	})
+
+	t.Run("Changed page", func(t *testing.T) {
+		cage := CreateTestCage(78, apimodels.Active)
+		path := "/v2/cages?capacity_gte=78&capacity_lte=78"
+		response := sendWithIfMatch(http.MethodGet, path, "", "")
+		etag := response.Header().Get("ETag")
+
+		sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"power_status": "DOWN"}`, "")
+
+		response = sendConditional(path, "If-None-Match", etag)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.NotEqual(t, etag, response.Header().Get("ETag"))
+
+		// Deleting the cage removes it from the page as well.
+		etag = response.Header().Get("ETag")
+		lastModified, _ := http.ParseTime(response.Header().Get("Last-Modified"))
+		sendWithIfMatch(http.MethodDelete, cagePath(cage.ID), "", "")
+
+		response = sendConditional(path, "If-None-Match", etag)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"cages": []}`, response.Body.String())
+		modified, _ := http.ParseTime(response.Header().Get("Last-Modified"))
+		assert.False(t, modified.Before(lastModified))
+	})
+
+	t.Run("Dinosaur with its cage", func(t *testing.T) {
+		cage := CreateTestCage(3, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Conditional Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, cage.ID)
+		waitForNextSecond()
+		path := dinosaurPath(dinosaur.ID) + "?include=cage"
+		response := sendWithIfMatch(http.MethodGet, path, "", "")
+		lastModified := response.Header().Get("Last-Modified")
+
+		// The version of the dinosaur does not tell whether its cage has changed.
+		assert.Empty(t, response.Header().Get("ETag"))
+		etag := sendWithIfMatch(http.MethodGet, dinosaurPath(dinosaur.ID), "", "").Header().Get("ETag")
+		response = sendConditional(path, "If-None-Match", etag)
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		response = sendConditional(path, "If-Modified-Since", lastModified)
+		assert.Equal(t, http.StatusNotModified, response.Code)
+
+		response = sendConditional("/dinosaurs?include=cage&name=Conditional%20Rex", "If-Modified-Since", lastModified)
+		assert.Equal(t, http.StatusNotModified, response.Code)
+
+		response = sendConditional(dinosaurPath(dinosaur.ID), "If-None-Match", etag)
+		assert.Equal(t, http.StatusNotModified, response.Code)
+
+		// Changes of other cages and dinosaurs leave the page as it is.
+		other := CreateTestCage(3, apimodels.Active)
+		sendWithIfMatch(http.MethodPatch, cagePath(other.ID), `{"capacity": 4}`, "")
+		createTemporaryDinosaur(t, "Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, other.ID)
+
+		response = sendConditional("/dinosaurs?include=cage&name=Conditional%20Rex", "If-Modified-Since", lastModified)
+		assert.Equal(t, http.StatusNotModified, response.Code)
+
+		sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"capacity": 4}`, "")
+
+		response = sendConditional("/dinosaurs?include=cage&name=Conditional%20Rex", "If-Modified-Since", lastModified)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("Dinosaur deleted from the page", func(t *testing.T) {
+		cage := CreateTestCage(3, apimodels.Active)
+		createTemporaryDinosaur(t, "Deleted Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, cage.ID)
+		deleted := createTemporaryDinosaur(t, "Deleted Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, cage.ID)
+		waitForNextSecond()
+		path := "/dinosaurs?name=Deleted%20Rex"
+		lastModified := sendWithIfMatch(http.MethodGet, path, "", "").Header().Get("Last-Modified")
+
+		response := sendConditional(path, "If-Modified-Since", lastModified)
+		assert.Equal(t, http.StatusNotModified, response.Code)
+
+		sendWithIfMatch(http.MethodDelete, dinosaurPath(deleted.ID), "", "")
+
+		response = sendConditional(path, "If-Modified-Since", lastModified)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("Total of the page", func(t *testing.T) {
+		CreateTestCage(81, apimodels.Active)
+		waitForNextSecond()
+		path := "/cages?capacity_gte=81&capacity_lte=81&limit=1&include_total=true"
+		lastModified := sendWithIfMatch(http.MethodGet, path, "", "").Header().Get("Last-Modified")
+
+		// Another cage past the page still changes its total.
+		CreateTestCage(81, apimodels.Active)
+
+		response := sendConditional(path, "If-Modified-Since", lastModified)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("Invalid If-Modified-Since is ignored", func(t *testing.T) {
+		response := sendConditional(cagePath(cageWithTyrannosaurus.ID), "If-Modified-Since", "yesterday")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+}
+
+func sendConditional(path string, header string, value string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(http.MethodGet, path, nil)
+	request.Header.Set(header, value)
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	return response
+}
+
+// waitForNextSecond sleeps until the current second is over, since Last-Modified only tells seconds apart
+// and changes within the current second are never reported as unmodified.
+func waitForNextSecond() {
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+}
@@ -0,0 +1,184 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"pp-jurassic-park-api/internal/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("Defaults with required database settings", func(t *testing.T) {
+		clearConfigEnv(t)
+
+		cfg, args, err := config.Load([]string{"-db-user", "gorm", "-db-name", "gorm"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, args)
+		assert.Equal(t, ":8080", cfg.Server.Addr)
+		assert.Equal(t, ":9090", cfg.GRPC.Addr)
+		assert.Equal(t, "localhost", cfg.Database.Host)
+		assert.Equal(t, "info", cfg.Log.Level)
+		assert.False(t, cfg.Features.DebugEndpoints)
+	})
+
+	t.Run("Flags override environment which overrides the config file", func(t *testing.T) {
+		clearConfigEnv(t)
+		configFile := writeConfigFile(t, `{
+			"server": {"addr": ":9000", "read_timeout": "3s"},
+			"database": {"host": "file-host", "user": "file-user", "name": "park", "port": 6000},
+			"log": {"level": "warn"}
+		}`)
+		t.Setenv("DB_HOST", "env-host")
+		t.Setenv("DB_PORT", "7000")
+		t.Setenv("LOG_LEVEL", "error")
+
+		cfg, _, err := config.Load([]string{"-config", configFile, "-db-port", "8000", "-feature-debug-endpoints"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ":9000", cfg.Server.Addr)
+		assert.Equal(t, config.Duration(3*time.Second), cfg.Server.ReadTimeout)
+		assert.Equal(t, "file-user", cfg.Database.User)
+		assert.Equal(t, "env-host", cfg.Database.Host)
+		assert.Equal(t, "error", cfg.Log.Level)
+		assert.Equal(t, 8000, cfg.Database.Port)
+		assert.True(t, cfg.Features.DebugEndpoints)
+	})
+
+	t.Run("Config file path from environment", func(t *testing.T) {
+		clearConfigEnv(t)
+		t.Setenv("CONFIG_FILE", writeConfigFile(t, `{"database": {"user": "gorm", "name": "gorm"}}`))
+
+		cfg, _, err := config.Load(nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "gorm", cfg.Database.User)
+	})
+
+	t.Run("Remaining arguments are returned", func(t *testing.T) {
+		clearConfigEnv(t)
+
+		_, args, err := config.Load([]string{"-db-user", "gorm", "-db-name", "gorm", "migrate", "status"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"migrate", "status"}, args)
+	})
+
+	t.Run("Unparsable environment value", func(t *testing.T) {
+		clearConfigEnv(t)
+		t.Setenv("DB_PORT", "not-a-port")
+
+		_, _, err := config.Load([]string{"-db-user", "gorm", "-db-name", "gorm"})
+
+		assert.ErrorContains(t, err, "DB_PORT")
+	})
+
+	t.Run("Unparsable flag value", func(t *testing.T) {
+		clearConfigEnv(t)
+
+		_, _, err := config.Load([]string{"-db-user", "gorm", "-db-name", "gorm", "-http-read-timeout", "soon"})
+
+		assert.ErrorContains(t, err, "-http-read-timeout")
+	})
+
+	t.Run("Invalid config file", func(t *testing.T) {
+		clearConfigEnv(t)
+
+		_, _, err := config.Load([]string{"-config", writeConfigFile(t, `{"server": {"read_timeout": 5}}`)})
+
+		assert.ErrorContains(t, err, "invalid config file")
+	})
+
+	t.Run("Unknown keys of the config file are reported", func(t *testing.T) {
+		clearConfigEnv(t)
+		configFile := writeConfigFile(t, `{"server": {"read_timout": "5s"}, "database": {"port": 0}, "loggin": {}}`)
+
+		_, _, err := config.Load([]string{"-config", configFile, "-db-user", "gorm", "-db-name", "gorm"})
+
+		assert.ErrorContains(t, err, "server.read_timout is not a known setting")
+		assert.ErrorContains(t, err, "loggin is not a known setting")
+		assert.ErrorContains(t, err, "database.port")
+	})
+
+	t.Run("All invalid settings are reported", func(t *testing.T) {
+		clearConfigEnv(t)
+
+		_, _, err := config.Load([]string{"-http-addr", "8080", "-db-port", "0", "-db-sslmode", "maybe", "-log-level", "loud",
+			"-grpc-addr", "9090", "-grpc-watch-interval", "0s"})
+
+		assert.ErrorContains(t, err, "server.addr")
+		assert.ErrorContains(t, err, "grpc.addr")
+		assert.ErrorContains(t, err, "grpc.watch_interval")
+		assert.ErrorContains(t, err, "database.port")
+		assert.ErrorContains(t, err, "database.user")
+		assert.ErrorContains(t, err, "database.name")
+		assert.ErrorContains(t, err, "database.sslmode")
+		assert.ErrorContains(t, err, "log.level")
+	})
+
+	t.Run("SQLite only requires a database file", func(t *testing.T) {
+		clearConfigEnv(t)
+		t.Setenv("DB_DRIVER", "sqlite")
+
+		cfg, _, err := config.Load([]string{"-db-path", "park.db"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, config.DriverSQLite, cfg.Database.Driver)
+		assert.Equal(t, "park.db", cfg.Database.Path)
+	})
+
+	t.Run("Invalid driver settings are reported", func(t *testing.T) {
+		clearConfigEnv(t)
+
+		_, _, err := config.Load([]string{"-db-driver", "sqlite"})
+		assert.ErrorContains(t, err, "database.path")
+
+		_, _, err = config.Load([]string{"-db-driver", "mysql"})
+		assert.ErrorContains(t, err, "database.driver")
+	})
+}
+
+func TestDatabaseDSN(t *testing.T) {
+	t.Run("Credentials are escaped", func(t *testing.T) {
+		cfg := config.Default().Database
+		cfg.Host = "db"
+		cfg.User = "park"
+		cfg.Password = "p@ss word"
+		cfg.Name = "jurassic"
+
+		assert.Equal(t, "postgres://park:p%40ss%20word@db:5432/jurassic?TimeZone=UTC&sslmode=disable", cfg.DSN())
+	})
+
+	t.Run("SQLite enables foreign keys and immediate transactions", func(t *testing.T) {
+		cfg := config.Default().Database
+		cfg.Driver = config.DriverSQLite
+		cfg.Path = "data/park.db"
+
+		assert.Equal(t, "file:data/park.db?_busy_timeout=5000&_foreign_keys=on&_journal_mode=WAL&_txlock=immediate", cfg.DSN())
+	})
+}
+
+func clearConfigEnv(t *testing.T) {
+	for _, name := range []string{
+		"CONFIG_FILE", "HTTP_ADDR", "HTTP_READ_TIMEOUT", "HTTP_WRITE_TIMEOUT", "HTTP_IDLE_TIMEOUT", "HTTP_SHUTDOWN_TIMEOUT",
+		"GRPC_ADDR", "GRPC_WATCH_INTERVAL",
+		"DB_DRIVER", "DB_PATH", "DB_HOST", "DB_PORT", "DB_USER", "DB_PASSWORD", "DB_NAME", "DB_SSLMODE", "DB_TIMEZONE",
+		"DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "DB_CONN_MAX_LIFETIME", "DB_CONN_MAX_IDLE_TIME",
+		"LOG_LEVEL", "FEATURE_DEBUG_ENDPOINTS",
+	} {
+		t.Setenv(name, "")
+		os.Unsetenv(name)
+	}
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
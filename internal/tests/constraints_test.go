@@ -0,0 +1,267 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"pp-jurassic-park-api/internal/api/handlers"
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestDatabaseConstraints(t *testing.T) {
+	if testDB == nil {
+		t.Skip("requires a database backend")
+	}
+
+	t.Run("Full cage", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		err := createRawDinosaur(t, "Co", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		assertConstraint(t, err, repository.ConstraintCageCapacity)
+	})
+
+	t.Run("Cage without power", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Down)
+
+		err := createRawDinosaur(t, "Co", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		assertConstraint(t, err, repository.ConstraintCagePowered)
+	})
+
+	t.Run("Herbivore with carnivores", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		createTemporaryDinosaur(t, "Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, cage.ID)
+
+		err := createRawDinosaur(t, "Co", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		assertConstraint(t, err, repository.ConstraintNoHerbivoresCarnivores)
+	})
+
+	t.Run("Carnivores of different species", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		createTemporaryDinosaur(t, "Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, cage.ID)
+
+		err := createRawDinosaur(t, "Blue", apimodels.Velociraptor, apimodels.Carnivore, cage.ID)
+
+		assertConstraint(t, err, repository.ConstraintSingleCarnivoreSpecies)
+	})
+
+	t.Run("Unknown cage", func(t *testing.T) {
+		err := createRawDinosaur(t, "Co", apimodels.Stegosaurus, apimodels.Herbivore, 123456)
+
+		assertConstraint(t, err, repository.ConstraintDinosaurCage)
+	})
+
+	t.Run("Occupied cage cannot be deleted", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		err := store.Cages().Delete(ctx, cage.ID)
+		assertConstraint(t, err, repository.ConstraintCageEmptyOnDelete)
+
+		err = store.Cages().Unscoped().Delete(ctx, cage.ID)
+		assertConstraint(t, err, repository.ConstraintDinosaurCage)
+	})
+
+	t.Run("Deleted cage", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		assert.NoError(t, store.Cages().Delete(ctx, cage.ID))
+
+		err := createRawDinosaur(t, "Co", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		assertConstraint(t, err, repository.ConstraintCageNotDeleted)
+	})
+
+	t.Run("Restored dinosaur is placed again", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Active)
+		removed := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		assert.NoError(t, store.Dinosaurs().Delete(ctx, removed.ID))
+		createTemporaryDinosaur(t, "Mo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		restored, _ := store.Dinosaurs().Unscoped().Get(ctx, removed.ID)
+		restored.DeletedAt = gorm.DeletedAt{}
+		err := store.Dinosaurs().Unscoped().Update(ctx, &restored)
+
+		assertConstraint(t, err, repository.ConstraintCageCapacity)
+	})
+
+	t.Run("Moves are checked once all are stored", func(t *testing.T) {
+		rexCage := CreateTestCage(1, apimodels.Active)
+		spinoCage := CreateTestCage(1, apimodels.Active)
+		rex := createTemporaryDinosaur(t, "Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, rexCage.ID)
+		spino := createTemporaryDinosaur(t, "Spino", apimodels.Spinosaurus, apimodels.Carnivore, spinoCage.ID)
+
+		rex.CageID, spino.CageID = spinoCage.ID, rexCage.ID
+		assert.NoError(t, store.Dinosaurs().Move(ctx, []*dbmodels.Dinosaur{&rex, &spino}))
+
+		moved, _ := store.Dinosaurs().Get(ctx, rex.ID)
+		assert.Equal(t, spinoCage.ID, moved.CageID)
+		assert.False(t, moved.DeletedAt.Valid)
+
+		// Moving the Spinosaurus back alone leaves two carnivores of different species in one full cage.
+		spino.CageID = spinoCage.ID
+		err := store.Dinosaurs().Move(ctx, []*dbmodels.Dinosaur{&spino})
+
+		assertConstraint(t, err, repository.ConstraintCageCapacity)
+		unmoved, _ := store.Dinosaurs().Get(ctx, spino.ID)
+		assert.Equal(t, rexCage.ID, unmoved.CageID)
+	})
+
+	t.Run("Moves leave the placement checks in place", func(t *testing.T) {
+		fullCage := CreateTestCage(1, apimodels.Active)
+		downCage := CreateTestCage(2, apimodels.Down)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, fullCage.ID)
+		mo := createTemporaryDinosaur(t, "Mo", apimodels.Stegosaurus, apimodels.Herbivore, CreateTestCage(1, apimodels.Active).ID)
+
+		mo.CageID = downCage.ID
+		err := store.Dinosaurs().Move(ctx, []*dbmodels.Dinosaur{&mo})
+		assertConstraint(t, err, repository.ConstraintCagePowered)
+
+		err = testDB.Exec("UPDATE dinosaurs SET cage_id = ? WHERE id = ?", fullCage.ID, mo.ID).Error
+		assert.ErrorContains(t, err, repository.ConstraintCageCapacity)
+	})
+
+	t.Run("Capacity cannot drop below occupancy", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		createTemporaryDinosaur(t, "Mo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		err := testDB.Exec("UPDATE cages SET capacity = 1 WHERE id = ?", cage.ID).Error
+
+		assert.ErrorContains(t, err, repository.ConstraintCapacityAboveOccupancy)
+	})
+
+	t.Run("Species must match diet", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		err := testDB.Exec("INSERT INTO dinosaurs (name, species, type, cage_id) VALUES ('Rex', 'Tyrannosaurus', 'HERBIVORE', ?)", cage.ID).Error
+
+		assert.ErrorContains(t, err, "dinosaurs_species_type_valid")
+	})
+}
+
+func TestDatabaseConstraintResponses(t *testing.T) {
+	if testDB == nil {
+		t.Skip("requires a database backend")
+	}
+
+	// The rule-blind store hides cage occupants and power from the service,
+	// so only the database is left to reject invalid placements.
+	blindStore := ruleBlindStore{Store: store}
+	blindRouter := gin.Default()
+	blindRouter.POST("/dinosaurs", handlers.NewDinosaurHandler(service.NewDinosaurService(blindStore), service.NewIdempotencyService(blindStore, time.Second)).AddDinosaur)
+
+	tests := []struct {
+		name     string
+		cage     func() dbmodels.Cage
+		species  apimodels.Species
+		expected string
+	}{
+		{
+			name: "Full cage",
+			cage: func() dbmodels.Cage {
+				cage := CreateTestCage(1, apimodels.Active)
+				createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+				return cage
+			},
+			species:  apimodels.Stegosaurus,
+			expected: "Dinosaur cannot be placed in cage that is already full.",
+		},
+		{
+			name:     "Cage without power",
+			cage:     func() dbmodels.Cage { return CreateTestCage(2, apimodels.Down) },
+			species:  apimodels.Stegosaurus,
+			expected: "Dinosaur cannot be placed in cage that has no power.",
+		},
+		{
+			name: "Herbivore with carnivores",
+			cage: func() dbmodels.Cage {
+				cage := CreateTestCage(2, apimodels.Active)
+				createTemporaryDinosaur(t, "Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, cage.ID)
+				return cage
+			},
+			species:  apimodels.Stegosaurus,
+			expected: "Herbivore Dinosaur cannot be placed in cage with Carnivores.",
+		},
+		{
+			name: "Carnivores of different species",
+			cage: func() dbmodels.Cage {
+				cage := CreateTestCage(2, apimodels.Active)
+				createTemporaryDinosaur(t, "Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, cage.ID)
+				return cage
+			},
+			species:  apimodels.Velociraptor,
+			expected: "Carnivore Dinosaur cannot be placed in cage with any other species.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cage := test.cage()
+			payload := fmt.Sprintf(`{"name": "Newcomer", "species": "%s", "cage_id": %d}`, test.species, cage.ID)
+			request, _ := http.NewRequest(http.MethodPost, "/dinosaurs", bytes.NewBufferString(payload))
+			response := httptest.NewRecorder()
+
+			blindRouter.ServeHTTP(response, request)
+
+			assert.Equal(t, http.StatusConflict, response.Code)
+			assert.Contains(t, response.Body.String(), test.expected)
+		})
+	}
+}
+
+type ruleBlindStore struct {
+	repository.Store
+}
+
+func (s ruleBlindStore) Cages() repository.CageRepository {
+	return ruleBlindCages{CageRepository: s.Store.Cages()}
+}
+
+func (s ruleBlindStore) Transaction(ctx context.Context, fn func(tx repository.Store) error) error {
+	return s.Store.Transaction(ctx, func(tx repository.Store) error {
+		return fn(ruleBlindStore{Store: tx})
+	})
+}
+
+type ruleBlindCages struct {
+	repository.CageRepository
+}
+
+func (r ruleBlindCages) GetForUpdate(ctx context.Context, id uint) (dbmodels.Cage, error) {
+	cage, err := r.CageRepository.GetForUpdate(ctx, id)
+	cage.Dinosaurs = nil
+	cage.PowerStatus = string(apimodels.Active)
+	return cage, err
+}
+
+func createRawDinosaur(t *testing.T, name string, species apimodels.Species, dinosaurType apimodels.DinosaurType, cageID uint) error {
+	dinosaur := dbmodels.Dinosaur{Name: name, Species: string(species), Type: string(dinosaurType), CageID: cageID}
+	err := store.Dinosaurs().Create(ctx, &dinosaur)
+	if err == nil {
+		t.Cleanup(func() { store.Dinosaurs().Unscoped().Delete(ctx, dinosaur.ID) })
+	}
+	return err
+}
+
+func assertConstraint(t *testing.T, err error, constraint string) {
+	var constraintErr *repository.ConstraintError
+	if assert.True(t, errors.As(err, &constraintErr), "expected constraint violation, got %v", err) {
+		assert.Equal(t, constraint, constraintErr.Constraint)
+	}
+}
@@ -0,0 +1,43 @@
+package tests
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"pp-jurassic-park-api/internal/api/handlers"
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/config"
+	"pp-jurassic-park-api/internal/db"
+
+	"github.com/gin-gonic/gin"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDBStats(t *testing.T) {
+	t.Run("Pool statistics reflect configured limits", func(t *testing.T) {
+		// sql.Open does not connect, so pool statistics are available without a running database.
+		sqlDB, _ := sql.Open("pgx", "host=localhost")
+		defer sqlDB.Close()
+		db.ConfigurePool(sqlDB, config.Database{MaxOpenConns: 7, MaxIdleConns: 2, ConnMaxLifetime: config.Duration(time.Minute)})
+
+		debugRouter := gin.Default()
+		debugRouter.GET("/debug/db", handlers.NewDebugHandler(sqlDB).GetDBStats)
+
+		request, _ := http.NewRequest(http.MethodGet, "/debug/db", nil)
+		response := httptest.NewRecorder()
+
+		debugRouter.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var statsResponse apimodels.GetDBStatsResponse
+		json.Unmarshal(response.Body.Bytes(), &statsResponse)
+		assert.Equal(t, 7, statsResponse.Stats.MaxOpenConnections)
+		assert.Equal(t, 0, statsResponse.Stats.InUse)
+	})
+}
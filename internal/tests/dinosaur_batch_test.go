@@ -0,0 +1,138 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddDinosaurs(t *testing.T) {
+	t.Run("Whole batch is added", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		t.Cleanup(func() { deleteDinosaursInCage(cage.ID) })
+
+		response, batch := addBatch(t, fmt.Sprintf(`{"dinosaurs": [
+			{"name": "Bo", "species": "Stegosaurus", "cage_id": %[1]d},
+			{"name": "Mo", "species": "Triceratops", "cage_id": %[1]d}
+		]}`, cage.ID))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		if assert.Len(t, batch.Results, 2) {
+			for i, name := range []string{"Bo", "Mo"} {
+				assert.Equal(t, i, batch.Results[i].Index)
+				assert.Equal(t, apimodels.BatchItemCreated, batch.Results[i].Status)
+				if assert.NotNil(t, batch.Results[i].Dinosaur) {
+					assert.Equal(t, name, batch.Results[i].Dinosaur.Name)
+					assert.Greater(t, batch.Results[i].Dinosaur.ID, uint(0))
+				}
+			}
+		}
+		assertCurrentCount(t, cage.ID, 2)
+	})
+
+	t.Run("Batch members count against each other", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response, batch := addBatch(t, fmt.Sprintf(`{"mode": "all_or_nothing", "dinosaurs": [
+			{"name": "Mo", "species": "Stegosaurus", "cage_id": %[1]d},
+			{"name": "Jo", "species": "Stegosaurus", "cage_id": %[1]d}
+		]}`, cage.ID))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		if assert.Len(t, batch.Results, 2) {
+			assert.Equal(t, apimodels.BatchItemRolledBack, batch.Results[0].Status)
+			assert.Nil(t, batch.Results[0].Dinosaur)
+			assert.Equal(t, apimodels.BatchItemRejected, batch.Results[1].Status)
+			assert.Equal(t, "Dinosaur cannot be placed in cage that is already full.", batch.Results[1].Error)
+		}
+		assertCurrentCount(t, cage.ID, 1)
+		assert.Len(t, listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d", cage.ID)), 1)
+	})
+
+	t.Run("Best effort adds what fits", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		t.Cleanup(func() { deleteDinosaursInCage(cage.ID) })
+
+		response, batch := addBatch(t, fmt.Sprintf(`{"mode": "best_effort", "dinosaurs": [
+			{"name": "Mo", "species": "Stegosaurus", "cage_id": %[1]d},
+			{"name": "Jo", "species": "Stegosaurus", "cage_id": %[1]d}
+		]}`, cage.ID))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		if assert.Len(t, batch.Results, 2) {
+			assert.Equal(t, apimodels.BatchItemCreated, batch.Results[0].Status)
+			assert.Equal(t, apimodels.BatchItemRejected, batch.Results[1].Status)
+		}
+		assertCurrentCount(t, cage.ID, 2)
+	})
+
+	t.Run("Diet is checked against earlier batch members", func(t *testing.T) {
+		cage := CreateTestCage(3, apimodels.Active)
+		t.Cleanup(func() { deleteDinosaursInCage(cage.ID) })
+
+		_, batch := addBatch(t, fmt.Sprintf(`{"mode": "best_effort", "dinosaurs": [
+			{"name": "Blue", "species": "Velociraptor", "cage_id": %[1]d},
+			{"name": "Bo", "species": "Stegosaurus", "cage_id": %[1]d},
+			{"name": "Rex", "species": "Tyrannosaurus", "cage_id": %[1]d},
+			{"name": "Delta", "species": "Velociraptor", "cage_id": %[1]d}
+		]}`, cage.ID))
+
+		if assert.Len(t, batch.Results, 4) {
+			assert.Equal(t, apimodels.BatchItemCreated, batch.Results[0].Status)
+			assert.Equal(t, "Herbivore Dinosaur cannot be placed in cage with Carnivores.", batch.Results[1].Error)
+			assert.Equal(t, "Carnivore Dinosaur cannot be placed in cage with any other species.", batch.Results[2].Error)
+			assert.Equal(t, apimodels.BatchItemCreated, batch.Results[3].Status)
+		}
+	})
+
+	t.Run("Every rejected dinosaur gets its own error", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		downCage := CreateTestCage(2, apimodels.Down)
+		t.Cleanup(func() { deleteDinosaursInCage(cage.ID) })
+
+		response, batch := addBatch(t, fmt.Sprintf(`{"mode": "best_effort", "dinosaurs": [
+			{"name": " ", "species": "Stegosaurus", "cage_id": %[1]d},
+			{"name": "Bo", "species": "Dodo", "cage_id": %[1]d},
+			{"name": "Mo", "species": "Stegosaurus", "cage_id": 123456},
+			{"name": "Jo", "species": "Stegosaurus", "cage_id": %[2]d},
+			{"name": "Flo", "species": "Stegosaurus", "cage_id": %[1]d}
+		]}`, cage.ID, downCage.ID))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		if assert.Len(t, batch.Results, 5) {
+			assert.Equal(t, "Invalid name. Name cannot be blank.", batch.Results[0].Error)
+			assert.Equal(t, "Unknown species.", batch.Results[1].Error)
+			assert.Equal(t, "Cage not found.", batch.Results[2].Error)
+			assert.Equal(t, "Dinosaur cannot be placed in cage that has no power.", batch.Results[3].Error)
+			assert.Equal(t, apimodels.BatchItemCreated, batch.Results[4].Status)
+		}
+	})
+
+	t.Run("Invalid batches", func(t *testing.T) {
+		for _, payload := range []string{
+			`{"dinosaurs": []}`,
+			`{"mode": "some", "dinosaurs": [{"name": "Bo", "species": "Stegosaurus", "cage_id": 1}]}`,
+			`{"dinosaurs": "all"}`,
+		} {
+			response := sendWithIfMatch(http.MethodPost, "/dinosaurs/batch", payload, "")
+
+			assert.Equal(t, http.StatusBadRequest, response.Code, payload)
+		}
+	})
+}
+
+func addBatch(t *testing.T, payload string) (*httptest.ResponseRecorder, apimodels.AddDinosaursResponse) {
+	response := sendWithIfMatch(http.MethodPost, "/dinosaurs/batch", payload, "")
+
+	var batch apimodels.AddDinosaursResponse
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &batch))
+	return response, batch
+}
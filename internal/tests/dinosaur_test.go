@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"net/http/httptest"
 	apimodels "pp-jurassic-park-api/internal/api/models"
-	"pp-jurassic-park-api/internal/db"
 	dbmodels "pp-jurassic-park-api/internal/db/models"
 	"strconv"
 	"strings"
@@ -223,7 +222,7 @@ func TestMoveDinosaur(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, response.Code)
 
-		var createResponse apimodels.MoveDinosaurResponse
+		var createResponse apimodels.UpdateDinosaurResponse
 		json.Unmarshal(response.Body.Bytes(), &createResponse)
 		assertDinosaur(t, createResponse.Dinosaur, triceratops.Name, apimodels.Species(triceratops.Species), apimodels.DinosaurType(triceratops.Type), cageWithStegosaurus.ID)
 	})
@@ -355,22 +354,21 @@ func TestRemoveDinosaur(t *testing.T) {
 }
 
 func CreateTestDinosaur(name string, species apimodels.Species, dinosaurType apimodels.DinosaurType, cageID uint) dbmodels.Dinosaur {
-	dbConn, _ := db.Connect()
-
 	dinosaur := dbmodels.Dinosaur{
 		Name:    name,
 		Species: string(species),
 		Type:    string(dinosaurType),
 		CageID:  cageID,
 	}
-	dbConn.Create(&dinosaur)
+	store.Dinosaurs().Create(ctx, &dinosaur)
 	dinosaurIDsToCleanup = append(dinosaurIDsToCleanup, dinosaur.ID)
 	return dinosaur
 }
 
 func DeleteTestDinosaurs(ids []uint) {
-	dbConn, _ := db.Connect()
-	dbConn.Where("id IN (?)", ids).Delete(&dbmodels.Dinosaur{})
+	for _, id := range ids {
+		store.Dinosaurs().Unscoped().Delete(ctx, id)
+	}
 }
 
 func assertDinosaur(t *testing.T, dinosaur apimodels.Dinosaur, name string, species apimodels.Species, dinosaurType apimodels.DinosaurType, cageID uint) {
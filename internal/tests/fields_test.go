@@ -0,0 +1,142 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSparseFieldsets(t *testing.T) {
+	t.Run("Cage restricted to fields", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, cagePath(cageWithTyrannosaurus.ID)+"?fields=id,capacity,power_status", "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"cage": {"id": %d, "capacity": 3, "power_status": "ACTIVE"}}`, cageWithTyrannosaurus.ID),
+			response.Body.String())
+		assert.NotEmpty(t, response.Header().Get("ETag"))
+	})
+
+	t.Run("Current count without dinosaurs", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, cagePath(cageWithTyrannosaurus.ID)+"?fields=current_count", "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, `{"cage": {"current_count": 1}}`, response.Body.String())
+	})
+
+	t.Run("Cages restricted to fields", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, "/cages?fields=id,current_count&limit=1&include_total=true", "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var list struct {
+			Cages      []map[string]any `json:"cages"`
+			NextCursor string           `json:"next_cursor"`
+			Total      int              `json:"total"`
+		}
+		json.Unmarshal(response.Body.Bytes(), &list)
+		if assert.Len(t, list.Cages, 1) {
+			assert.ElementsMatch(t, []string{"id", "current_count"}, keys(list.Cages[0]))
+		}
+		assert.NotEmpty(t, list.NextCursor)
+		assert.Greater(t, list.Total, 1)
+	})
+
+	t.Run("Included dinosaurs are returned with the fields", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, cagePath(cageWithTyrannosaurus.ID)+"?fields=id&include=dinosaurs", "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var get apimodels.GetCageResponse
+		json.Unmarshal(response.Body.Bytes(), &get)
+		assert.Equal(t, cageWithTyrannosaurus.ID, get.Cage.ID)
+		assert.Zero(t, get.Cage.Capacity)
+		if assert.Len(t, get.Cage.Dinosaurs, 1) {
+			assert.Equal(t, "Terry", get.Cage.Dinosaurs[0].Name)
+		}
+	})
+
+	t.Run("v2 embeds dinosaurs only if included", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, "/v2"+cagePath(cageWithTyrannosaurus.ID)+"?include=dinosaurs", "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var get apimodels.CageSummaryResponse
+		json.Unmarshal(response.Body.Bytes(), &get)
+		assert.Equal(t, 1, get.Cage.CurrentCount)
+		if assert.NotNil(t, get.Cage.Dinosaurs) && assert.Len(t, *get.Cage.Dinosaurs, 1) {
+			assert.Equal(t, fmt.Sprintf("/v2/dinosaurs/%d", tyrannosaurus.ID), (*get.Cage.Dinosaurs)[0].Links.Self)
+		}
+
+		// An empty cage still lists its dinosaurs when they are included.
+		cage := CreateTestCage(2, apimodels.Active)
+		response = sendWithIfMatch(http.MethodGet, "/v2/cages?include=dinosaurs&capacity_gte=2&capacity_lte=2&sort=-id&limit=1", "", "")
+		var list apimodels.CageSummariesResponse
+		json.Unmarshal(response.Body.Bytes(), &list)
+		if assert.Len(t, list.Cages, 1) && assert.NotNil(t, list.Cages[0].Dinosaurs) {
+			assert.Equal(t, cage.ID, list.Cages[0].ID)
+			assert.Empty(t, *list.Cages[0].Dinosaurs)
+		}
+	})
+
+	t.Run("Dinosaur with its cage", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, dinosaurPath(tyrannosaurus.ID)+"?include=cage", "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var get apimodels.GetDinosaurResponse
+		json.Unmarshal(response.Body.Bytes(), &get)
+		if assert.NotNil(t, get.Dinosaur.Cage) {
+			assert.Equal(t, cageWithTyrannosaurus.ID, get.Dinosaur.Cage.ID)
+			assert.Equal(t, 1, get.Dinosaur.Cage.CurrentCount)
+			assert.Len(t, get.Dinosaur.Cage.Dinosaurs, 1)
+		}
+
+		response = sendWithIfMatch(http.MethodGet, dinosaurPath(tyrannosaurus.ID), "", "")
+		assert.NotContains(t, response.Body.String(), `"cage":`)
+	})
+
+	t.Run("Dinosaurs with their cages", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, "/dinosaurs?fields=name&include=cage&name=Terry", "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var list struct {
+			Dinosaurs []map[string]json.RawMessage `json:"dinosaurs"`
+		}
+		json.Unmarshal(response.Body.Bytes(), &list)
+		if assert.NotEmpty(t, list.Dinosaurs) {
+			assert.ElementsMatch(t, []string{"name", "cage"}, keys(list.Dinosaurs[0]))
+			var cage apimodels.Cage
+			json.Unmarshal(list.Dinosaurs[0]["cage"], &cage)
+			assert.Equal(t, cageWithTyrannosaurus.ID, cage.ID)
+		}
+
+		response = sendWithIfMatch(http.MethodGet, "/v2"+dinosaurPath(tyrannosaurus.ID)+"?include=cage", "", "")
+		var get apimodels.DinosaurSummaryResponse
+		json.Unmarshal(response.Body.Bytes(), &get)
+		if assert.NotNil(t, get.Dinosaur.Cage) {
+			assert.Equal(t, fmt.Sprintf("/v2/cages/%d", cageWithTyrannosaurus.ID), get.Dinosaur.Cage.Links.Self)
+			assert.Equal(t, 1, get.Dinosaur.Cage.CurrentCount)
+			assert.Nil(t, get.Dinosaur.Cage.Dinosaurs)
+		}
+	})
+
+	t.Run("Unknown fields and relations", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, "/cages?fields=id,volume", "", "")
+		assertProblem(t, response, http.StatusBadRequest, apimodels.ValidationFailed)
+
+		response = sendWithIfMatch(http.MethodGet, cagePath(cageWithTyrannosaurus.ID)+"?include=cage", "", "")
+		assertProblem(t, response, http.StatusBadRequest, apimodels.ValidationFailed)
+
+		response = sendWithIfMatch(http.MethodGet, dinosaurPath(tyrannosaurus.ID)+"?include=dinosaurs", "", "")
+		assertProblem(t, response, http.StatusBadRequest, apimodels.ValidationFailed)
+	})
+}
+
+func keys[V any](object map[string]V) []string {
+	names := []string{}
+	for name := range object {
+		names = append(names, name)
+	}
+	return names
+}
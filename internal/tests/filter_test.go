@@ -0,0 +1,157 @@
+package tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterCages(t *testing.T) {
+	t.Run("Request without body", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/cages", nil)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("By power status", func(t *testing.T) {
+		downCage := CreateTestCage(2, apimodels.Down)
+
+		ids := listCageIDs(t, "/cages?power_status=DOWN")
+		assert.Contains(t, ids, downCage.ID)
+		assert.NotContains(t, ids, activeCage.ID)
+
+		ids = listCageIDs(t, "/cages?power_status=DOWN,ACTIVE")
+		assert.Contains(t, ids, downCage.ID)
+		assert.Contains(t, ids, activeCage.ID)
+
+		ids = listCageIDs(t, "/cages?power_status=DOWN&power_status=ACTIVE")
+		assert.Contains(t, ids, downCage.ID)
+		assert.Contains(t, ids, activeCage.ID)
+	})
+
+	t.Run("By capacity range", func(t *testing.T) {
+		smallCage := CreateTestCage(40, apimodels.Active)
+		mediumCage := CreateTestCage(41, apimodels.Active)
+		largeCage := CreateTestCage(42, apimodels.Active)
+
+		ids := listCageIDs(t, "/cages?capacity_gte=41")
+		assert.NotContains(t, ids, smallCage.ID)
+		assert.Contains(t, ids, mediumCage.ID)
+		assert.Contains(t, ids, largeCage.ID)
+
+		ids = listCageIDs(t, "/cages?capacity_gte=40&capacity_lte=41")
+		assert.Contains(t, ids, smallCage.ID)
+		assert.Contains(t, ids, mediumCage.ID)
+		assert.NotContains(t, ids, largeCage.ID)
+	})
+
+	t.Run("By free capacity", func(t *testing.T) {
+		fullCage := CreateTestCage(1, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, fullCage.ID)
+		emptyCage := CreateTestCage(1, apimodels.Active)
+		// A deleted dinosaur does not occupy its cage.
+		deleted := createTemporaryDinosaur(t, "Mo", apimodels.Stegosaurus, apimodels.Herbivore, emptyCage.ID)
+		sendWithIfMatch(http.MethodDelete, dinosaurPath(deleted.ID), "", "")
+
+		ids := listCageIDs(t, "/cages?has_free_capacity=true")
+		assert.Contains(t, ids, emptyCage.ID)
+		assert.NotContains(t, ids, fullCage.ID)
+
+		ids = listCageIDs(t, "/cages?has_free_capacity=false")
+		assert.Contains(t, ids, fullCage.ID)
+		assert.NotContains(t, ids, emptyCage.ID)
+	})
+
+	t.Run("Query and body filters are combined", func(t *testing.T) {
+		activeLargeCage := CreateTestCage(50, apimodels.Active)
+		downLargeCage := CreateTestCage(50, apimodels.Down)
+
+		response := sendWithIfMatch(http.MethodGet, "/cages?capacity_gte=50", `{"filtered_power_status": ["DOWN"]}`, "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), fmt.Sprintf(`"id":%d,`, downLargeCage.ID))
+		assert.NotContains(t, response.Body.String(), fmt.Sprintf(`"id":%d,`, activeLargeCage.ID))
+		assert.NotContains(t, response.Body.String(), fmt.Sprintf(`"id":%d,`, activeCage.ID))
+	})
+
+	t.Run("Invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"power_status=OFF", "has_free_capacity=maybe", "capacity_gte=-1", "capacity_lte=many"} {
+			request, _ := http.NewRequest(http.MethodGet, "/cages?"+query, nil)
+			response := httptest.NewRecorder()
+
+			router.ServeHTTP(response, request)
+
+			assert.Equal(t, http.StatusBadRequest, response.Code, query)
+		}
+	})
+}
+
+func TestFilterDinosaurs(t *testing.T) {
+	herbivoreCage := CreateTestCage(3, apimodels.Active)
+	carnivoreCage := CreateTestCage(3, apimodels.Active)
+	barney := createTemporaryDinosaur(t, "Barney", apimodels.Stegosaurus, apimodels.Herbivore, herbivoreCage.ID)
+	bart := createTemporaryDinosaur(t, "Bart", apimodels.Triceratops, apimodels.Herbivore, herbivoreCage.ID)
+	carl := createTemporaryDinosaur(t, "Carl", apimodels.Velociraptor, apimodels.Carnivore, carnivoreCage.ID)
+
+	t.Run("Request without body", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodGet, "/dinosaurs", nil)
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("By species", func(t *testing.T) {
+		ids := listDinosaurIDs(t, "/dinosaurs?species=Stegosaurus&species=Velociraptor")
+
+		assert.Contains(t, ids, barney.ID)
+		assert.NotContains(t, ids, bart.ID)
+		assert.Contains(t, ids, carl.ID)
+	})
+
+	t.Run("By type", func(t *testing.T) {
+		ids := listDinosaurIDs(t, "/dinosaurs?type=CARNIVORE")
+
+		assert.NotContains(t, ids, barney.ID)
+		assert.Contains(t, ids, carl.ID)
+	})
+
+	t.Run("By cage", func(t *testing.T) {
+		ids := listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d,%d", herbivoreCage.ID, carnivoreCage.ID))
+		assert.ElementsMatch(t, []uint{barney.ID, bart.ID, carl.ID}, ids)
+
+		ids = listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d&type=HERBIVORE&species=Triceratops", herbivoreCage.ID))
+		assert.Equal(t, []uint{bart.ID}, ids)
+	})
+
+	t.Run("By name prefix", func(t *testing.T) {
+		ids := listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d,%d&name=Bar", herbivoreCage.ID, carnivoreCage.ID))
+		assert.ElementsMatch(t, []uint{barney.ID, bart.ID}, ids)
+
+		ids = listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d,%d&name=Barn&name=Ca", herbivoreCage.ID, carnivoreCage.ID))
+		assert.ElementsMatch(t, []uint{barney.ID, carl.ID}, ids)
+
+		ids = listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d,%d&name=bar", herbivoreCage.ID, carnivoreCage.ID))
+		assert.Empty(t, ids)
+	})
+
+	t.Run("Invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"species=Dodo", "type=OMNIVORE", "cage_id=first", "cage_id=0"} {
+			request, _ := http.NewRequest(http.MethodGet, "/dinosaurs?"+query, nil)
+			response := httptest.NewRecorder()
+
+			router.ServeHTTP(response, request)
+
+			assert.Equal(t, http.StatusBadRequest, response.Code, query)
+		}
+	})
+}
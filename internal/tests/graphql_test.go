@@ -0,0 +1,315 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"pp-jurassic-park-api/internal/api/handlers"
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Path       []any          `json:"path"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+func TestGraphQL(t *testing.T) {
+	t.Run("Cage with its dinosaurs", func(t *testing.T) {
+		response := queryGraphQL(t, router, fmt.Sprintf(`{
+			cage(id: "%d") {
+				id capacity powerStatus currentCount
+				dinosaurs { name type species { name type } cage { id } }
+			}
+		}`, cageWithTyrannosaurus.ID), nil)
+
+		assert.Empty(t, response.Errors)
+		assert.JSONEq(t, fmt.Sprintf(`{"cage": {
+			"id": "%[1]d", "capacity": 3, "powerStatus": "ACTIVE", "currentCount": 1,
+			"dinosaurs": [{"name": "Terry", "type": "CARNIVORE", "species": {"name": "Tyrannosaurus", "type": "CARNIVORE"}, "cage": {"id": "%[1]d"}}]
+		}}`, cageWithTyrannosaurus.ID), string(response.Data))
+	})
+
+	t.Run("Filters", func(t *testing.T) {
+		cage := CreateTestCage(79, apimodels.Active)
+		createTemporaryDinosaur(t, "Graphy", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := queryGraphQL(t, router, `query Filtered($statuses: [PowerStatus!]) {
+			cages(powerStatus: $statuses, capacityGte: 79, capacityLte: 79, hasFreeCapacity: true) { capacity currentCount }
+			dinosaurs(namePrefix: ["Graph"], species: [Stegosaurus], type: [HERBIVORE]) { name cage { powerStatus } }
+		}`, map[string]any{"statuses": []string{"ACTIVE"}})
+
+		assert.Empty(t, response.Errors)
+		assert.JSONEq(t, `{
+			"cages": [{"capacity": 79, "currentCount": 1}],
+			"dinosaurs": [{"name": "Graphy", "cage": {"powerStatus": "ACTIVE"}}]
+		}`, string(response.Data))
+
+		response = queryGraphQL(t, router, fmt.Sprintf(`{ dinosaurs(cageId: ["%d"], type: [CARNIVORE]) { name } }`, cage.ID), nil)
+		assert.JSONEq(t, `{"dinosaurs": []}`, string(response.Data))
+	})
+
+	t.Run("Cages are paged", func(t *testing.T) {
+		first := CreateTestCage(82, apimodels.Active)
+		second := CreateTestCage(82, apimodels.Active)
+
+		response := queryGraphQL(t, router, `{ cages(capacityGte: 82, capacityLte: 82, first: 1) { id cursor } }`, nil)
+		assert.Empty(t, response.Errors)
+		var page struct {
+			Cages []struct {
+				ID     string
+				Cursor string
+			}
+		}
+		json.Unmarshal(response.Data, &page)
+		if assert.Len(t, page.Cages, 1) {
+			assert.Equal(t, fmt.Sprint(first.ID), page.Cages[0].ID)
+		}
+
+		// The cursor is the same as the one returned by GET /cages for the page ending with the cage.
+		rest := getCages(t, "/cages?capacity_gte=82&capacity_lte=82&limit=1")
+		assert.Equal(t, rest.NextCursor, page.Cages[0].Cursor)
+
+		response = queryGraphQL(t, router, `query Next($after: String) { cages(capacityGte: 82, capacityLte: 82, after: $after) { id } }`,
+			map[string]any{"after": page.Cages[0].Cursor})
+		assert.Empty(t, response.Errors)
+		assert.JSONEq(t, fmt.Sprintf(`{"cages": [{"id": "%d"}]}`, second.ID), string(response.Data))
+
+		response = queryGraphQL(t, router, `{ cages(first: 101) { id } }`, nil)
+		assert.Equal(t, "first", response.Errors[0].Extensions["field"])
+
+		response = queryGraphQL(t, router, `{ cages(after: "nowhere") { id } }`, nil)
+		assert.Equal(t, "after", response.Errors[0].Extensions["field"])
+	})
+
+	t.Run("Species", func(t *testing.T) {
+		response := queryGraphQL(t, router, `{ species { name type dinosaurs { name } } }`, nil)
+
+		assert.Empty(t, response.Errors)
+		var data struct {
+			Species []struct {
+				Name      string
+				Type      string
+				Dinosaurs []struct{ Name string }
+			}
+		}
+		json.Unmarshal(response.Data, &data)
+		assert.Len(t, data.Species, len(apimodels.AllSpecies))
+		assert.Equal(t, "Tyrannosaurus", data.Species[0].Name)
+		assert.Equal(t, "CARNIVORE", data.Species[0].Type)
+		assert.Contains(t, data.Species[0].Dinosaurs, struct{ Name string }{Name: "Terry"})
+	})
+
+	t.Run("Missing records are null", func(t *testing.T) {
+		response := queryGraphQL(t, router, `{ cage(id: "999999") { id } dinosaur(id: "999999") { id } }`, nil)
+
+		assert.Empty(t, response.Errors)
+		assert.JSONEq(t, `{"cage": null, "dinosaur": null}`, string(response.Data))
+	})
+
+	t.Run("Add dinosaur", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		response := queryGraphQL(t, router, `mutation Add($cageId: ID!) {
+			addDinosaur(name: "Graphy", species: Triceratops, cageId: $cageId) { id name type cage { currentCount } }
+		}`, map[string]any{"cageId": fmt.Sprint(cage.ID)})
+
+		assert.Empty(t, response.Errors)
+		var data struct{ AddDinosaur map[string]any }
+		json.Unmarshal(response.Data, &data)
+		assert.Equal(t, "HERBIVORE", data.AddDinosaur["type"])
+		assert.Equal(t, map[string]any{"currentCount": float64(1)}, data.AddDinosaur["cage"])
+		deleteDinosaursInCage(cage.ID)
+	})
+
+	t.Run("Add dinosaur follows the placement rules", func(t *testing.T) {
+		downCage := CreateTestCage(2, apimodels.Down)
+		fullCage := CreateTestCage(1, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, fullCage.ID)
+
+		for cageID, code := range map[uint]apimodels.ErrorCode{
+			downCage.ID:              apimodels.CageUnpowered,
+			fullCage.ID:              apimodels.CageFull,
+			cageWithTyrannosaurus.ID: apimodels.DietConflict,
+		} {
+			response := queryGraphQL(t, router, fmt.Sprintf(`mutation {
+				addDinosaur(name: "Graphy", species: Triceratops, cageId: "%d") { id }
+			}`, cageID), nil)
+
+			assert.JSONEq(t, `null`, string(response.Data))
+			assert.Len(t, response.Errors, 1)
+			assert.Equal(t, string(code), response.Errors[0].Extensions["code"])
+			assert.Equal(t, float64(cageID), response.Errors[0].Extensions["cageId"])
+			assert.Equal(t, []any{"addDinosaur"}, response.Errors[0].Path)
+		}
+		assertCurrentCount(t, downCage.ID, 0)
+		assertCurrentCount(t, cageWithTyrannosaurus.ID, 1)
+
+		response := queryGraphQL(t, router, fmt.Sprintf(`mutation {
+			addDinosaur(name: "Rex", species: Velociraptor, cageId: "%d") { id }
+		}`, cageWithTyrannosaurus.ID), nil)
+		assert.Equal(t, string(apimodels.SpeciesConflict), response.Errors[0].Extensions["code"])
+	})
+
+	t.Run("Move dinosaur follows the placement rules", func(t *testing.T) {
+		fullCage := CreateTestCage(1, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, fullCage.ID)
+		emptyCage := CreateTestCage(1, apimodels.Active)
+		source := CreateTestCage(1, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Graphy", apimodels.Triceratops, apimodels.Herbivore, source.ID)
+
+		response := queryGraphQL(t, router, fmt.Sprintf(`mutation {
+			moveDinosaur(id: "%d", cageId: "%d") { id }
+		}`, dinosaur.ID, fullCage.ID), nil)
+		assert.Equal(t, string(apimodels.CageFull), response.Errors[0].Extensions["code"])
+
+		// Each move returns the cage as it stands right after the move.
+		response = queryGraphQL(t, router, fmt.Sprintf(`mutation {
+			first: moveDinosaur(id: "%[1]d", cageId: "%[2]d") { cage { currentCount dinosaurs { name } } }
+			second: moveDinosaur(id: "%[1]d", cageId: "%[3]d") { cage { id currentCount } }
+		}`, dinosaur.ID, emptyCage.ID, source.ID), nil)
+		assert.Empty(t, response.Errors)
+		assert.JSONEq(t, fmt.Sprintf(`{
+			"first": {"cage": {"currentCount": 1, "dinosaurs": [{"name": "Graphy"}]}},
+			"second": {"cage": {"id": "%d", "currentCount": 1}}
+		}`, source.ID), string(response.Data))
+	})
+
+	t.Run("Set cage power", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		response := queryGraphQL(t, router, fmt.Sprintf(`mutation {
+			setCagePower(id: "%d", powerStatus: DOWN) { powerStatus }
+		}`, cage.ID), nil)
+		assert.Empty(t, response.Errors)
+		assert.JSONEq(t, `{"setCagePower": {"powerStatus": "DOWN"}}`, string(response.Data))
+
+		response = queryGraphQL(t, router, fmt.Sprintf(`mutation {
+			addDinosaur(name: "Graphy", species: Triceratops, cageId: "%d") { id }
+		}`, cage.ID), nil)
+		assert.Equal(t, string(apimodels.CageUnpowered), response.Errors[0].Extensions["code"])
+
+		response = queryGraphQL(t, router, `mutation { setCagePower(id: "999999", powerStatus: ACTIVE) { id } }`, nil)
+		assert.Equal(t, string(apimodels.NotFound), response.Errors[0].Extensions["code"])
+	})
+
+	t.Run("Invalid queries", func(t *testing.T) {
+		response := queryGraphQL(t, router, `{ cages { unknown } }`, nil)
+		assert.Equal(t, string(apimodels.ValidationFailed), response.Errors[0].Extensions["code"])
+
+		response = queryGraphQL(t, router, `{ dinosaurs(species: [Dodo]) { id } }`, nil)
+		assert.Equal(t, string(apimodels.ValidationFailed), response.Errors[0].Extensions["code"])
+
+		response = queryGraphQL(t, router, `{ cage(id: "cage") { id } }`, nil)
+		assert.Equal(t, "id", response.Errors[0].Extensions["field"])
+
+		response = queryGraphQL(t, router, `mutation { addDinosaur(name: " ", species: Triceratops, cageId: "1") { id } }`, nil)
+		assert.Equal(t, "name", response.Errors[0].Extensions["field"])
+
+		request, _ := http.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{"variables": {}}`))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		assertProblem(t, recorder, http.StatusBadRequest, apimodels.ValidationFailed)
+	})
+
+	t.Run("Nested records are loaded in batches", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			cage := CreateTestCage(80, apimodels.Active)
+			createTemporaryDinosaur(t, fmt.Sprintf("Batchy %d", i), apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		}
+		reads := 0
+		counting := &countingStore{Store: store, reads: &reads}
+		graphQLHandler, _ := handlers.NewGraphQLHandler(service.NewCageService(counting), service.NewDinosaurService(counting))
+		countingRouter := gin.New()
+		countingRouter.POST("/graphql", graphQLHandler.Query)
+
+		response := queryGraphQL(t, countingRouter, `{
+			cages(capacityGte: 80, capacityLte: 80) { dinosaurs { cage { dinosaurs { name } } } }
+		}`, nil)
+		assert.Empty(t, response.Errors)
+		assert.Equal(t, 2, reads)
+
+		reads = 0
+		response = queryGraphQL(t, countingRouter, `{ dinosaurs(namePrefix: ["Batchy"]) { cage { dinosaurs { species { name } } } } }`, nil)
+		assert.Empty(t, response.Errors)
+		assert.Equal(t, 3, reads)
+	})
+}
+
+func queryGraphQL(t *testing.T, handler http.Handler, query string, variables map[string]any) graphQLResponse {
+	payload, _ := json.Marshal(map[string]any{"query": query, "variables": variables})
+	request, _ := http.NewRequest(http.MethodPost, "/graphql", bytes.NewBuffer(payload))
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	var result graphQLResponse
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &result))
+	return result
+}
+
+// countingStore counts the reads of cages and dinosaurs.
+type countingStore struct {
+	repository.Store
+	reads *int
+}
+
+func (s *countingStore) Cages() repository.CageRepository {
+	return countingCages{CageRepository: s.Store.Cages(), reads: s.reads}
+}
+
+func (s *countingStore) Dinosaurs() repository.DinosaurRepository {
+	return countingDinosaurs{DinosaurRepository: s.Store.Dinosaurs(), reads: s.reads}
+}
+
+type countingCages struct {
+	repository.CageRepository
+	reads *int
+}
+
+func (r countingCages) Preload(relations repository.CageRelations) repository.CageRepository {
+	return countingCages{CageRepository: r.CageRepository.Preload(relations), reads: r.reads}
+}
+
+func (r countingCages) List(ctx context.Context, filter repository.CageFilter, page repository.Page) ([]dbmodels.Cage, error) {
+	*r.reads++
+	return r.CageRepository.List(ctx, filter, page)
+}
+
+func (r countingCages) Get(ctx context.Context, id uint) (dbmodels.Cage, error) {
+	*r.reads++
+	return r.CageRepository.Get(ctx, id)
+}
+
+type countingDinosaurs struct {
+	repository.DinosaurRepository
+	reads *int
+}
+
+func (r countingDinosaurs) Preload(relations repository.DinosaurRelations) repository.DinosaurRepository {
+	return countingDinosaurs{DinosaurRepository: r.DinosaurRepository.Preload(relations), reads: r.reads}
+}
+
+func (r countingDinosaurs) List(ctx context.Context, filter repository.DinosaurFilter, page repository.Page) ([]dbmodels.Dinosaur, error) {
+	*r.reads++
+	return r.DinosaurRepository.List(ctx, filter, page)
+}
+
+func (r countingDinosaurs) Get(ctx context.Context, id uint) (dbmodels.Dinosaur, error) {
+	*r.reads++
+	return r.DinosaurRepository.Get(ctx, id)
+}
@@ -0,0 +1,197 @@
+package tests
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"pp-jurassic-park-api/internal/api/handlers"
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/api/parkpb"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPC(t *testing.T) {
+	cages, dinosaurs := dialGRPC(t)
+
+	t.Run("Create and get cage", func(t *testing.T) {
+		created, err := cages.CreateCage(ctx, &parkpb.CreateCageRequest{Capacity: 3, PowerStatus: parkpb.PowerStatus_POWER_STATUS_ACTIVE})
+		require.NoError(t, err)
+		cageIDsToCleanup = append(cageIDsToCleanup, uint(created.Cage.Id))
+		assert.Equal(t, int32(3), created.Cage.Capacity)
+		assert.NotNil(t, created.Cage.CreatedAt)
+
+		found, err := cages.GetCage(ctx, &parkpb.GetCageRequest{Id: created.Cage.Id, IncludeDinosaurs: true})
+		require.NoError(t, err)
+		assert.Equal(t, parkpb.PowerStatus_POWER_STATUS_ACTIVE, found.Cage.PowerStatus)
+		assert.Empty(t, found.Cage.Dinosaurs)
+	})
+
+	t.Run("Invalid input", func(t *testing.T) {
+		_, err := cages.CreateCage(ctx, &parkpb.CreateCageRequest{Capacity: 0, PowerStatus: parkpb.PowerStatus_POWER_STATUS_DOWN})
+		assertGRPCError(t, err, codes.InvalidArgument, apimodels.ValidationFailed, "capacity")
+
+		_, err = cages.ListCages(ctx, &parkpb.ListCagesRequest{Limit: 101})
+		assertGRPCError(t, err, codes.InvalidArgument, apimodels.ValidationFailed, "limit")
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		_, err := cages.GetCage(ctx, &parkpb.GetCageRequest{Id: 999999})
+		assertGRPCError(t, err, codes.NotFound, apimodels.NotFound, "")
+
+		_, err = dinosaurs.GetDinosaur(ctx, &parkpb.GetDinosaurRequest{Id: 999999})
+		assertGRPCError(t, err, codes.NotFound, apimodels.NotFound, "")
+	})
+
+	t.Run("Placement rules", func(t *testing.T) {
+		_, err := dinosaurs.AddDinosaur(ctx, &parkpb.AddDinosaurRequest{Dinosaur: &parkpb.NewDinosaur{
+			Name: "Grippy", Species: parkpb.Species_SPECIES_TRICERATOPS, CageId: uint64(cageWithTyrannosaurus.ID),
+		}})
+		assertGRPCError(t, err, codes.FailedPrecondition, apimodels.DietConflict, "")
+
+		check, err := dinosaurs.GetCompatibility(ctx, &parkpb.GetCompatibilityRequest{
+			CageId:   uint64(downCage.ID),
+			Dinosaur: &parkpb.GetCompatibilityRequest_Species{Species: parkpb.Species_SPECIES_VELOCIRAPTOR},
+		})
+		require.NoError(t, err)
+		assert.False(t, check.Compatible)
+		assert.Equal(t, string(apimodels.CageUnpowered), check.Violations[0].Rule)
+	})
+
+	t.Run("Add dinosaur records the actor", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		added, err := dinosaurs.AddDinosaur(metadata.AppendToOutgoingContext(ctx, "x-actor", "perimeter-controller"),
+			&parkpb.AddDinosaurRequest{Dinosaur: &parkpb.NewDinosaur{Name: "Grippy", Species: parkpb.Species_SPECIES_STEGOSAURUS, CageId: uint64(cage.ID)}})
+		require.NoError(t, err)
+		t.Cleanup(func() { store.Dinosaurs().Unscoped().Delete(ctx, uint(added.Dinosaur.Id)) })
+		assert.Equal(t, parkpb.DinosaurType_DINOSAUR_TYPE_HERBIVORE, added.Dinosaur.Type)
+
+		history, err := dinosaurs.GetDinosaurHistory(ctx, &parkpb.GetDinosaurHistoryRequest{Id: added.Dinosaur.Id})
+		require.NoError(t, err)
+		assert.Equal(t, "perimeter-controller", history.History[0].Actor)
+	})
+
+	t.Run("Stale version", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		_, err := cages.UpdateCage(ctx, &parkpb.UpdateCageRequest{Id: uint64(cage.ID), Capacity: proto32(3), ExpectedVersion: uint64(cage.Version) + 1})
+		assertGRPCError(t, err, codes.Aborted, apimodels.PreconditionFailed, "If-Match")
+	})
+
+	t.Run("Rejected batch is reported", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Active)
+
+		resp, err := dinosaurs.AddDinosaurs(ctx, &parkpb.AddDinosaursRequest{Dinosaurs: []*parkpb.NewDinosaur{
+			{Name: "One", Species: parkpb.Species_SPECIES_STEGOSAURUS, CageId: uint64(cage.ID)},
+			{Name: "Two", Species: parkpb.Species_SPECIES_STEGOSAURUS, CageId: uint64(cage.ID)},
+		}})
+		require.NoError(t, err)
+		assert.True(t, resp.Rejected)
+		assert.Equal(t, parkpb.AddDinosaursResult_STATUS_ROLLED_BACK, resp.Results[0].Status)
+		assert.Equal(t, parkpb.AddDinosaursResult_STATUS_REJECTED, resp.Results[1].Status)
+		assert.Equal(t, string(apimodels.CageFull), resp.Results[1].Code)
+		assertCurrentCount(t, cage.ID, 0)
+	})
+
+	t.Run("Watch cages", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		watchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+
+		stream, err := cages.WatchCages(watchCtx, &parkpb.WatchCagesRequest{Ids: []uint64{uint64(cage.ID)}})
+		require.NoError(t, err)
+		event, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, parkpb.CageEvent_TYPE_CURRENT, event.Type)
+		assert.Equal(t, parkpb.PowerStatus_POWER_STATUS_ACTIVE, event.Cage.PowerStatus)
+
+		_, err = cages.UpdateCage(ctx, &parkpb.UpdateCageRequest{Id: uint64(cage.ID), PowerStatus: parkpb.PowerStatus_POWER_STATUS_DOWN.Enum()})
+		require.NoError(t, err)
+		event, err = stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, parkpb.CageEvent_TYPE_UPDATED, event.Type)
+		assert.Equal(t, parkpb.PowerStatus_POWER_STATUS_DOWN, event.Cage.PowerStatus)
+
+		_, err = cages.DeleteCage(ctx, &parkpb.DeleteCageRequest{Id: uint64(cage.ID)})
+		require.NoError(t, err)
+		event, err = stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, parkpb.CageEvent_TYPE_DELETED, event.Type)
+		assert.NotNil(t, event.Cage.DeletedAt)
+	})
+
+	t.Run("Watches only read changed cages", func(t *testing.T) {
+		unchanged := CreateTestCage(2, apimodels.Active)
+		changed := CreateTestCage(2, apimodels.Active)
+		deleted := CreateTestCage(2, apimodels.Active)
+		occupied := CreateTestCage(2, apimodels.Active)
+		since := time.Now()
+
+		sendMergePatch(cagePath(changed.ID), `{"capacity": 3}`)
+		assert.NoError(t, store.Cages().Delete(ctx, deleted.ID))
+		response := sendWithIfMatch(http.MethodPost, "/dinosaurs", fmt.Sprintf(`{"name": "Bo", "species": "Stegosaurus", "cage_id": %d}`, occupied.ID), "")
+		assert.Equal(t, http.StatusOK, response.Code)
+		t.Cleanup(func() { deleteDinosaursInCage(occupied.ID) })
+
+		filter := repository.CageFilter{IDs: []uint{unchanged.ID, changed.ID, deleted.ID, occupied.ID}, ChangedSince: &since}
+		read, err := store.Cages().Unscoped().List(ctx, filter, repository.Page{})
+		require.NoError(t, err)
+		ids := []uint{}
+		for _, cage := range read {
+			ids = append(ids, cage.ID)
+		}
+		assert.Equal(t, []uint{changed.ID, deleted.ID, occupied.ID}, ids)
+	})
+}
+
+// dialGRPC serves the gRPC API over an in-memory connection for the duration of the test.
+func dialGRPC(t *testing.T) (parkpb.CageServiceClient, parkpb.DinosaurServiceClient) {
+	cageServer := handlers.NewCageGRPCServer(service.NewCageService(store), 10*time.Millisecond)
+	server := handlers.NewGRPCServer(cageServer, handlers.NewDinosaurGRPCServer(service.NewDinosaurService(store)))
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(func() {
+		cageServer.StopWatches()
+		server.GracefulStop()
+	})
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return parkpb.NewCageServiceClient(conn), parkpb.NewDinosaurServiceClient(conn)
+}
+
+func assertGRPCError(t *testing.T, err error, code codes.Code, reason apimodels.ErrorCode, field string) {
+	st, _ := status.FromError(err)
+	assert.Equal(t, code, st.Code())
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			assert.Equal(t, string(reason), info.Reason)
+			assert.Equal(t, field, info.Metadata["field"])
+			return
+		}
+	}
+	t.Errorf("no ErrorInfo in status %v", st)
+}
+
+func proto32(value int32) *int32 {
+	return &value
+}
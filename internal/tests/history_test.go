@@ -0,0 +1,192 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDinosaurHistory(t *testing.T) {
+	t.Run("Every change is recorded with actor and snapshots", func(t *testing.T) {
+		firstCage := CreateTestCage(2, apimodels.Active)
+		secondCage := CreateTestCage(2, apimodels.Active)
+
+		response := sendAs(http.MethodPost, "/dinosaurs", fmt.Sprintf(`{"name": "Terry", "species": "Tyrannosaurus", "cage_id": %d}`, firstCage.ID), "muldoon")
+		var addResponse apimodels.AddDinosaurResponse
+		json.Unmarshal(response.Body.Bytes(), &addResponse)
+		id := addResponse.Dinosaur.ID
+		sendAs(http.MethodPatch, dinosaurPath(id), fmt.Sprintf(`{"cage_id": %d}`, secondCage.ID), "muldoon")
+		sendAs(http.MethodDelete, dinosaurPath(id), "", "hammond")
+		sendAs(http.MethodPost, dinosaurPath(id)+"/restore", "", "")
+		t.Cleanup(func() { deleteDinosaursInCage(secondCage.ID) })
+
+		history := getHistory(t, dinosaurPath(id)+"/history")
+
+		assert.Empty(t, history.NextCursor)
+		if assert.Len(t, history.History, 4) {
+			created, moved, deleted, restored := history.History[0], history.History[1], history.History[2], history.History[3]
+
+			assert.Equal(t, apimodels.Created, created.Action)
+			assert.Equal(t, "muldoon", created.Actor)
+			assert.Empty(t, created.Before)
+			assert.Equal(t, firstCage.ID, snapshotOf(t, created.After).CageID)
+
+			assert.Equal(t, apimodels.Moved, moved.Action)
+			assert.Equal(t, firstCage.ID, snapshotOf(t, moved.Before).CageID)
+			assert.Equal(t, secondCage.ID, snapshotOf(t, moved.After).CageID)
+
+			assert.Equal(t, apimodels.Deleted, deleted.Action)
+			assert.Equal(t, "hammond", deleted.Actor)
+			assert.Nil(t, snapshotOf(t, deleted.Before).DeletedAt)
+			assert.NotNil(t, snapshotOf(t, deleted.After).DeletedAt)
+
+			assert.Equal(t, apimodels.Restored, restored.Action)
+			assert.Equal(t, "anonymous", restored.Actor)
+			assert.Nil(t, snapshotOf(t, restored.After).DeletedAt)
+
+			assert.False(t, created.RecordedAt.IsZero())
+			assert.False(t, restored.RecordedAt.Before(created.RecordedAt))
+		}
+	})
+
+	t.Run("Rejected changes are not recorded", func(t *testing.T) {
+		fullCage := CreateTestCage(1, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, fullCage.ID)
+		cage := CreateTestCage(1, apimodels.Active)
+		response := sendAs(http.MethodPost, "/dinosaurs", fmt.Sprintf(`{"name": "Mo", "species": "Stegosaurus", "cage_id": %d}`, cage.ID), "")
+		var addResponse apimodels.AddDinosaurResponse
+		json.Unmarshal(response.Body.Bytes(), &addResponse)
+		t.Cleanup(func() { deleteDinosaursInCage(cage.ID) })
+
+		response = sendAs(http.MethodPatch, dinosaurPath(addResponse.Dinosaur.ID), fmt.Sprintf(`{"cage_id": %d}`, fullCage.ID), "")
+		assert.Equal(t, http.StatusConflict, response.Code)
+
+		history := getHistory(t, dinosaurPath(addResponse.Dinosaur.ID)+"/history")
+		assert.Len(t, history.History, 1)
+	})
+
+	t.Run("Dinosaur not found", func(t *testing.T) {
+		response := sendAs(http.MethodGet, dinosaurPath(123456)+"/history", "", "")
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("Invalid dinosaur ID", func(t *testing.T) {
+		response := sendAs(http.MethodGet, "/dinosaurs/invalidID/history", "", "")
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestCageHistory(t *testing.T) {
+	t.Run("Power changes are recorded", func(t *testing.T) {
+		response := sendAs(http.MethodPost, "/cages", `{"capacity": 2, "power_status": "ACTIVE"}`, "arnold")
+		var createResponse apimodels.CreateCageResponse
+		json.Unmarshal(response.Body.Bytes(), &createResponse)
+		cageIDsToCleanup = append(cageIDsToCleanup, createResponse.Cage.ID)
+		path := cagePath(createResponse.Cage.ID)
+
+		sendAs(http.MethodPatch, path, `{"power_status": "DOWN"}`, "nedry")
+		// Setting the current power status again changes nothing and is not recorded.
+		sendAs(http.MethodPatch, path, `{"power_status": "DOWN"}`, "nedry")
+		sendAs(http.MethodDelete, path, "", "arnold")
+
+		history := getHistory(t, path+"/history")
+
+		if assert.Len(t, history.History, 3) {
+			assert.Equal(t, apimodels.Created, history.History[0].Action)
+			assert.Equal(t, apimodels.PowerChanged, history.History[1].Action)
+			assert.Equal(t, "nedry", history.History[1].Actor)
+			assert.JSONEq(t, fmt.Sprintf(`{"id": %d, "capacity": 2, "power_status": "ACTIVE", "version": 1}`, createResponse.Cage.ID), string(history.History[1].Before))
+			assert.JSONEq(t, fmt.Sprintf(`{"id": %d, "capacity": 2, "power_status": "DOWN", "version": 2}`, createResponse.Cage.ID), string(history.History[1].After))
+			assert.Equal(t, apimodels.Deleted, history.History[2].Action)
+		}
+	})
+
+	t.Run("History is paginated", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		for _, powerStatus := range []string{"DOWN", "ACTIVE", "DOWN", "ACTIVE", "DOWN"} {
+			sendAs(http.MethodPatch, cagePath(cage.ID), fmt.Sprintf(`{"power_status": "%s"}`, powerStatus), "")
+		}
+
+		firstPage := getHistory(t, cagePath(cage.ID)+"/history?limit=2")
+		assert.Len(t, firstPage.History, 2)
+		assert.NotEmpty(t, firstPage.NextCursor)
+
+		secondPage := getHistory(t, cagePath(cage.ID)+"/history?limit=2&cursor="+firstPage.NextCursor)
+		assert.Len(t, secondPage.History, 2)
+		assert.Greater(t, secondPage.History[0].ID, firstPage.History[1].ID)
+
+		lastPage := getHistory(t, cagePath(cage.ID)+"/history?limit=2&cursor="+secondPage.NextCursor)
+		assert.Len(t, lastPage.History, 1)
+		assert.Empty(t, lastPage.NextCursor)
+	})
+
+	t.Run("Invalid paging parameters", func(t *testing.T) {
+		for _, query := range []string{"limit=0", "limit=101", "limit=many", "cursor=invalid"} {
+			response := sendAs(http.MethodGet, cagePath(activeCage.ID)+"/history?"+query, "", "")
+
+			assert.Equal(t, http.StatusBadRequest, response.Code, query)
+		}
+	})
+
+	t.Run("Cage not found", func(t *testing.T) {
+		response := sendAs(http.MethodGet, cagePath(123456)+"/history", "", "")
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestHistoryIsImmutable(t *testing.T) {
+	if testDB == nil {
+		t.Skip("requires a database backend")
+	}
+
+	t.Run("History entries cannot be changed or removed", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		sendAs(http.MethodPatch, cagePath(cage.ID), `{"power_status": "DOWN"}`, "")
+
+		err := testDB.Exec("UPDATE history SET actor = 'nobody' WHERE entity_type = 'cage' AND entity_id = ?", cage.ID).Error
+		assert.ErrorContains(t, err, "history_immutable")
+
+		err = testDB.Exec("DELETE FROM history WHERE entity_type = 'cage' AND entity_id = ?", cage.ID).Error
+		assert.ErrorContains(t, err, "history_immutable")
+	})
+}
+
+type historySnapshot struct {
+	CageID    uint    `json:"cage_id"`
+	DeletedAt *string `json:"deleted_at"`
+}
+
+func snapshotOf(t *testing.T, data json.RawMessage) historySnapshot {
+	var snapshot historySnapshot
+	assert.NoError(t, json.Unmarshal(data, &snapshot))
+	return snapshot
+}
+
+func getHistory(t *testing.T, path string) apimodels.GetHistoryResponse {
+	response := sendAs(http.MethodGet, path, "", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	var history apimodels.GetHistoryResponse
+	json.Unmarshal(response.Body.Bytes(), &history)
+	return history
+}
+
+func sendAs(method string, path string, payload string, actor string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(method, path, bytes.NewBufferString(payload))
+	if actor != "" {
+		request.Header.Set("X-Actor", actor)
+	}
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	return response
+}
@@ -0,0 +1,245 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"pp-jurassic-park-api/internal/api/handlers"
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/config"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	t.Run("Retried cage creation is replayed", func(t *testing.T) {
+		response := sendWithIdempotencyKey(http.MethodPost, "/cages", `{"capacity": 2, "power_status": "ACTIVE"}`, "cage-1")
+		assert.Equal(t, http.StatusOK, response.Code)
+		var created apimodels.CreateCageResponse
+		json.Unmarshal(response.Body.Bytes(), &created)
+		cageIDsToCleanup = append(cageIDsToCleanup, created.Cage.ID)
+		assert.Empty(t, response.Header().Get(handlers.IdempotentReplayedHeader))
+
+		// Formatted differently, but the same request.
+		retry := sendWithIdempotencyKey(http.MethodPost, "/cages", "{\n  \"capacity\": 2,\n  \"power_status\": \"ACTIVE\"\n}", "cage-1")
+		assert.Equal(t, http.StatusOK, retry.Code)
+		assert.Equal(t, response.Body.String(), retry.Body.String())
+		assert.Equal(t, response.Header().Get("ETag"), retry.Header().Get("ETag"))
+		assert.Equal(t, response.Header().Get("Content-Type"), retry.Header().Get("Content-Type"))
+		assert.Equal(t, "true", retry.Header().Get(handlers.IdempotentReplayedHeader))
+
+		history := getHistory(t, cagePath(created.Cage.ID)+"/history")
+		assert.Len(t, history.History, 1)
+	})
+
+	t.Run("Retried dinosaur does not take another slot", func(t *testing.T) {
+		cage := CreateTestCage(3, apimodels.Active)
+		payload := fmt.Sprintf(`{"name": "Bo", "species": "Stegosaurus", "cage_id": %d}`, cage.ID)
+
+		var ids []uint
+		for i := 0; i < 3; i++ {
+			response := sendWithIdempotencyKey(http.MethodPost, "/dinosaurs", payload, "dinosaur-1")
+			assert.Equal(t, http.StatusOK, response.Code)
+			var added apimodels.AddDinosaurResponse
+			json.Unmarshal(response.Body.Bytes(), &added)
+			ids = append(ids, added.Dinosaur.ID)
+		}
+		dinosaurIDsToCleanup = append(dinosaurIDsToCleanup, ids[0])
+
+		assert.Equal(t, []uint{ids[0], ids[0], ids[0]}, ids)
+		assertCurrentCount(t, cage.ID, 1)
+	})
+
+	t.Run("Key reused for a different request", func(t *testing.T) {
+		response := sendWithIdempotencyKey(http.MethodPost, "/cages", `{"capacity": 1, "power_status": "DOWN"}`, "cage-2")
+		var created apimodels.CreateCageResponse
+		json.Unmarshal(response.Body.Bytes(), &created)
+		cageIDsToCleanup = append(cageIDsToCleanup, created.Cage.ID)
+
+		response = sendWithIdempotencyKey(http.MethodPost, "/cages", `{"capacity": 5, "power_status": "DOWN"}`, "cage-2")
+		problem := assertProblem(t, response, http.StatusUnprocessableEntity, apimodels.IdempotencyKeyReused)
+		assert.Equal(t, handlers.IdempotencyKeyHeader, problem.Field)
+
+		// The query string is part of the request as well.
+		response = sendWithIdempotencyKey(http.MethodPost, "/cages?dry_run=true", `{"capacity": 1, "power_status": "DOWN"}`, "cage-2")
+		assertProblem(t, response, http.StatusUnprocessableEntity, apimodels.IdempotencyKeyReused)
+	})
+
+	t.Run("Keys are shared by the versions of the API", func(t *testing.T) {
+		payload := `{"capacity": 1, "power_status": "DOWN"}`
+		first := sendWithIdempotencyKey(http.MethodPost, "/v1/cages", payload, "cage-5")
+		var created apimodels.CreateCageResponse
+		json.Unmarshal(first.Body.Bytes(), &created)
+		cageIDsToCleanup = append(cageIDsToCleanup, created.Cage.ID)
+
+		response := sendWithIdempotencyKey(http.MethodPost, "/cages", payload, "cage-5")
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "true", response.Header().Get(handlers.IdempotentReplayedHeader))
+		assert.Equal(t, first.Body.String(), response.Body.String())
+
+		// The stored response is not in the format of v2.
+		response = sendWithIdempotencyKey(http.MethodPost, "/v2/cages", payload, "cage-5")
+		assertProblem(t, response, http.StatusUnprocessableEntity, apimodels.IdempotencyKeyReused)
+	})
+
+	t.Run("Keys are scoped to the route", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Active)
+		payload := fmt.Sprintf(`{"name": "Bo", "species": "Stegosaurus", "cage_id": %d}`, cage.ID)
+
+		response := sendWithIdempotencyKey(http.MethodPost, "/v2/dinosaurs", payload, "cage-5")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Empty(t, response.Header().Get(handlers.IdempotentReplayedHeader))
+		deleteDinosaursInCage(cage.ID)
+	})
+
+	t.Run("Rejected requests are replayed", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Down)
+		payload := fmt.Sprintf(`{"name": "Bo", "species": "Stegosaurus", "cage_id": %d}`, cage.ID)
+
+		response := sendWithIdempotencyKey(http.MethodPost, "/dinosaurs", payload, "dinosaur-2")
+		assertProblem(t, response, http.StatusConflict, apimodels.CageUnpowered)
+
+		sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"power_status": "ACTIVE"}`, "")
+		response = sendWithIdempotencyKey(http.MethodPost, "/dinosaurs", payload, "dinosaur-2")
+		assertProblem(t, response, http.StatusConflict, apimodels.CageUnpowered)
+		assert.Equal(t, "true", response.Header().Get(handlers.IdempotentReplayedHeader))
+		assertCurrentCount(t, cage.ID, 0)
+	})
+
+	t.Run("Concurrent retries", func(t *testing.T) {
+		cage := CreateTestCage(10, apimodels.Active)
+		payload := fmt.Sprintf(`{"name": "Bo", "species": "Stegosaurus", "cage_id": %d}`, cage.ID)
+
+		codes := sendInParallel(t, 10, func(int) *http.Request {
+			request, _ := http.NewRequest(http.MethodPost, "/dinosaurs", strings.NewReader(payload))
+			request.Header.Set(handlers.IdempotencyKeyHeader, "dinosaur-3")
+			return request
+		})
+
+		assert.Equal(t, 10, codes[http.StatusOK]+codes[http.StatusConflict], codes)
+		assert.GreaterOrEqual(t, codes[http.StatusOK], 1)
+		assertCurrentCount(t, cage.ID, 1)
+		deleteDinosaursInCage(cage.ID)
+	})
+
+	t.Run("Key in use", func(t *testing.T) {
+		keys := service.NewIdempotencyService(store, time.Duration(config.Default().Server.WriteTimeout))
+		_, err := keys.Begin(ctx, "POST /cages", "cage-3", "fingerprint")
+		assert.NoError(t, err)
+
+		_, err = keys.Begin(ctx, "POST /cages", "cage-3", "fingerprint")
+		assert.ErrorIs(t, err, service.ErrIdempotencyKeyInUse)
+
+		assert.NoError(t, keys.Release(ctx, "POST /cages", "cage-3"))
+		stored, err := keys.Begin(ctx, "POST /cages", "cage-3", "fingerprint")
+		assert.NoError(t, err)
+		assert.Nil(t, stored)
+	})
+
+	t.Run("Keys left reserved are taken over after their lease", func(t *testing.T) {
+		abandoned := dbmodels.IdempotencyKey{
+			Scope:       "POST /cages",
+			Key:         "cage-6",
+			Fingerprint: "another request",
+			CreatedAt:   time.Now().UTC().Add(-time.Duration(config.Default().Server.WriteTimeout) - service.IdempotencyKeyLeaseMargin - time.Second),
+		}
+		assert.NoError(t, store.IdempotencyKeys().Create(ctx, &abandoned))
+
+		response := sendWithIdempotencyKey(http.MethodPost, "/v2/cages", `{"capacity": 1, "power_status": "DOWN"}`, "cage-6")
+		assert.Equal(t, http.StatusOK, response.Code)
+		var created apimodels.CageSummaryResponse
+		json.Unmarshal(response.Body.Bytes(), &created)
+		cageIDsToCleanup = append(cageIDsToCleanup, created.Cage.ID)
+
+		response = sendWithIdempotencyKey(http.MethodPost, "/v2/cages", `{"capacity": 1, "power_status": "DOWN"}`, "cage-6")
+		assert.Equal(t, "true", response.Header().Get(handlers.IdempotentReplayedHeader))
+	})
+
+	t.Run("Expired keys can be used again", func(t *testing.T) {
+		expired := dbmodels.IdempotencyKey{
+			Scope:       "POST /cages",
+			Key:         "cage-4",
+			Fingerprint: "another request",
+			StatusCode:  http.StatusOK,
+			ContentType: "application/json",
+			Body:        `{}`,
+			CreatedAt:   time.Now().UTC().Add(-service.IdempotencyKeyTTL - time.Minute),
+		}
+		assert.NoError(t, store.IdempotencyKeys().Create(ctx, &expired))
+
+		response := sendWithIdempotencyKey(http.MethodPost, "/cages", `{"capacity": 1, "power_status": "DOWN"}`, "cage-4")
+		assert.Equal(t, http.StatusOK, response.Code)
+		var created apimodels.CreateCageResponse
+		json.Unmarshal(response.Body.Bytes(), &created)
+		cageIDsToCleanup = append(cageIDsToCleanup, created.Cage.ID)
+		assert.NotZero(t, created.Cage.ID)
+	})
+
+	t.Run("Requests are cut off before their lease runs out", func(t *testing.T) {
+		keys := service.NewIdempotencyService(stalledStore{Store: store}, 50*time.Millisecond)
+		stalledRouter := gin.Default()
+		stalledRouter.POST("/cages", handlers.NewCageHandler(service.NewCageService(stalledStore{Store: store}), keys).CreateCage)
+		request, _ := http.NewRequest(http.MethodPost, "/cages", bytes.NewBufferString(`{"capacity": 1, "power_status": "DOWN"}`))
+		request.Header.Set(handlers.IdempotencyKeyHeader, "cage-7")
+		response := httptest.NewRecorder()
+
+		stalledRouter.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusInternalServerError, response.Code)
+		assert.Less(t, keys.Timeout(), keys.Lease())
+		_, err := store.IdempotencyKeys().Get(ctx, "POST /cages", "cage-7")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+
+	t.Run("Invalid key", func(t *testing.T) {
+		response := sendWithIdempotencyKey(http.MethodPost, "/cages", `{"capacity": 1, "power_status": "DOWN"}`, strings.Repeat("k", 256))
+
+		problem := assertProblem(t, response, http.StatusBadRequest, apimodels.ValidationFailed)
+		assert.Equal(t, handlers.IdempotencyKeyHeader, problem.Field)
+	})
+}
+
+// stalledStore is a store whose cages are only created once the request has been canceled.
+type stalledStore struct {
+	repository.Store
+}
+
+func (s stalledStore) Cages() repository.CageRepository {
+	return stalledCages{CageRepository: s.Store.Cages()}
+}
+
+func (s stalledStore) Transaction(ctx context.Context, fn func(tx repository.Store) error) error {
+	return s.Store.Transaction(ctx, func(tx repository.Store) error {
+		return fn(stalledStore{Store: tx})
+	})
+}
+
+type stalledCages struct {
+	repository.CageRepository
+}
+
+func (r stalledCages) Create(ctx context.Context, cage *dbmodels.Cage) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func sendWithIdempotencyKey(method string, path string, payload string, key string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(method, path, bytes.NewBufferString(payload))
+	request.Header.Set(handlers.IdempotencyKeyHeader, key)
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	return response
+}
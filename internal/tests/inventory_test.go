@@ -0,0 +1,319 @@
+package tests
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportInventory(t *testing.T) {
+	t.Run("CSV lists cages, then dinosaurs", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, "/v1/export?format=csv", "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "text/csv", response.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="inventory.csv"`, response.Header().Get("Content-Disposition"))
+
+		rows, err := csv.NewReader(response.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"kind", "id", "capacity", "power_status", "name", "species", "type", "cage_id", "cage_ref"}, rows[0])
+		downCageID := strconv.Itoa(int(downCage.ID))
+		assert.Contains(t, rows, []string{"cage", downCageID, "2", "DOWN", "", "", "", "", downCageID})
+		cageID := strconv.Itoa(int(cageWithTyrannosaurus.ID))
+		assert.Contains(t, rows, []string{"dinosaur", strconv.Itoa(int(tyrannosaurus.ID)), "", "", "Terry", "Tyrannosaurus",
+			"CARNIVORE", cageID, cageID})
+
+		kinds := []string{}
+		for _, row := range rows[1:] {
+			kinds = append(kinds, row[0])
+		}
+		assert.True(t, slices.IsSorted(kinds), "cages are listed before dinosaurs")
+	})
+
+	t.Run("NDJSON holds a record per line", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Tony", apimodels.Triceratops, apimodels.Herbivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodGet, "/v1/export?format=ndjson", "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "application/x-ndjson", response.Header().Get("Content-Type"))
+
+		records := []apimodels.InventoryRecord{}
+		scanner := bufio.NewScanner(response.Body)
+		for scanner.Scan() {
+			var record apimodels.InventoryRecord
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+			records = append(records, record)
+		}
+		assert.Contains(t, records, apimodels.InventoryRecord{
+			Kind: apimodels.InventoryCage, ID: cage.ID, Capacity: 2, PowerStatus: apimodels.Active, CageRef: strconv.Itoa(int(cage.ID)),
+		})
+		assert.Contains(t, records, apimodels.InventoryRecord{
+			Kind: apimodels.InventoryDinosaur, ID: dinosaur.ID, Name: "Tony", Species: "Triceratops",
+			Type: apimodels.Herbivore, CageID: cage.ID, CageRef: strconv.Itoa(int(cage.ID)),
+		})
+	})
+
+	t.Run("Deleted records are left out", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Down)
+		require.NoError(t, store.Cages().Delete(ctx, cage.ID))
+
+		response := sendWithIfMatch(http.MethodGet, "/v1/export?format=ndjson", "", "")
+		assert.NotContains(t, response.Body.String(), fmt.Sprintf(`"id":%d,"capacity":1,"power_status":"DOWN"`, cage.ID))
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, "/v1/export?format=xlsx", "", "")
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), `"field":"format"`)
+	})
+}
+
+func TestImportInventory(t *testing.T) {
+	t.Run("CSV adds cages and their dinosaurs", func(t *testing.T) {
+		existing := CreateTestCage(2, apimodels.Active)
+		t.Cleanup(func() { deleteDinosaursInCage(existing.ID) })
+		cages := cleanupImportedCages(t)
+
+		response, report := importInventory(t, "csv", false, fmt.Sprintf(`kind,capacity,power_status,name,species,cage_id,cage_ref
+cage,2,ACTIVE,,,,raptors
+dinosaur,,,Blue,Velociraptor,,raptors
+dinosaur,,,Delta,Velociraptor,,raptors
+dinosaur,,,Bo,Stegosaurus,%d,
+`, existing.ID))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, apimodels.ImportInventoryResponse{Cages: 1, Dinosaurs: 3, Errors: []apimodels.ImportError{}}, report)
+		imported := cages()
+		if assert.Len(t, imported, 1) {
+			assert.Equal(t, 2, imported[0].Capacity)
+			assertCurrentCount(t, imported[0].ID, 2)
+		}
+		assertCurrentCount(t, existing.ID, 1)
+	})
+
+	t.Run("NDJSON adds cages and their dinosaurs", func(t *testing.T) {
+		cages := cleanupImportedCages(t)
+
+		response, report := importInventory(t, "ndjson", false, `{"kind": "cage", "cage_ref": "7", "capacity": 1, "power_status": "ACTIVE"}
+
+{"kind": "dinosaur", "name": "Rexy", "species": "Tyrannosaurus", "type": "CARNIVORE", "cage_ref": "7"}
+{"kind": "cage", "capacity": 3, "power_status": "DOWN"}
+`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, 2, report.Cages)
+		assert.Equal(t, 1, report.Dinosaurs)
+		assert.Len(t, cages(), 2)
+	})
+
+	t.Run("Rejected rows are reported by line", func(t *testing.T) {
+		full := CreateTestCage(1, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, full.ID)
+		unpowered := CreateTestCage(1, apimodels.Down)
+		cages := cleanupImportedCages(t)
+
+		response, report := importInventory(t, "csv", false, fmt.Sprintf(`kind,capacity,power_status,name,species,type,cage_id,cage_ref
+cage,2,ACTIVE,,,,,1
+cage,0,ACTIVE,,,,,2
+dinosaur,,,Rexy,Tyrannosaurus,,,1
+dinosaur,,,Tony,Triceratops,,,1
+dinosaur,,,Mo,Pterodactyl,,,1
+dinosaur,,,Jo,Stegosaurus,,%d,
+dinosaur,,,Lo,Stegosaurus,,,2
+dinosaur,,,Zo,Stegosaurus,,%d,
+dinosaur,,,Po,Stegosaurus,,999999,
+lagoon,,,,,,,
+cage,3
+dinosaur,,,Vo,Stegosaurus,,,3
+dinosaur,,,Ko,Stegosaurus,CARNIVORE,,1
+cage,2,ACTIVE,,,,,1
+`, full.ID, unpowered.ID))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		assert.Equal(t, []apimodels.ImportError{
+			{Line: 3, Kind: apimodels.InventoryCage, Code: apimodels.ValidationFailed, Field: "capacity", Error: "Capacity should be greater than 0."},
+			{Line: 5, Kind: apimodels.InventoryDinosaur, Code: apimodels.DietConflict, CageRef: "1",
+				Error: "Herbivore Dinosaur cannot be placed in cage with Carnivores."},
+			{Line: 6, Kind: apimodels.InventoryDinosaur, Code: apimodels.ValidationFailed, Field: "species", Error: "Unknown species."},
+			{Line: 7, Kind: apimodels.InventoryDinosaur, Code: apimodels.CageFull, CageID: full.ID,
+				Error: "Dinosaur cannot be placed in cage that is already full."},
+			{Line: 8, Kind: apimodels.InventoryDinosaur, Code: apimodels.ValidationFailed, Field: "cage_ref", CageRef: "2",
+				Error: "Cage of the dinosaur is rejected by its own row."},
+			{Line: 9, Kind: apimodels.InventoryDinosaur, Code: apimodels.CageUnpowered, CageID: unpowered.ID,
+				Error: "Dinosaur cannot be placed in cage that has no power."},
+			{Line: 10, Kind: apimodels.InventoryDinosaur, Code: apimodels.NotFound, CageID: 999999, Error: "Cage not found."},
+			{Line: 11, Code: apimodels.ValidationFailed, Field: "kind", Error: "Invalid kind. Kind should be cage or dinosaur."},
+			{Line: 12, Code: apimodels.ValidationFailed, Error: "Invalid row. It has 2 columns, the header 8."},
+			{Line: 13, Kind: apimodels.InventoryDinosaur, Code: apimodels.ValidationFailed, Field: "cage_ref", CageRef: "3",
+				Error: "Unknown cage_ref. No cage of the import has it."},
+			{Line: 14, Kind: apimodels.InventoryDinosaur, Code: apimodels.ValidationFailed, Field: "type",
+				Error: "Invalid type. Type should match the diet of the species."},
+			{Line: 15, Kind: apimodels.InventoryCage, Code: apimodels.ValidationFailed, Field: "cage_ref", CageRef: "1",
+				Error: "Duplicate cage_ref. Cage refs should be unique within the import."},
+		}, report.Errors)
+		assert.Zero(t, report.Cages)
+		assert.Zero(t, report.Dinosaurs)
+		assert.Empty(t, cages(), "nothing is imported")
+		assertCurrentCount(t, full.ID, 1)
+	})
+
+	t.Run("Dry run imports nothing", func(t *testing.T) {
+		cages := cleanupImportedCages(t)
+		payload := `{"kind": "cage", "cage_ref": "1", "capacity": 1, "power_status": "ACTIVE"}
+{"kind": "dinosaur", "name": "Rexy", "species": "Tyrannosaurus", "cage_ref": "1"}
+`
+
+		response, report := importInventory(t, "ndjson", true, payload)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, apimodels.ImportInventoryResponse{DryRun: true, Cages: 1, Dinosaurs: 1, Errors: []apimodels.ImportError{}}, report)
+		assert.Empty(t, cages())
+
+		response, report = importInventory(t, "ndjson", true, payload+`{"kind": "dinosaur", "name": "Blue", "species": "Velociraptor", "cage_ref": "1"}`)
+		assert.Equal(t, http.StatusOK, response.Code)
+		if assert.Len(t, report.Errors, 1) {
+			assert.Equal(t, 3, report.Errors[0].Line)
+			assert.Equal(t, apimodels.CageFull, report.Errors[0].Code)
+		}
+		assert.Empty(t, cages())
+	})
+
+	t.Run("Malformed NDJSON lines are reported", func(t *testing.T) {
+		response, report := importInventory(t, "ndjson", true, `{"kind": "cage", "capacity": "big", "power_status": "ACTIVE"}
+{"kind": "cage",
+`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, []apimodels.ImportError{
+			{Line: 1, Kind: apimodels.InventoryCage, Code: apimodels.ValidationFailed, Field: "capacity", Error: "Invalid capacity."},
+			{Line: 2, Code: apimodels.ValidationFailed, Error: "Invalid JSON."},
+		}, report.Errors)
+	})
+
+	t.Run("Exported inventory can be imported", func(t *testing.T) {
+		cleanupImportedCages(t)
+		cage := CreateTestCage(2, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		export := sendWithIfMatch(http.MethodGet, "/v1/export?format=csv", "", "").Body.String()
+		response, report := importInventory(t, "csv", true, export)
+		assert.Equal(t, http.StatusOK, response.Code)
+		// Dinosaurs of the export live in powered cages, and each cage holds at most its capacity.
+		for _, importErr := range report.Errors {
+			assert.Equal(t, apimodels.CageUnpowered, importErr.Code, "line %d: %s", importErr.Line, importErr.Error)
+		}
+	})
+
+	t.Run("Importing an export again copies its records", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		bo := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		cages := cleanupImportedCages(t)
+
+		rows, err := csv.NewReader(sendWithIfMatch(http.MethodGet, "/v1/export?format=csv", "", "").Body).ReadAll()
+		require.NoError(t, err)
+		ref := strconv.Itoa(int(cage.ID))
+		export := &strings.Builder{}
+		writer := csv.NewWriter(export)
+		for _, row := range rows {
+			if row[0] == "kind" || row[len(row)-1] == ref {
+				require.NoError(t, writer.Write(row))
+			}
+		}
+		writer.Flush()
+
+		response, report := importInventory(t, "csv", false, export.String())
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, apimodels.ImportInventoryResponse{Cages: 1, Dinosaurs: 1, Errors: []apimodels.ImportError{}}, report)
+		imported := cages()
+		if assert.Len(t, imported, 1) {
+			assert.NotEqual(t, cage.ID, imported[0].ID)
+			copied, err := store.Cages().Get(ctx, imported[0].ID)
+			require.NoError(t, err)
+			if assert.Len(t, copied.Dinosaurs, 1) {
+				assert.Equal(t, "Bo", copied.Dinosaurs[0].Name)
+			}
+		}
+		assertCurrentCount(t, cage.ID, 1)
+		unchanged, _ := store.Dinosaurs().Get(ctx, bo.ID)
+		assert.Equal(t, cage.ID, unchanged.CageID)
+	})
+
+	t.Run("Invalid imports", func(t *testing.T) {
+		for name, test := range map[string]struct {
+			format  string
+			payload string
+			field   string
+		}{
+			"Unknown format":  {format: "xlsx", payload: "kind\ncage", field: "format"},
+			"Empty import":    {format: "csv", payload: "kind,capacity\n"},
+			"Unknown column":  {format: "csv", payload: "kind,color\ncage,red"},
+			"Missing kind":    {format: "csv", payload: "capacity,power_status\n2,ACTIVE"},
+			"Malformed CSV":   {format: "csv", payload: "kind,name\ndinosaur,\"Rex"},
+			"Empty NDJSON":    {format: "ndjson", payload: "\n\n"},
+			"Too many rows":   {format: "csv", payload: "kind\n" + strings.Repeat("cage\n", 10001)},
+			"Too long a line": {format: "ndjson", payload: `{"kind": "dinosaur", "name": "` + strings.Repeat("x", 64*1024) + `"}`},
+		} {
+			t.Run(name, func(t *testing.T) {
+				response := sendWithIfMatch(http.MethodPost, "/v1/import?format="+test.format, test.payload, "")
+				assert.Equal(t, http.StatusBadRequest, response.Code)
+				var problem apimodels.Problem
+				require.NoError(t, json.Unmarshal(response.Body.Bytes(), &problem))
+				assert.Equal(t, apimodels.ValidationFailed, problem.Code)
+				assert.Equal(t, test.field, problem.Field)
+			})
+		}
+	})
+}
+
+func importInventory(t *testing.T, format string, dryRun bool, payload string) (*httptest.ResponseRecorder, apimodels.ImportInventoryResponse) {
+	response := sendWithIfMatch(http.MethodPost, fmt.Sprintf("/v1/import?format=%s&dry_run=%t", format, dryRun), payload, "")
+
+	var report apimodels.ImportInventoryResponse
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &report))
+	return response, report
+}
+
+// cleanupImportedCages returns the cages created since it has been called, which are removed along with
+// their dinosaurs at the end of the test.
+func cleanupImportedCages(t *testing.T) func() []dbmodels.Cage {
+	existing := map[uint]bool{}
+	for _, cage := range listAllCages(t) {
+		existing[cage.ID] = true
+	}
+	imported := func() []dbmodels.Cage {
+		result := []dbmodels.Cage{}
+		for _, cage := range listAllCages(t) {
+			if !existing[cage.ID] {
+				result = append(result, cage)
+			}
+		}
+		return result
+	}
+	t.Cleanup(func() {
+		ids := []uint{}
+		for _, cage := range imported() {
+			ids = append(ids, cage.ID)
+		}
+		DeleteTestCages(ids)
+	})
+	return imported
+}
+
+func listAllCages(t *testing.T) []dbmodels.Cage {
+	cages, err := store.Cages().Unscoped().List(ctx, repository.CageFilter{}, repository.Page{})
+	require.NoError(t, err)
+	return cages
+}
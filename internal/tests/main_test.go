@@ -1,15 +1,23 @@
 package tests
 
 import (
+	"context"
 	"log"
 	"os"
+	"path/filepath"
 	"pp-jurassic-park-api/internal/api/handlers"
 	apimodels "pp-jurassic-park-api/internal/api/models"
+	"pp-jurassic-park-api/internal/config"
 	"pp-jurassic-park-api/internal/db"
 	dbmodels "pp-jurassic-park-api/internal/db/models"
+	"pp-jurassic-park-api/internal/repository"
+	"pp-jurassic-park-api/internal/service"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 var activeCage dbmodels.Cage
@@ -31,13 +39,93 @@ var dinosaurToBeRemoved dbmodels.Dinosaur
 var cageIDsToCleanup []uint
 var dinosaurIDsToCleanup []uint
 
+var ctx = context.Background()
+var store repository.Store
+var testDB *gorm.DB
 var router *gin.Engine
 
+// TestMain runs the whole suite once per storage backend.
+// Backends can be selected with TEST_BACKENDS (e.g. "memory,sqlite,postgres"). By default the suite
+// always runs against the in-memory store and a temporary SQLite file, and against Postgres whenever it is reachable.
 func TestMain(m *testing.M) {
-	if err := db.Migrate(); err != nil {
-		log.Fatalf("Failed to run migrations for test database: %v", err)
+	backends, explicit := os.LookupEnv("TEST_BACKENDS")
+	if !explicit {
+		backends = "memory,sqlite,postgres"
 	}
 
+	exitCode := 0
+	for _, backend := range strings.Split(backends, ",") {
+		backendStore, err := setupStore(strings.TrimSpace(backend))
+		if err != nil {
+			if explicit {
+				log.Fatalf("Failed to set up %s backend: %v", backend, err)
+			}
+			log.Printf("Skipping %s backend: %v", backend, err)
+			continue
+		}
+
+		log.Printf("Running tests against %s backend", backend)
+		if code := runSuite(m, backendStore); code != 0 {
+			exitCode = code
+		}
+	}
+
+	os.Exit(exitCode)
+}
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// testDatabaseConfig points at the dedicated test database from docker-compose.
+func testDatabaseConfig() config.Database {
+	cfg := config.Default().Database
+	cfg.Host = "postgrestest"
+	cfg.User = "gormtest"
+	cfg.Password = "gormtest"
+	cfg.Name = "gormtest"
+	return cfg
+}
+
+func setupStore(backend string) (repository.Store, error) {
+	switch backend {
+	case "memory":
+		testDB = nil
+		return repository.NewMemoryStore(), nil
+	case "sqlite":
+		dir, err := os.MkdirTemp("", "jurassic-park-test")
+		if err != nil {
+			return nil, err
+		}
+		cfg := config.Default().Database
+		cfg.Driver = config.DriverSQLite
+		cfg.Path = filepath.Join(dir, "park.db")
+		return setupDatabaseStore(cfg)
+	case "postgres":
+		return setupDatabaseStore(testDatabaseConfig())
+	default:
+		log.Fatalf("Unknown test backend %q", backend)
+		return nil, nil
+	}
+}
+
+func setupDatabaseStore(cfg config.Database) (repository.Store, error) {
+	dbConn, err := db.Connect(cfg, "warn")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.MigrateUp(dbConn); err != nil {
+		return nil, err
+	}
+	testDB = dbConn
+	return repository.NewGormStore(dbConn), nil
+}
+
+func runSuite(m *testing.M, backendStore repository.Store) int {
+	store = backendStore
+	cageIDsToCleanup = nil
+	dinosaurIDsToCleanup = nil
+
 	activeCage = CreateTestCage(2, apimodels.Active)
 	downCage = CreateTestCage(2, apimodels.Down)
 	cageToBeUpdated = CreateTestCage(3, apimodels.Active)
@@ -56,29 +144,31 @@ func TestMain(m *testing.M) {
 
 	router = setupRouter()
 
-	os.Exit(m.Run())
+	code := m.Run()
 
 	DeleteTestDinosaurs(dinosaurIDsToCleanup)
 	DeleteTestCages(cageIDsToCleanup)
-}
-
-func init() {
-	os.Setenv("GO_ENV", "test")
-	gin.SetMode(gin.TestMode)
+	return code
 }
 
 func setupRouter() *gin.Engine {
+	idempotencyKeys := service.NewIdempotencyService(store, time.Duration(config.Default().Server.WriteTimeout))
+	cageService := service.NewCageService(store)
+	dinosaurService := service.NewDinosaurService(store)
+	cageHandler := handlers.NewCageHandler(cageService, idempotencyKeys)
+	dinosaurHandler := handlers.NewDinosaurHandler(dinosaurService, idempotencyKeys)
+	graphQLHandler, err := handlers.NewGraphQLHandler(cageService, dinosaurService)
+	if err != nil {
+		log.Fatalf("Failed to build GraphQL schema: %v", err)
+	}
+
 	router := gin.Default()
-	router.GET("/cages", handlers.GetCages)
-	router.GET("/cages/:id", handlers.GetCage)
-	router.POST("/cages", handlers.CreateCage)
-	router.PATCH("/cages/:id", handlers.UpdateCagePowerStatus)
-	router.DELETE("/cages/:id", handlers.DeleteCage)
-
-	router.GET("/dinosaurs", handlers.GetDinosaurs)
-	router.GET("/dinosaurs/:id", handlers.GetDinosaur)
-	router.POST("/dinosaurs", handlers.AddDinosaur)
-	router.PATCH("/dinosaurs/:id", handlers.MoveDinosaur)
-	router.DELETE("/dinosaurs/:id", handlers.RemoveDinosaur)
+	router.Use(handlers.Actor())
+	routes := handlers.Routes(cageHandler, dinosaurHandler)
+	routes = append(routes, handlers.InventoryRoutes(handlers.NewInventoryHandler(service.NewInventoryService(store)))...)
+	handlers.RegisterRoutes(router, routes)
+	router.GET("/openapi.json", handlers.OpenAPI(routes))
+	router.GET("/docs/*path", handlers.Docs("/openapi.json"))
+	router.POST("/graphql", graphQLHandler.Query)
 	return router
 }
@@ -0,0 +1,185 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatchCage(t *testing.T) {
+	t.Run("Capacity and power status are changed together", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		response := sendMergePatch(cagePath(cage.ID), `{"capacity": 4, "power_status": "DOWN"}`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var updateResponse apimodels.UpdateCageResponse
+		json.Unmarshal(response.Body.Bytes(), &updateResponse)
+		assertCage(t, updateResponse.Cage, 4, apimodels.Down, 0)
+		assert.Equal(t, `"2.1"`, response.Header().Get("ETag"))
+
+		history := getHistory(t, cagePath(cage.ID)+"/history")
+		if assert.Len(t, history.History, 1) {
+			assert.Equal(t, apimodels.Updated, history.History[0].Action)
+		}
+	})
+
+	t.Run("Capacity can shrink down to the current count", func(t *testing.T) {
+		cage := CreateTestCage(3, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		createTemporaryDinosaur(t, "Mo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := sendMergePatch(cagePath(cage.ID), `{"capacity": 1}`)
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.Contains(t, response.Body.String(), "Capacity cannot be lower than the number of dinosaurs in the cage.")
+
+		response = sendMergePatch(cagePath(cage.ID), `{"capacity": 2}`)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assertCurrentCount(t, cage.ID, 2)
+	})
+
+	t.Run("Empty patch changes nothing", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		response := sendMergePatch(cagePath(cage.ID), `{}`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, `"1.1"`, response.Header().Get("ETag"))
+	})
+
+	t.Run("Invalid patches", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		for payload, message := range map[string]string{
+			`{"capacity": 0}`:         "Capacity should be greater than 0.",
+			`{"capacity": "large"}`:   "Invalid input.",
+			`{"capacity": null}`:      "Field capacity cannot be removed.",
+			`{"power_status": "OFF"}`: "Invalid power status.",
+			`{"current_count": 1}`:    "Field current_count cannot be changed.",
+			`{"id": 7}`:               "Field id cannot be changed.",
+			`[]`:                      "Invalid input.",
+		} {
+			response := sendMergePatch(cagePath(cage.ID), payload)
+
+			assert.Equal(t, http.StatusBadRequest, response.Code, payload)
+			assert.Contains(t, response.Body.String(), message, payload)
+		}
+	})
+
+	t.Run("Unsupported content type", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPatch, cagePath(activeCage.ID), bytes.NewBufferString(`{"capacity": 4}`))
+		request.Header.Set("Content-Type", "application/json-patch+json")
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		assert.Equal(t, http.StatusUnsupportedMediaType, response.Code)
+	})
+}
+
+func TestMergePatchDinosaur(t *testing.T) {
+	t.Run("Name is corrected", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := sendMergePatch(dinosaurPath(dinosaur.ID), `{"name": "Bob"}`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var updateResponse apimodels.UpdateDinosaurResponse
+		json.Unmarshal(response.Body.Bytes(), &updateResponse)
+		assertDinosaur(t, updateResponse.Dinosaur, "Bob", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		// The cage lists its dinosaurs, so its occupancy changes along with them.
+		response = sendWithIfMatch(http.MethodGet, cagePath(cage.ID), "", "")
+		assert.Equal(t, `"1.2"`, response.Header().Get("ETag"))
+	})
+
+	t.Run("Species change keeps the type in line", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		createTemporaryDinosaur(t, "Mo", apimodels.Triceratops, apimodels.Herbivore, cage.ID)
+
+		response := sendMergePatch(dinosaurPath(dinosaur.ID), `{"species": "Ankylosaurus"}`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var updateResponse apimodels.UpdateDinosaurResponse
+		json.Unmarshal(response.Body.Bytes(), &updateResponse)
+		assertDinosaur(t, updateResponse.Dinosaur, "Bo", apimodels.Ankylosaurus, apimodels.Herbivore, cage.ID)
+	})
+
+	t.Run("Species change conflicting with cage-mates", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		createTemporaryDinosaur(t, "Mo", apimodels.Triceratops, apimodels.Herbivore, cage.ID)
+
+		response := sendMergePatch(dinosaurPath(dinosaur.ID), `{"species": "Velociraptor"}`)
+
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.Contains(t, response.Body.String(), "Carnivore")
+	})
+
+	t.Run("Species change in a full cage without power", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		sendMergePatch(cagePath(cage.ID), `{"power_status": "DOWN"}`)
+
+		response := sendMergePatch(dinosaurPath(dinosaur.ID), `{"species": "Velociraptor"}`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `"type":"CARNIVORE"`)
+	})
+
+	t.Run("Species change and move together", func(t *testing.T) {
+		herbivoreCage := CreateTestCage(2, apimodels.Active)
+		raptorCage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, herbivoreCage.ID)
+		createTemporaryDinosaur(t, "Blue", apimodels.Velociraptor, apimodels.Carnivore, raptorCage.ID)
+
+		response := sendMergePatch(dinosaurPath(dinosaur.ID), fmt.Sprintf(`{"species": "Velociraptor", "cage_id": %d}`, raptorCage.ID))
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var updateResponse apimodels.UpdateDinosaurResponse
+		json.Unmarshal(response.Body.Bytes(), &updateResponse)
+		assertDinosaur(t, updateResponse.Dinosaur, "Bo", apimodels.Velociraptor, apimodels.Carnivore, raptorCage.ID)
+		assertCurrentCount(t, herbivoreCage.ID, 0)
+		assertCurrentCount(t, raptorCage.ID, 2)
+
+		history := getHistory(t, dinosaurPath(dinosaur.ID)+"/history")
+		if assert.Len(t, history.History, 1) {
+			assert.Equal(t, apimodels.Updated, history.History[0].Action)
+		}
+	})
+
+	t.Run("Invalid patches", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		for payload, message := range map[string]string{
+			`{"name": " "}`:         "Invalid name. Name cannot be blank.",
+			`{"name": null}`:        "Field name cannot be removed.",
+			`{"species": "Dodo"}`:   "Unknown species.",
+			`{"type": "CARNIVORE"}`: "Field type cannot be changed.",
+			`{"cage_id": "second"}`: "Invalid input.",
+		} {
+			response := sendMergePatch(dinosaurPath(dinosaur.ID), payload)
+
+			assert.Equal(t, http.StatusBadRequest, response.Code, payload)
+			assert.Contains(t, response.Body.String(), message, payload)
+		}
+	})
+}
+
+func sendMergePatch(path string, payload string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(http.MethodPatch, path, bytes.NewBufferString(payload))
+	request.Header.Set("Content-Type", "application/merge-patch+json")
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	return response
+}
@@ -0,0 +1,62 @@
+package tests
+
+import (
+	"testing"
+
+	"pp-jurassic-park-api/internal/db"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	for _, dialect := range []string{"postgres", "sqlite"} {
+		t.Run("Embedded "+dialect+" migrations are numbered and reversible", func(t *testing.T) {
+			migrations, err := db.LoadMigrations(dialect)
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, migrations)
+			for i, migration := range migrations {
+				assert.Equal(t, i+1, migration.Version)
+				assert.NotEmpty(t, migration.Name)
+				assert.NotEmpty(t, migration.Up)
+				assert.NotEmpty(t, migration.Down)
+			}
+		})
+	}
+
+	t.Run("Dialects share the migration history", func(t *testing.T) {
+		postgresMigrations, err := db.LoadMigrations("postgres")
+		assert.NoError(t, err)
+		sqliteMigrations, err := db.LoadMigrations("sqlite")
+		assert.NoError(t, err)
+
+		assert.Equal(t, len(postgresMigrations), len(sqliteMigrations))
+		for i := range postgresMigrations {
+			if i < len(sqliteMigrations) {
+				assert.Equal(t, postgresMigrations[i].Name, sqliteMigrations[i].Name)
+			}
+		}
+	})
+
+	t.Run("Unknown dialect", func(t *testing.T) {
+		_, err := db.LoadMigrations("mysql")
+
+		assert.Error(t, err)
+	})
+}
+
+func TestCheckSchema(t *testing.T) {
+	if testDB == nil {
+		t.Skip("requires a database backend")
+	}
+
+	t.Run("Schema is up to date after migrating", func(t *testing.T) {
+		assert.NoError(t, db.CheckSchema(testDB))
+
+		statuses, err := db.MigrationStatuses(testDB)
+		assert.NoError(t, err)
+		for _, status := range statuses {
+			assert.True(t, status.Applied)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+	dbmodels "pp-jurassic-park-api/internal/db/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoveDinosaurs(t *testing.T) {
+	t.Run("Carnivores swap full cages", func(t *testing.T) {
+		rexCage := CreateTestCage(1, apimodels.Active)
+		spinoCage := CreateTestCage(1, apimodels.Active)
+		rex := createTemporaryDinosaur(t, "Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, rexCage.ID)
+		spino := createTemporaryDinosaur(t, "Spino", apimodels.Spinosaurus, apimodels.Carnivore, spinoCage.ID)
+
+		// Neither move is possible on its own.
+		response := sendMergePatch(dinosaurPath(rex.ID), fmt.Sprintf(`{"cage_id": %d}`, spinoCage.ID))
+		assert.Equal(t, http.StatusConflict, response.Code)
+
+		response = sendWithIfMatch(http.MethodPost, "/moves", fmt.Sprintf(`{"moves": [
+			{"dinosaur_id": %d, "target_cage_id": %d},
+			{"dinosaur_id": %d, "target_cage_id": %d}
+		]}`, rex.ID, spinoCage.ID, spino.ID, rexCage.ID), "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var moveResponse apimodels.MoveDinosaursResponse
+		json.Unmarshal(response.Body.Bytes(), &moveResponse)
+		if assert.Len(t, moveResponse.Dinosaurs, 2) {
+			assertDinosaur(t, moveResponse.Dinosaurs[0], "Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, spinoCage.ID)
+			assertDinosaur(t, moveResponse.Dinosaurs[1], "Spino", apimodels.Spinosaurus, apimodels.Carnivore, rexCage.ID)
+		}
+		assertCurrentCount(t, rexCage.ID, 1)
+		assertCurrentCount(t, spinoCage.ID, 1)
+
+		history := getHistory(t, dinosaurPath(rex.ID)+"/history")
+		if assert.Len(t, history.History, 1) {
+			assert.Equal(t, apimodels.Moved, history.History[0].Action)
+		}
+	})
+
+	t.Run("Dinosaurs rotate through full cages", func(t *testing.T) {
+		cages := []dbmodels.Cage{
+			CreateTestCage(1, apimodels.Active), CreateTestCage(1, apimodels.Active), CreateTestCage(1, apimodels.Active),
+		}
+		dinosaurs := []uint{
+			createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cages[0].ID).ID,
+			createTemporaryDinosaur(t, "Mo", apimodels.Triceratops, apimodels.Herbivore, cages[1].ID).ID,
+			createTemporaryDinosaur(t, "Jo", apimodels.Brachiosaurus, apimodels.Herbivore, cages[2].ID).ID,
+		}
+
+		response := sendWithIfMatch(http.MethodPost, "/moves", fmt.Sprintf(`{"moves": [
+			{"dinosaur_id": %d, "target_cage_id": %d},
+			{"dinosaur_id": %d, "target_cage_id": %d},
+			{"dinosaur_id": %d, "target_cage_id": %d}
+		]}`, dinosaurs[0], cages[1].ID, dinosaurs[1], cages[2].ID, dinosaurs[2], cages[0].ID), "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, []uint{dinosaurs[2]}, listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d", cages[0].ID)))
+		assert.Equal(t, []uint{dinosaurs[0]}, listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d", cages[1].ID)))
+		assert.Equal(t, []uint{dinosaurs[1]}, listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d", cages[2].ID)))
+	})
+
+	t.Run("Final state has to follow the rules", func(t *testing.T) {
+		herbivoreCage := CreateTestCage(2, apimodels.Active)
+		raptorCage := CreateTestCage(2, apimodels.Active)
+		bo := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, herbivoreCage.ID)
+		mo := createTemporaryDinosaur(t, "Mo", apimodels.Stegosaurus, apimodels.Herbivore, herbivoreCage.ID)
+		blue := createTemporaryDinosaur(t, "Blue", apimodels.Velociraptor, apimodels.Carnivore, raptorCage.ID)
+
+		for moves, message := range map[string]string{
+			// Bo would join a full cage, as Blue stays.
+			fmt.Sprintf(`[{"dinosaur_id": %d, "target_cage_id": %d}, {"dinosaur_id": %d, "target_cage_id": %d}]`,
+				bo.ID, raptorCage.ID, mo.ID, raptorCage.ID): "Dinosaur cannot be placed in cage that is already full.",
+			// Mo would stay behind with Blue.
+			fmt.Sprintf(`[{"dinosaur_id": %d, "target_cage_id": %d}, {"dinosaur_id": %d, "target_cage_id": %d}]`,
+				bo.ID, raptorCage.ID, blue.ID, herbivoreCage.ID): "Carnivore Dinosaur cannot be placed in cage with any other species.",
+		} {
+			response := sendWithIfMatch(http.MethodPost, "/moves", `{"moves": `+moves+`}`, "")
+
+			assert.Equal(t, http.StatusConflict, response.Code, moves)
+			assert.Contains(t, response.Body.String(), message, moves)
+		}
+		assert.ElementsMatch(t, []uint{bo.ID, mo.ID}, listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d", herbivoreCage.ID)))
+		assert.Equal(t, []uint{blue.ID}, listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d", raptorCage.ID)))
+	})
+
+	t.Run("Dinosaurs leaving a cage make room for others", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		otherCage := CreateTestCage(2, apimodels.Active)
+		bo := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		mo := createTemporaryDinosaur(t, "Mo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		jo := createTemporaryDinosaur(t, "Jo", apimodels.Triceratops, apimodels.Herbivore, otherCage.ID)
+		flo := createTemporaryDinosaur(t, "Flo", apimodels.Triceratops, apimodels.Herbivore, otherCage.ID)
+
+		response := sendWithIfMatch(http.MethodPost, "/moves", fmt.Sprintf(`{"moves": [
+			{"dinosaur_id": %d, "target_cage_id": %d},
+			{"dinosaur_id": %d, "target_cage_id": %d},
+			{"dinosaur_id": %d, "target_cage_id": %d}
+		]}`, jo.ID, cage.ID, bo.ID, otherCage.ID, mo.ID, cage.ID), "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.ElementsMatch(t, []uint{mo.ID, jo.ID}, listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d", cage.ID)))
+		assert.ElementsMatch(t, []uint{bo.ID, flo.ID}, listDinosaurIDs(t, fmt.Sprintf("/dinosaurs?cage_id=%d", otherCage.ID)))
+
+		// Mo stayed where it was.
+		history := getHistory(t, dinosaurPath(mo.ID)+"/history")
+		assert.Empty(t, history.History)
+	})
+
+	t.Run("Cage without power", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		downCage := CreateTestCage(2, apimodels.Down)
+		bo := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodPost, "/moves",
+			fmt.Sprintf(`{"moves": [{"dinosaur_id": %d, "target_cage_id": %d}]}`, bo.ID, downCage.ID), "")
+
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.Contains(t, response.Body.String(), "Dinosaur cannot be placed in cage that has no power.")
+	})
+
+	t.Run("Invalid moves", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		bo := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		for payload, status := range map[string]int{
+			`{"moves": []}`:  http.StatusBadRequest,
+			`{"moves": "a"}`: http.StatusBadRequest,
+			fmt.Sprintf(`{"moves": [{"dinosaur_id": %[1]d, "target_cage_id": %[2]d}, {"dinosaur_id": %[1]d, "target_cage_id": %[2]d}]}`,
+				bo.ID, cage.ID): http.StatusBadRequest,
+			fmt.Sprintf(`{"moves": [{"dinosaur_id": 123456, "target_cage_id": %d}]}`, cage.ID): http.StatusNotFound,
+			fmt.Sprintf(`{"moves": [{"dinosaur_id": %d, "target_cage_id": 123456}]}`, bo.ID):   http.StatusNotFound,
+		} {
+			response := sendWithIfMatch(http.MethodPost, "/moves", payload, "")
+
+			assert.Equal(t, status, response.Code, payload)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"pp-jurassic-park-api/internal/api/handlers"
+	"pp-jurassic-park-api/internal/api/openapi"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// documentPath is the committed OpenAPI document, kept in sync with the routes and models.
+const documentPath = "../../api/openapi.json"
+
+var updateDocument = flag.Bool("update-openapi", false, "rewrite api/openapi.json from the routes")
+
+func TestOpenAPIDocument(t *testing.T) {
+	t.Run("Committed document is up to date", func(t *testing.T) {
+		routes := append(handlers.Routes(nil, nil), handlers.InventoryRoutes(nil)...)
+		routes = append(routes, handlers.DebugRoutes(nil)...)
+		var generated bytes.Buffer
+		encoder := json.NewEncoder(&generated)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		assert.NoError(t, encoder.Encode(handlers.Document(routes)))
+
+		if *updateDocument {
+			assert.NoError(t, os.WriteFile(documentPath, generated.Bytes(), 0o644))
+		}
+
+		committed, err := os.ReadFile(documentPath)
+		assert.NoError(t, err)
+		assert.Equal(t, string(committed), generated.String(),
+			"Routes or models have changed. Run `go test ./internal/tests -run TestOpenAPIDocument -update-openapi` and commit api/openapi.json.")
+	})
+
+	t.Run("Served document describes every route", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, "/openapi.json", "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+		var document openapi.Document
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &document))
+
+		served := map[string]bool{}
+		for _, route := range router.Routes() {
+			if route.Path == "/openapi.json" || strings.HasPrefix(route.Path, "/docs/") || route.Path == "/graphql" {
+				continue
+			}
+			operation := strings.ToLower(route.Method) + " " + documentedPath(route.Path)
+			served[operation] = true
+		}
+		documented := map[string]bool{}
+		for path, item := range document.Paths {
+			for method := range item {
+				documented[method+" "+path] = true
+			}
+		}
+		assert.Equal(t, served, documented)
+	})
+
+	t.Run("Models are described", func(t *testing.T) {
+		document := handlers.Document(handlers.Routes(nil, nil))
+
+		cage := document.Components.Schemas["Cage"]
+		if assert.NotNil(t, cage) {
+			assert.Equal(t, []string{"id", "capacity", "current_count", "power_status", "dinosaurs"}, cage.Required)
+			assert.Equal(t, "#/components/schemas/PowerStatus", cage.Properties["power_status"].Ref)
+			assert.Equal(t, "date-time", cage.Properties["deleted_at"].Format)
+		}
+		assert.Equal(t, []string{"ACTIVE", "DOWN"}, document.Components.Schemas["PowerStatus"].Enum)
+
+		update := document.Paths["/v1/cages/{id}"]["patch"]
+		assert.Contains(t, update.RequestBody.Content, handlers.MergePatchContentType)
+		assert.Contains(t, update.Responses["412"].Content, "application/problem+json")
+		assert.Equal(t, "UpdateCageV1", update.OperationID)
+		assert.False(t, update.Deprecated)
+
+		assert.Equal(t, "#/components/schemas/CageSummaryResponse",
+			document.Paths["/v2/cages/{id}"]["patch"].Responses["200"].Content["application/json"].Schema.Ref)
+		assert.True(t, document.Paths["/cages/{id}"]["patch"].Deprecated)
+	})
+
+	t.Run("Swagger UI", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, "/docs/", "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), "swagger-ui")
+
+		response = sendWithIfMatch(http.MethodGet, "/docs/swagger-initializer.js", "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Contains(t, response.Body.String(), `url: "/openapi.json"`)
+
+		response = sendWithIfMatch(http.MethodGet, "/docs/swagger-ui-bundle.js", "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+}
+
+// documentedPath turns a gin route into an OpenAPI path, e.g. /cages/:id into /cages/{id}.
+func documentedPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if name, ok := strings.CutPrefix(segment, ":"); ok {
+			segments[i] = "{" + name + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
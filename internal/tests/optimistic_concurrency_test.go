@@ -0,0 +1,175 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCageIfMatch(t *testing.T) {
+	t.Run("ETag is returned for a cage", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		response := sendWithIfMatch(http.MethodGet, cagePath(cage.ID), "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, `"1.1"`, response.Header().Get("ETag"))
+	})
+
+	t.Run("Power update with current version", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		response := sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"power_status": "DOWN"}`, `"1"`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, `"2.1"`, response.Header().Get("ETag"))
+	})
+
+	t.Run("Concurrent power updates: second one is rejected", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		first := sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"power_status": "DOWN"}`, `"1"`)
+		second := sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"power_status": "ACTIVE"}`, `"1"`)
+
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, http.StatusPreconditionFailed, second.Code)
+		reloaded, _ := store.Cages().Get(ctx, cage.ID)
+		assert.Equal(t, string(apimodels.Down), reloaded.PowerStatus)
+	})
+
+	t.Run("Wildcard matches any version", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		response := sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"power_status": "DOWN"}`, "*")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("Malformed If-Match", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		response := sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"power_status": "DOWN"}`, "one")
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("Delete with stale version", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"power_status": "DOWN"}`, "")
+
+		response := sendWithIfMatch(http.MethodDelete, cagePath(cage.ID), "", `"1"`)
+
+		assert.Equal(t, http.StatusPreconditionFailed, response.Code)
+	})
+
+	t.Run("Delete with current version", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		response := sendWithIfMatch(http.MethodDelete, cagePath(cage.ID), "", `"1"`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("Placing a dinosaur keeps the cage version", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		etag := sendWithIfMatch(http.MethodGet, cagePath(cage.ID), "", "").Header().Get("ETag")
+		payload := fmt.Sprintf(`{"name": "Bronto", "species": "Brachiosaurus", "cage_id": %d}`, cage.ID)
+		created := sendWithIfMatch(http.MethodPost, "/dinosaurs", payload, "")
+		assert.Equal(t, http.StatusOK, created.Code)
+		t.Cleanup(func() { deleteDinosaursInCage(cage.ID) })
+
+		// The cage read before has changed, but its own fields have not.
+		response := sendConditional(cagePath(cage.ID), "If-None-Match", etag)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, `"1.2"`, response.Header().Get("ETag"))
+
+		response = sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"power_status": "DOWN"}`, etag)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, `"2.2"`, response.Header().Get("ETag"))
+
+		response = sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"power_status": "ACTIVE"}`, etag)
+		assert.Equal(t, http.StatusPreconditionFailed, response.Code)
+	})
+
+	t.Run("Capacity is checked against the dinosaurs placed since", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		etag := sendWithIfMatch(http.MethodGet, cagePath(cage.ID), "", "").Header().Get("ETag")
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		createTemporaryDinosaur(t, "Mo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"capacity": 1}`, etag)
+		assert.Equal(t, http.StatusConflict, response.Code)
+
+		response = sendWithIfMatch(http.MethodDelete, cagePath(cage.ID), "", etag)
+		assert.Equal(t, http.StatusConflict, response.Code)
+	})
+}
+
+func TestDinosaurIfMatch(t *testing.T) {
+	t.Run("Move with current version", func(t *testing.T) {
+		sourceCage := CreateTestCage(2, apimodels.Active)
+		targetCage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Milo", apimodels.Stegosaurus, apimodels.Herbivore, sourceCage.ID)
+
+		response := sendWithIfMatch(http.MethodPatch, dinosaurPath(dinosaur.ID), fmt.Sprintf(`{"cage_id": %d}`, targetCage.ID), `"1"`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, `"2"`, response.Header().Get("ETag"))
+	})
+
+	t.Run("Move with stale version", func(t *testing.T) {
+		sourceCage := CreateTestCage(2, apimodels.Active)
+		targetCage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Milo", apimodels.Stegosaurus, apimodels.Herbivore, sourceCage.ID)
+		sendWithIfMatch(http.MethodPatch, dinosaurPath(dinosaur.ID), fmt.Sprintf(`{"cage_id": %d}`, targetCage.ID), "")
+
+		response := sendWithIfMatch(http.MethodPatch, dinosaurPath(dinosaur.ID), fmt.Sprintf(`{"cage_id": %d}`, sourceCage.ID), `"1"`)
+
+		assert.Equal(t, http.StatusPreconditionFailed, response.Code)
+		reloaded, _ := store.Dinosaurs().Get(ctx, dinosaur.ID)
+		assert.Equal(t, targetCage.ID, reloaded.CageID)
+	})
+
+	t.Run("Remove with stale version", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Milo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodDelete, dinosaurPath(dinosaur.ID), "", `"7"`)
+
+		assert.Equal(t, http.StatusPreconditionFailed, response.Code)
+	})
+
+	t.Run("Remove with current version", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Milo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodDelete, dinosaurPath(dinosaur.ID), "", `"1"`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+}
+
+func sendWithIfMatch(method string, path string, payload string, ifMatch string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(method, path, bytes.NewBufferString(payload))
+	if ifMatch != "" {
+		request.Header.Set("If-Match", ifMatch)
+	}
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	return response
+}
+
+func cagePath(id uint) string {
+	return "/cages/" + strconv.FormatUint(uint64(id), 10)
+}
+
+func dinosaurPath(id uint) string {
+	return "/dinosaurs/" + strconv.FormatUint(uint64(id), 10)
+}
@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginateCages(t *testing.T) {
+	var cages []uint
+	for capacity := 60; capacity < 65; capacity++ {
+		cages = append(cages, CreateTestCage(capacity, apimodels.Active).ID)
+	}
+
+	t.Run("Pages follow the sort order", func(t *testing.T) {
+		firstPage := getCages(t, "/cages?capacity_gte=60&capacity_lte=64&sort=-capacity&limit=2&include_total=true")
+		assert.Equal(t, []uint{cages[4], cages[3]}, cageIDs(firstPage.Cages))
+		assert.NotEmpty(t, firstPage.NextCursor)
+		if assert.NotNil(t, firstPage.Total) {
+			assert.Equal(t, 5, *firstPage.Total)
+		}
+
+		secondPage := getCages(t, "/cages?capacity_gte=60&capacity_lte=64&sort=-capacity&limit=2&cursor="+firstPage.NextCursor)
+		assert.Equal(t, []uint{cages[2], cages[1]}, cageIDs(secondPage.Cages))
+		assert.Nil(t, secondPage.Total)
+
+		lastPage := getCages(t, "/cages?capacity_gte=60&capacity_lte=64&sort=-capacity&limit=2&cursor="+secondPage.NextCursor)
+		assert.Equal(t, []uint{cages[0]}, cageIDs(lastPage.Cages))
+		assert.Empty(t, lastPage.NextCursor)
+	})
+
+	t.Run("Records with equal sort values are ordered by ID", func(t *testing.T) {
+		down := []uint{CreateTestCage(65, apimodels.Down).ID, CreateTestCage(65, apimodels.Down).ID}
+
+		var ids []uint
+		path := "/cages?capacity_gte=60&capacity_lte=65&sort=power_status,-capacity&limit=1"
+		for cursor := ""; ; {
+			page := getCages(t, withCursor(path, cursor))
+			ids = append(ids, cageIDs(page.Cages)...)
+			if cursor = page.NextCursor; cursor == "" {
+				break
+			}
+		}
+
+		assert.Equal(t, []uint{cages[4], cages[3], cages[2], cages[1], cages[0], down[0], down[1]}, ids)
+	})
+
+	t.Run("Cursor issued for another sort order", func(t *testing.T) {
+		firstPage := getCages(t, "/cages?sort=-capacity&limit=1")
+
+		response := sendWithIfMatch(http.MethodGet, "/cages?sort=capacity&cursor="+firstPage.NextCursor, "", "")
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), "Invalid cursor.")
+	})
+
+	t.Run("Invalid paging parameters", func(t *testing.T) {
+		for _, query := range []string{"limit=0", "limit=101", "sort=name", "sort=capacity,-capacity", "cursor=invalid", "include_total=maybe"} {
+			request, _ := http.NewRequest(http.MethodGet, "/cages?"+query, nil)
+			response := httptest.NewRecorder()
+
+			router.ServeHTTP(response, request)
+
+			assert.Equal(t, http.StatusBadRequest, response.Code, query)
+		}
+	})
+}
+
+func TestPaginateDinosaurs(t *testing.T) {
+	cage := CreateTestCage(4, apimodels.Active)
+	ducky := createTemporaryDinosaur(t, "Ducky", apimodels.Triceratops, apimodels.Herbivore, cage.ID)
+	alan := createTemporaryDinosaur(t, "Alan", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+	cera := createTemporaryDinosaur(t, "Cera", apimodels.Triceratops, apimodels.Herbivore, cage.ID)
+	bron := createTemporaryDinosaur(t, "Bron", apimodels.Brachiosaurus, apimodels.Herbivore, cage.ID)
+
+	t.Run("Pages follow the sort order", func(t *testing.T) {
+		path := fmt.Sprintf("/dinosaurs?cage_id=%d&sort=name&limit=3&include_total=true", cage.ID)
+		firstPage := getDinosaurs(t, path)
+		assert.Equal(t, []uint{alan.ID, bron.ID, cera.ID}, dinosaurIDs(firstPage.Dinosaurs))
+		if assert.NotNil(t, firstPage.Total) {
+			assert.Equal(t, 4, *firstPage.Total)
+		}
+
+		lastPage := getDinosaurs(t, path+"&cursor="+firstPage.NextCursor)
+		assert.Equal(t, []uint{ducky.ID}, dinosaurIDs(lastPage.Dinosaurs))
+		assert.Empty(t, lastPage.NextCursor)
+	})
+
+	t.Run("Sorting by several columns", func(t *testing.T) {
+		page := getDinosaurs(t, fmt.Sprintf("/dinosaurs?cage_id=%d&sort=-species,name", cage.ID))
+
+		assert.Equal(t, []uint{cera.ID, ducky.ID, alan.ID, bron.ID}, dinosaurIDs(page.Dinosaurs))
+	})
+
+	t.Run("Names are sorted byte-wise", func(t *testing.T) {
+		otherCage := CreateTestCage(3, apimodels.Active)
+		littlefoot := createTemporaryDinosaur(t, "littlefoot", apimodels.Brachiosaurus, apimodels.Herbivore, otherCage.ID)
+		petrie := createTemporaryDinosaur(t, "Petrie", apimodels.Brachiosaurus, apimodels.Herbivore, otherCage.ID)
+		eme := createTemporaryDinosaur(t, "Émé", apimodels.Brachiosaurus, apimodels.Herbivore, otherCage.ID)
+		path := fmt.Sprintf("/dinosaurs?cage_id=%d&sort=name&limit=1", otherCage.ID)
+
+		// Upper case comes before lower case, and accented letters after both, on every store.
+		firstPage := getDinosaurs(t, path)
+		secondPage := getDinosaurs(t, path+"&cursor="+firstPage.NextCursor)
+		lastPage := getDinosaurs(t, path+"&cursor="+secondPage.NextCursor)
+		assert.Equal(t, []uint{petrie.ID, littlefoot.ID, eme.ID},
+			append(append(dinosaurIDs(firstPage.Dinosaurs), dinosaurIDs(secondPage.Dinosaurs)...), dinosaurIDs(lastPage.Dinosaurs)...))
+	})
+
+	t.Run("Default order is by ID", func(t *testing.T) {
+		page := getDinosaurs(t, fmt.Sprintf("/dinosaurs?cage_id=%d", cage.ID))
+
+		assert.Equal(t, []uint{ducky.ID, alan.ID, cera.ID, bron.ID}, dinosaurIDs(page.Dinosaurs))
+		assert.Empty(t, page.NextCursor)
+		assert.Nil(t, page.Total)
+	})
+
+	t.Run("Invalid sort column", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, "/dinosaurs?sort=capacity", "", "")
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		assert.Contains(t, response.Body.String(), "Invalid sort parameter.")
+	})
+}
+
+func getCages(t *testing.T, path string) apimodels.GetCagesResponse {
+	response := sendWithIfMatch(http.MethodGet, path, "", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	var getResponse apimodels.GetCagesResponse
+	json.Unmarshal(response.Body.Bytes(), &getResponse)
+	return getResponse
+}
+
+func getDinosaurs(t *testing.T, path string) apimodels.GetDinosaursResponse {
+	response := sendWithIfMatch(http.MethodGet, path, "", "")
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	var getResponse apimodels.GetDinosaursResponse
+	json.Unmarshal(response.Body.Bytes(), &getResponse)
+	return getResponse
+}
+
+func cageIDs(cages []apimodels.Cage) []uint {
+	ids := []uint{}
+	for _, cage := range cages {
+		ids = append(ids, cage.ID)
+	}
+	return ids
+}
+
+func dinosaurIDs(dinosaurs []apimodels.Dinosaur) []uint {
+	ids := []uint{}
+	for _, dinosaur := range dinosaurs {
+		ids = append(ids, dinosaur.ID)
+	}
+	return ids
+}
@@ -0,0 +1,141 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProblemResponses(t *testing.T) {
+	t.Run("Broken placement rules", func(t *testing.T) {
+		fullCage := CreateTestCage(1, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, fullCage.ID)
+		downCage := CreateTestCage(1, apimodels.Down)
+		raptorCage := CreateTestCage(2, apimodels.Active)
+		createTemporaryDinosaur(t, "Blue", apimodels.Velociraptor, apimodels.Carnivore, raptorCage.ID)
+
+		for _, test := range []struct {
+			species string
+			cageID  uint
+			code    apimodels.ErrorCode
+		}{
+			{"Stegosaurus", fullCage.ID, apimodels.CageFull},
+			{"Stegosaurus", downCage.ID, apimodels.CageUnpowered},
+			{"Stegosaurus", raptorCage.ID, apimodels.DietConflict},
+			{"Tyrannosaurus", raptorCage.ID, apimodels.SpeciesConflict},
+		} {
+			response := sendWithIfMatch(http.MethodPost, "/dinosaurs",
+				fmt.Sprintf(`{"name": "Mo", "species": "%s", "cage_id": %d}`, test.species, test.cageID), "")
+
+			problem := assertProblem(t, response, http.StatusConflict, test.code)
+			assert.Equal(t, test.cageID, problem.CageID, test.code)
+		}
+	})
+
+	t.Run("Problem members", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodPost, "/dinosaurs", fmt.Sprintf(`{"name": "Mo", "species": "Stegosaurus", "cage_id": %d}`, cage.ID), "")
+
+		assert.JSONEq(t, fmt.Sprintf(`{
+			"type": "urn:jurassic-park:problem:cage-full",
+			"title": "Cage is full",
+			"status": 409,
+			"detail": "Dinosaur cannot be placed in cage that is already full.",
+			"code": "CAGE_FULL",
+			"cage_id": %d
+		}`, cage.ID), response.Body.String())
+	})
+
+	t.Run("Cage not empty", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Active)
+		createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodDelete, cagePath(cage.ID), "", "")
+
+		problem := assertProblem(t, response, http.StatusConflict, apimodels.CageNotEmpty)
+		assert.Equal(t, cage.ID, problem.CageID)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		problem := assertProblem(t, sendWithIfMatch(http.MethodGet, cagePath(123456), "", ""), http.StatusNotFound, apimodels.NotFound)
+		assert.Equal(t, uint(123456), problem.CageID)
+		assert.Equal(t, "Cage not found.", problem.Detail)
+
+		problem = assertProblem(t, sendWithIfMatch(http.MethodGet, dinosaurPath(123456), "", ""), http.StatusNotFound, apimodels.NotFound)
+		assert.Equal(t, "Dinosaur not found.", problem.Detail)
+	})
+
+	t.Run("Validation failures name the field", func(t *testing.T) {
+		for _, test := range []struct {
+			method  string
+			path    string
+			payload string
+			field   string
+		}{
+			{http.MethodPost, "/cages", `{"capacity": 0, "power_status": "ACTIVE"}`, "capacity"},
+			{http.MethodPost, "/cages", `{"capacity": 1, "power_status": "OFF"}`, "power_status"},
+			{http.MethodPost, "/dinosaurs", `{"name": "Bo", "species": "Dodo", "cage_id": 1}`, "species"},
+			{http.MethodGet, "/cages?limit=0", "", "limit"},
+			{http.MethodGet, "/dinosaurs/first", "", "id"},
+			{http.MethodPost, "/cages", `[]`, ""},
+		} {
+			response := sendWithIfMatch(test.method, test.path, test.payload, "")
+
+			problem := assertProblem(t, response, http.StatusBadRequest, apimodels.ValidationFailed)
+			assert.Equal(t, test.field, problem.Field, test.path)
+		}
+
+		problem := assertProblem(t, sendMergePatch(cagePath(activeCage.ID), `{"current_count": 1}`), http.StatusBadRequest, apimodels.ValidationFailed)
+		assert.Equal(t, "current_count", problem.Field)
+	})
+
+	t.Run("Precondition failed", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Active)
+
+		response := sendWithIfMatch(http.MethodPatch, cagePath(cage.ID), `{"capacity": 2}`, `"7"`)
+
+		assertProblem(t, response, http.StatusPreconditionFailed, apimodels.PreconditionFailed)
+	})
+
+	t.Run("Unsupported media type", func(t *testing.T) {
+		request, _ := http.NewRequest(http.MethodPatch, cagePath(activeCage.ID), bytes.NewBufferString(`{"capacity": 4}`))
+		request.Header.Set("Content-Type", "text/plain")
+		response := httptest.NewRecorder()
+
+		router.ServeHTTP(response, request)
+
+		assertProblem(t, response, http.StatusUnsupportedMediaType, apimodels.UnsupportedMediaType)
+	})
+
+	t.Run("Rejected batch items carry codes", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Down)
+
+		_, batch := addBatch(t, fmt.Sprintf(`{"dinosaurs": [{"name": "Bo", "species": "Stegosaurus", "cage_id": %d}]}`, cage.ID))
+
+		if assert.Len(t, batch.Results, 1) {
+			assert.Equal(t, apimodels.CageUnpowered, batch.Results[0].Code)
+		}
+	})
+}
+
+// assertProblem checks that the response is a problem of the status and code, and returns it.
+func assertProblem(t *testing.T, response *httptest.ResponseRecorder, status int, code apimodels.ErrorCode) apimodels.Problem {
+	assert.Equal(t, status, response.Code, code)
+	assert.Equal(t, apimodels.ProblemContentType, response.Header().Get("Content-Type"), code)
+
+	var problem apimodels.Problem
+	assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &problem))
+	assert.Equal(t, status, problem.Status, code)
+	assert.Equal(t, code, problem.Code)
+	assert.NotEmpty(t, problem.Title, code)
+	return problem
+}
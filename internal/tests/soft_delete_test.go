@@ -0,0 +1,232 @@
+package tests
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSoftDeleteDinosaur(t *testing.T) {
+	t.Run("Deleted dinosaur is hidden unless requested", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodDelete, dinosaurPath(dinosaur.ID), "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		response = sendWithIfMatch(http.MethodGet, dinosaurPath(dinosaur.ID), "", "")
+		assert.Equal(t, http.StatusNotFound, response.Code)
+
+		response = sendWithIfMatch(http.MethodGet, dinosaurPath(dinosaur.ID)+"?include_deleted=true", "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+		var getResponse apimodels.GetDinosaurResponse
+		json.Unmarshal(response.Body.Bytes(), &getResponse)
+		assert.NotNil(t, getResponse.Dinosaur.DeletedAt)
+
+		assert.NotContains(t, listDinosaurIDs(t, "/dinosaurs"), dinosaur.ID)
+		assert.Contains(t, listDinosaurIDs(t, "/dinosaurs?include_deleted=true"), dinosaur.ID)
+		assertCurrentCount(t, cage.ID, 0)
+	})
+
+	t.Run("Restored dinosaur returns to its cage", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		sendWithIfMatch(http.MethodDelete, dinosaurPath(dinosaur.ID), "", "")
+
+		response := sendWithIfMatch(http.MethodPost, dinosaurPath(dinosaur.ID)+"/restore", "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var restoreResponse apimodels.RestoreDinosaurResponse
+		json.Unmarshal(response.Body.Bytes(), &restoreResponse)
+		assertDinosaur(t, restoreResponse.Dinosaur, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		assert.Nil(t, restoreResponse.Dinosaur.DeletedAt)
+		assert.Equal(t, `"2"`, response.Header().Get("ETag"))
+		assertCurrentCount(t, cage.ID, 1)
+	})
+
+	t.Run("Restoring a dinosaur that is not deleted changes nothing", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodPost, dinosaurPath(dinosaur.ID)+"/restore", "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, `"1"`, response.Header().Get("ETag"))
+	})
+
+	t.Run("Restoring into a full cage", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		sendWithIfMatch(http.MethodDelete, dinosaurPath(dinosaur.ID), "", "")
+		createTemporaryDinosaur(t, "Mo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodPost, dinosaurPath(dinosaur.ID)+"/restore", "", "")
+
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.Contains(t, response.Body.String(), "already full")
+	})
+
+	t.Run("Restoring into a cage with carnivores", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		sendWithIfMatch(http.MethodDelete, dinosaurPath(dinosaur.ID), "", "")
+		createTemporaryDinosaur(t, "Rex", apimodels.Tyrannosaurus, apimodels.Carnivore, cage.ID)
+
+		response := sendWithIfMatch(http.MethodPost, dinosaurPath(dinosaur.ID)+"/restore", "", "")
+
+		assert.Equal(t, http.StatusConflict, response.Code)
+		assert.Contains(t, response.Body.String(), "Herbivore")
+	})
+
+	t.Run("Restoring into a deleted cage", func(t *testing.T) {
+		cage := CreateTestCage(1, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		sendWithIfMatch(http.MethodDelete, dinosaurPath(dinosaur.ID), "", "")
+		response := sendWithIfMatch(http.MethodDelete, cagePath(cage.ID), "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		response = sendWithIfMatch(http.MethodPost, dinosaurPath(dinosaur.ID)+"/restore", "", "")
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.Contains(t, response.Body.String(), "Cage not found.")
+	})
+
+	t.Run("Restoring with a stale version", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		sendWithIfMatch(http.MethodDelete, dinosaurPath(dinosaur.ID), "", "")
+
+		response := sendWithIfMatch(http.MethodPost, dinosaurPath(dinosaur.ID)+"/restore", "", `"7"`)
+
+		assert.Equal(t, http.StatusPreconditionFailed, response.Code)
+	})
+
+	t.Run("Dinosaur not found", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodPost, dinosaurPath(123456)+"/restore", "", "")
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("Invalid include_deleted", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, dinosaurPath(tyrannosaurus.ID)+"?include_deleted=maybe", "", "")
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestSoftDeleteCage(t *testing.T) {
+	t.Run("Deleted cage is hidden unless requested", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+
+		response := sendWithIfMatch(http.MethodDelete, cagePath(cage.ID), "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		response = sendWithIfMatch(http.MethodGet, cagePath(cage.ID), "", "")
+		assert.Equal(t, http.StatusNotFound, response.Code)
+
+		response = sendWithIfMatch(http.MethodGet, cagePath(cage.ID)+"?include_deleted=true", "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+		var getResponse apimodels.GetCageResponse
+		json.Unmarshal(response.Body.Bytes(), &getResponse)
+		assert.NotNil(t, getResponse.Cage.DeletedAt)
+
+		assert.NotContains(t, listCageIDs(t, "/cages"), cage.ID)
+		assert.Contains(t, listCageIDs(t, "/cages?include_deleted=true"), cage.ID)
+	})
+
+	t.Run("Deleted dinosaurs do not keep a cage occupied", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		dinosaur := createTemporaryDinosaur(t, "Bo", apimodels.Stegosaurus, apimodels.Herbivore, cage.ID)
+		sendWithIfMatch(http.MethodDelete, dinosaurPath(dinosaur.ID), "", "")
+
+		response := sendWithIfMatch(http.MethodDelete, cagePath(cage.ID), "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("Restored cage is visible again", func(t *testing.T) {
+		cage := CreateTestCage(2, apimodels.Active)
+		sendWithIfMatch(http.MethodDelete, cagePath(cage.ID), "", "")
+
+		response := sendWithIfMatch(http.MethodPost, cagePath(cage.ID)+"/restore", "", `"1"`)
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var restoreResponse apimodels.RestoreCageResponse
+		json.Unmarshal(response.Body.Bytes(), &restoreResponse)
+		assertCage(t, restoreResponse.Cage, 2, apimodels.Active, 0)
+		assert.Nil(t, restoreResponse.Cage.DeletedAt)
+
+		response = sendWithIfMatch(http.MethodGet, cagePath(cage.ID), "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+
+	t.Run("Cage not found", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodPost, cagePath(123456)+"/restore", "", "")
+
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("Invalid cage ID", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodPost, "/cages/invalidID/restore", "", "")
+
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+// listDinosaurIDs collects the IDs of the listed dinosaurs from all pages.
+func listDinosaurIDs(t *testing.T, path string) []uint {
+	ids := []uint{}
+	for cursor := ""; ; {
+		response := sendWithIfMatch(http.MethodGet, withCursor(path, cursor), "{}", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var getResponse apimodels.GetDinosaursResponse
+		json.Unmarshal(response.Body.Bytes(), &getResponse)
+		for _, dinosaur := range getResponse.Dinosaurs {
+			ids = append(ids, dinosaur.ID)
+		}
+		if cursor = getResponse.NextCursor; cursor == "" {
+			return ids
+		}
+	}
+}
+
+// listCageIDs collects the IDs of the listed cages from all pages.
+func listCageIDs(t *testing.T, path string) []uint {
+	ids := []uint{}
+	for cursor := ""; ; {
+		response := sendWithIfMatch(http.MethodGet, withCursor(path, cursor), "{}", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		var getResponse apimodels.GetCagesResponse
+		json.Unmarshal(response.Body.Bytes(), &getResponse)
+		for _, cage := range getResponse.Cages {
+			ids = append(ids, cage.ID)
+		}
+		if cursor = getResponse.NextCursor; cursor == "" {
+			return ids
+		}
+	}
+}
+
+func withCursor(path string, cursor string) string {
+	if cursor == "" {
+		return path
+	}
+	if strings.Contains(path, "?") {
+		return path + "&cursor=" + cursor
+	}
+	return path + "?cursor=" + cursor
+}
+
+func assertCurrentCount(t *testing.T, cageID uint, currentCount int) {
+	response := sendWithIfMatch(http.MethodGet, cagePath(cageID), "", "")
+	var getResponse apimodels.GetCageResponse
+	json.Unmarshal(response.Body.Bytes(), &getResponse)
+	assert.Equal(t, currentCount, getResponse.Cage.CurrentCount)
+}
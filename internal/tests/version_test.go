@@ -0,0 +1,112 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	apimodels "pp-jurassic-park-api/internal/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionedRoutes(t *testing.T) {
+	t.Run("v1 serves the unversioned behaviour", func(t *testing.T) {
+		legacy := sendWithIfMatch(http.MethodGet, cagePath(cageWithTyrannosaurus.ID), "", "")
+		v1 := sendWithIfMatch(http.MethodGet, "/v1"+cagePath(cageWithTyrannosaurus.ID), "", "")
+
+		assert.Equal(t, http.StatusOK, v1.Code)
+		assert.JSONEq(t, legacy.Body.String(), v1.Body.String())
+		assert.Equal(t, legacy.Header().Get("ETag"), v1.Header().Get("ETag"))
+		assert.Empty(t, v1.Header().Get("Deprecation"))
+		assert.Empty(t, v1.Header().Get("Sunset"))
+	})
+
+	t.Run("Unversioned routes are deprecated", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, "/cages?power_status=ACTIVE", "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "@1790812800", response.Header().Get("Deprecation"))
+		assert.Equal(t, "Thu, 01 Apr 2027 00:00:00 GMT", response.Header().Get("Sunset"))
+		assert.Equal(t, `</v1/cages?power_status=ACTIVE>; rel="successor-version"`, response.Header().Get("Link"))
+
+		response = sendWithIfMatch(http.MethodGet, cagePath(123456), "", "")
+		assert.Equal(t, http.StatusNotFound, response.Code)
+		assert.NotEmpty(t, response.Header().Get("Deprecation"))
+	})
+
+	t.Run("v2 returns cage summaries", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, "/v2"+cagePath(cageWithTyrannosaurus.ID), "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.JSONEq(t, fmt.Sprintf(`{"cage": {
+			"id": %[1]d,
+			"capacity": 3,
+			"current_count": 1,
+			"power_status": "ACTIVE",
+			"links": {"self": "/v2/cages/%[1]d", "dinosaurs": "/v2/dinosaurs?cage_id=%[1]d"}
+		}}`, cageWithTyrannosaurus.ID), response.Body.String())
+
+		response = sendWithIfMatch(http.MethodGet, "/v2/cages?limit=1&include_total=true", "", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+		var list apimodels.CageSummariesResponse
+		json.Unmarshal(response.Body.Bytes(), &list)
+		assert.Len(t, list.Cages, 1)
+		assert.NotEmpty(t, list.NextCursor)
+		assert.Greater(t, *list.Total, 1)
+		assert.NotContains(t, response.Body.String(), `"dinosaurs":[`)
+	})
+
+	t.Run("v2 dinosaurs link to their cage", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodGet, "/v2"+dinosaurPath(tyrannosaurus.ID), "", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+		var get apimodels.DinosaurSummaryResponse
+		json.Unmarshal(response.Body.Bytes(), &get)
+		assert.Equal(t, "Terry", get.Dinosaur.Name)
+		assert.Equal(t, apimodels.DinosaurLinks{
+			Self: fmt.Sprintf("/v2/dinosaurs/%d", tyrannosaurus.ID),
+			Cage: fmt.Sprintf("/v2/cages/%d", cageWithTyrannosaurus.ID),
+		}, get.Dinosaur.Links)
+
+		// The cage's link lists its dinosaurs.
+		response = sendWithIfMatch(http.MethodGet, fmt.Sprintf("/v2/dinosaurs?cage_id=%d", cageWithTyrannosaurus.ID), "", "")
+		var list apimodels.DinosaurSummariesResponse
+		json.Unmarshal(response.Body.Bytes(), &list)
+		if assert.Len(t, list.Dinosaurs, 1) {
+			assert.Equal(t, tyrannosaurus.ID, list.Dinosaurs[0].ID)
+		}
+	})
+
+	t.Run("v2 shares the request handling of v1", func(t *testing.T) {
+		response := sendWithIfMatch(http.MethodPost, "/v2/cages", `{"capacity": 2, "power_status": "ACTIVE"}`, "")
+		assert.Equal(t, http.StatusOK, response.Code)
+		var created apimodels.CageSummaryResponse
+		json.Unmarshal(response.Body.Bytes(), &created)
+		cageIDsToCleanup = append(cageIDsToCleanup, created.Cage.ID)
+		assert.Equal(t, `"1.1"`, response.Header().Get("ETag"))
+
+		response = sendWithIfMatch(http.MethodPost, "/v2/dinosaurs",
+			fmt.Sprintf(`{"name": "Bo", "species": "Stegosaurus", "cage_id": %d}`, created.Cage.ID), "")
+		assert.Equal(t, http.StatusOK, response.Code)
+		var added apimodels.DinosaurSummaryResponse
+		json.Unmarshal(response.Body.Bytes(), &added)
+		dinosaurIDsToCleanup = append(dinosaurIDsToCleanup, added.Dinosaur.ID)
+		assert.Equal(t, fmt.Sprintf("/v2/cages/%d", created.Cage.ID), added.Dinosaur.Links.Cage)
+
+		response = sendWithIfMatch(http.MethodPost, "/v2/dinosaurs?dry_run=true",
+			fmt.Sprintf(`{"name": "Rex", "species": "Tyrannosaurus", "cage_id": %d}`, created.Cage.ID), "")
+		var dryRun apimodels.DryRunDinosaurSummaryResponse
+		json.Unmarshal(response.Body.Bytes(), &dryRun)
+		assert.Equal(t, []apimodels.ErrorCode{apimodels.SpeciesConflict}, violatedRules(dryRun.Violations))
+
+		response = sendWithIfMatch(http.MethodPatch, "/v2"+cagePath(created.Cage.ID), `{"capacity": 0}`, "")
+		assertProblem(t, response, http.StatusBadRequest, apimodels.ValidationFailed)
+
+		history := getHistory(t, "/v2"+cagePath(created.Cage.ID)+"/history")
+		if assert.Len(t, history.History, 1) {
+			assert.Equal(t, apimodels.Created, history.History[0].Action)
+		}
+	})
+}